@@ -0,0 +1,117 @@
+// Command assetgen draws the game's sprites into PNG files.
+//
+// Usage:
+//
+//	assetgen [-out dir] [-palette name] [-scale n] [-mountains] [-seed n] [-sounds dir]
+//
+// The same seed and palette always produce byte-identical files; the
+// shipped assets are regenerated with `go generate ./game`. Mountains are
+// generated by the game at runtime from the run seed, so they are only
+// written when asked for, to preview a seed's skyline. -sounds also writes
+// the synthesized sound effects and music as WAVs.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"doodlejump/game/soundgen"
+	"doodlejump/game/spritegen"
+)
+
+// savePNG encodes img into name inside dir
+func savePNG(dir, name string, img image.Image) error {
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("encode %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+func paletteNames() []string {
+	names := make([]string, 0, len(spritegen.Palettes))
+	for name := range spritegen.Palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func main() {
+	out := flag.String("out", ".", "directory to write the PNGs to")
+	paletteName := flag.String("palette", "classic", fmt.Sprintf("color palette: %v", paletteNames()))
+	scale := flag.Int("scale", 1, "integer upscale factor for every sprite")
+	mountains := flag.Bool("mountains", false, "also write the mountain layers")
+	seed := flag.Int64("seed", 0, "run seed to draw the mountain layers for")
+	sounds := flag.String("sounds", "", "also write the sound clips as WAVs into this directory")
+	flag.Parse()
+
+	palette, ok := spritegen.Palettes[*paletteName]
+	if !ok {
+		log.Fatalf("unknown palette %q; want one of %v", *paletteName, paletteNames())
+	}
+	if *scale < 1 {
+		log.Fatalf("scale must be at least 1, got %d", *scale)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	sprites := map[string]*image.RGBA{
+		"player.png":     palette.Player(),
+		"platform.png":   palette.Platform(),
+		"bird_left.png":  palette.BirdLeft(),
+		"bird_right.png": palette.BirdRight(),
+		"cloud.png":      palette.Cloud(),
+
+		"bird_perched_left.png":  palette.BirdPerchedLeft(),
+		"bird_perched_right.png": palette.BirdPerchedRight(),
+	}
+	for _, skin := range spritegen.PlatformSkins {
+		sprites["platform_"+skin+".png"] = palette.PlatformSkin(skin)
+	}
+
+	// Each mountain layer gets its own stream, matching the game's generateMountains
+	if *mountains {
+		for i, baseColor := range palette.Mountains {
+			rng := rand.New(rand.NewSource(*seed + int64(i)))
+			sprites[fmt.Sprintf("mountains_%d.png", i)] = spritegen.Mountain(
+				spritegen.MountainWidth,
+				spritegen.MountainHeight,
+				baseColor,
+				spritegen.MountainRoughness(i),
+				rng,
+			)
+		}
+	}
+
+	for name, img := range sprites {
+		if err := savePNG(*out, name, spritegen.Scale(img, *scale)); err != nil {
+			log.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	if *sounds != "" {
+		if err := os.MkdirAll(*sounds, 0o755); err != nil {
+			log.Fatal(err)
+		}
+		for name, gen := range soundgen.Clips {
+			path := filepath.Join(*sounds, name+".wav")
+			if err := os.WriteFile(path, soundgen.EncodeWAV(gen()), 0o644); err != nil {
+				log.Fatalf("write %s: %v", path, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Command bench measures the game's simulation and rendering on scripted
+// scenarios, to check performance work against numbers.
+//
+// Usage:
+//
+//	bench [-frames n] [-warmup n] [-scenario names] [-render=false] [-list]
+//
+// Every scenario is played by the bot on the same seed for a fixed number
+// of frames, first headless, then drawn in a window with vsync off. Each
+// pass reports milliseconds, allocations and bytes allocated per frame.
+// -list prints the scenarios and exits. Rendering needs a window, which
+// opens for the run and closes once every scenario is measured.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"doodlejump/game"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// result is one measured pass of a scenario
+type result struct {
+	scenario string
+	mode     string
+	frames   int
+	elapsed  time.Duration
+	allocs   uint64
+	bytes    uint64
+}
+
+// meter measures the frames between start and stop
+type meter struct {
+	began  time.Time
+	before runtime.MemStats
+}
+
+func (m *meter) start() {
+	runtime.GC()
+	runtime.ReadMemStats(&m.before)
+	m.began = time.Now()
+}
+
+func (m *meter) stop(scenario, mode string, frames int) result {
+	elapsed := time.Since(m.began)
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	return result{
+		scenario: scenario,
+		mode:     mode,
+		frames:   frames,
+		elapsed:  elapsed,
+		allocs:   after.Mallocs - m.before.Mallocs,
+		bytes:    after.TotalAlloc - m.before.TotalAlloc,
+	}
+}
+
+// simulate plays scenario headless for warmup unmeasured frames, then
+// frames measured ones
+func simulate(scenario string, warmup, frames int) (result, error) {
+	g, err := game.NewBench(scenario, true)
+	if err != nil {
+		return result{}, err
+	}
+	for range warmup {
+		if err := g.BenchTick(); err != nil {
+			return result{}, err
+		}
+	}
+	var m meter
+	m.start()
+	for range frames {
+		if err := g.BenchTick(); err != nil {
+			return result{}, err
+		}
+	}
+	return m.stop(scenario, "sim", frames), nil
+}
+
+// renderer is the game loop of the rendering passes: it runs and draws
+// each scenario in turn, then quits
+type renderer struct {
+	scenarios []string
+	warmup    int
+	frames    int
+	results   []result
+	err       error
+
+	g     *game.Game
+	frame int // Frames drawn of the current scenario, warmup included
+	m     meter
+}
+
+func (r *renderer) Update() error {
+	if r.g == nil {
+		if len(r.scenarios) == 0 {
+			return ebiten.Termination
+		}
+		if r.g, r.err = game.NewBench(r.scenarios[0], false); r.err != nil {
+			return r.err
+		}
+		r.frame = 0
+	}
+	if r.frame == r.warmup {
+		r.m.start()
+	}
+	return r.g.BenchTick()
+}
+
+func (r *renderer) Draw(screen *ebiten.Image) {
+	if r.g == nil {
+		return
+	}
+	r.g.Draw(screen)
+	r.frame++
+	if r.frame == r.warmup+r.frames {
+		r.results = append(r.results, r.m.stop(r.scenarios[0], "render", r.frames))
+		r.scenarios = r.scenarios[1:]
+		r.g = nil
+	}
+}
+
+func (r *renderer) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return game.ScreenWidth, game.ScreenHeight
+}
+
+// report prints the results as a table
+func report(results []result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "scenario\tpass\tframes\tms/frame\tallocs/frame\tKB/frame\t")
+	for _, r := range results {
+		n := float64(r.frames)
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.3f\t%.1f\t%.2f\t\n", r.scenario, r.mode, r.frames,
+			float64(r.elapsed.Microseconds())/1000/n, float64(r.allocs)/n, float64(r.bytes)/1024/n)
+	}
+	w.Flush()
+}
+
+func main() {
+	frames := flag.Int("frames", 600, "frames measured per scenario and pass")
+	warmup := flag.Int("warmup", 60, "frames played before measuring")
+	only := flag.String("scenario", "", "comma-separated scenarios to run; empty runs them all")
+	render := flag.Bool("render", true, "also measure with rendering, in a window")
+	list := flag.Bool("list", false, "list the scenarios and exit")
+	flag.Parse()
+
+	if *list {
+		for _, s := range game.BenchScenarios() {
+			fmt.Printf("%-12s %s\n", s[0], s[1])
+		}
+		return
+	}
+	if *frames < 1 || *warmup < 0 {
+		log.Fatalf("need at least 1 frame and no negative warmup, got -frames %d -warmup %d", *frames, *warmup)
+	}
+
+	var scenarios []string
+	if *only != "" {
+		scenarios = strings.Split(*only, ",")
+	} else {
+		for _, s := range game.BenchScenarios() {
+			scenarios = append(scenarios, s[0])
+		}
+	}
+
+	var results []result
+	for _, s := range scenarios {
+		r, err := simulate(s, *warmup, *frames)
+		if err != nil {
+			log.Fatal(err)
+		}
+		results = append(results, r)
+	}
+
+	if *render {
+		r := &renderer{scenarios: scenarios, warmup: *warmup, frames: *frames}
+		ebiten.SetWindowSize(game.ScreenWidth, game.ScreenHeight)
+		ebiten.SetWindowTitle("Doodle Jump bench")
+		ebiten.SetVsyncEnabled(false)
+		ebiten.SetTPS(ebiten.SyncWithFPS) // One tick per frame drawn, as fast as frames go
+		if err := ebiten.RunGameWithOptions(r, &ebiten.RunGameOptions{SkipTaskbar: true}); err != nil {
+			log.Fatal(err)
+		}
+		if r.err != nil {
+			log.Fatal(r.err)
+		}
+		results = append(results, r.results...)
+	}
+	report(results)
+}
@@ -0,0 +1,180 @@
+// Command godlejump is the desktop launcher for the game
+package main
+
+import (
+	"flag"
+	"log"
+	"strconv"
+	"time"
+
+	"doodlejump/game"
+	"doodlejump/game/ghostnet"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// window is what the launcher runs: a game, or a split-screen race of two
+type window interface {
+	ebiten.Game
+	WindowSize() (int, int)
+	PlaceWindow(monitor string) error
+	Fullscreen() bool
+}
+
+func main() {
+	scale := flag.Float64("scale", 0, "window size multiplier; 0 reopens the window at its last size, or at 2x the first time")
+	monitor := flag.String("monitor", "", "monitor to open the window on, by number from 1 or by name; empty keeps the last one")
+	seed := flag.Int64("seed", 0, "world seed, shown after every run so friends can race the same world; 0 picks a random one")
+	profile := flag.String("profile", game.DefaultProfile, "save profile to load")
+	edges := flag.String("edges", "wrap", "screen edge policy: wrap, bounce or death")
+	levelFile := flag.String("level", "", "custom level file to play")
+	fullscreen := flag.Bool("fullscreen", false, "start in fullscreen")
+	vsync := flag.Bool("vsync", true, "sync frames to the display refresh rate")
+	lowMemory := flag.Bool("lowmem", game.SmallMemory(), "low-memory profile: half-resolution sprites, unlit mountains and capped replays; on by default under 2 GB of RAM")
+	hardcore := flag.Bool("hardcore", false, "play Hardcore: keep warm or freeze at altitude")
+	zen := flag.Bool("zen", false, "play Zen: no birds or breaking platforms, only the height reached counts")
+	sandbox := flag.Bool("sandbox", false, "start a sandbox with an entity palette instead of a run")
+	split := flag.Bool("split", false, "split-screen race: two players side by side on one seed, first to the top wins")
+	coop := flag.Bool("coop", false, "co-op: one player flies from the keyboard, the other aims and shoots with the mouse or a second gamepad")
+	tournament := flag.String("tournament", "", "comma-separated names of 2-8 players for a local tournament on this week's seed")
+	hotseat := flag.String("hotseat", "", "comma-separated pass-and-play players, each optionally with *score-multiplier and +shields")
+	tuning := flag.String("tuning", "", "JSON file of gameplay tuning values to play with instead of the defaults")
+	ghost := flag.String("ghost", "", "host:port of a friend to race as ghosts on the same -seed")
+	ghostPort := flag.Int("ghost-port", 7777, "UDP port to receive the friend's ghost on")
+	leaderboard := flag.String("leaderboard", "", "URL of an online leaderboard service to submit runs to and show the global top list from")
+	idle := flag.Bool("idle", false, "screensaver: a bot climbs endlessly with the HUD hidden; any key quits")
+	kiosk := flag.Bool("kiosk", false, "arcade cabinet or event booth: coin-operated timed runs, an attract demo and a high-score table; the game can't be quit")
+	kioskTime := flag.Duration("kiosk-time", game.KioskRunTime, "time limit of each kiosk run")
+	replay := flag.String("replay", "", "replay file to watch, such as the replay.json kept next to the save")
+	importSeeds := flag.String("import-seeds", "", "add the seeds of a text list to the profile's bookmarks")
+	exportSeeds := flag.String("export-seeds", "", "write the profile's bookmarked seeds to a text list and exit")
+	summary := flag.String("summary", "", "write a summary of the session to this file on quit: HTML if it ends in .html, markdown otherwise")
+	summaryOpen := flag.Bool("summary-open", false, "open the -summary file once it is written")
+	summaryWebhook := flag.String("summary-webhook", "", "URL to post the session summary to as JSON on quit")
+	flag.Parse()
+
+	if *importSeeds != "" {
+		n, err := game.ImportBookmarks(*profile, *importSeeds)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Imported %d seeds from %s", n, *importSeeds)
+	}
+	if *exportSeeds != "" {
+		if err := game.ExportBookmarks(*profile, *exportSeeds); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	edgePolicy, err := game.ParseEdgePolicy(*edges)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []game.Option{
+		game.WithRenderScale(*scale),
+		game.WithProfile(*profile),
+		game.WithEdgePolicy(edgePolicy),
+		game.WithLevel(*levelFile),
+	}
+	if *seed != 0 {
+		opts = append(opts, game.WithSeed(*seed))
+	}
+	if *lowMemory {
+		opts = append(opts, game.WithLowMemory())
+	}
+	if *tuning != "" {
+		c, err := game.LoadGameConfig(*tuning)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, game.WithGameConfig(c))
+	}
+	if *hardcore {
+		opts = append(opts, game.WithHardcore())
+	}
+	if *sandbox {
+		opts = append(opts, game.WithSandbox())
+	}
+	if *leaderboard != "" {
+		opts = append(opts, game.WithOnlineLeaderboard(*leaderboard))
+	}
+	if *coop {
+		opts = append(opts, game.WithCoop())
+	}
+	if *summary != "" || *summaryWebhook != "" {
+		opts = append(opts, game.WithSessionSummary(game.SessionSummary{Path: *summary, Open: *summaryOpen, Webhook: *summaryWebhook}))
+	}
+	if *ghost != "" {
+		if *seed == 0 {
+			log.Fatal("-ghost needs a -seed, the same on both sides, so the ghost climbs your world")
+		}
+		link, err := ghostnet.Dial(":"+strconv.Itoa(*ghostPort), *ghost)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer link.Close()
+		opts = append(opts, game.WithGhost(link))
+	}
+	if *tournament != "" {
+		tSeed := game.WeeklySeed(time.Now())
+		if *seed != 0 {
+			tSeed = *seed
+		}
+		t, err := game.ParseTournament(*tournament, tSeed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, game.WithTournament(t))
+	}
+	if *hotseat != "" {
+		hSeed := time.Now().UnixNano()
+		if *seed != 0 {
+			hSeed = *seed
+		}
+		h, err := game.ParseHotseat(*hotseat, hSeed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, game.WithHotseat(h))
+	}
+	if *zen {
+		opts = append(opts, game.WithMode(game.ModeZen))
+	}
+	if *kiosk {
+		opts = append(opts, game.WithKiosk(game.NewKiosk(*kioskTime)))
+	}
+	if *idle {
+		opts = append(opts, game.WithIdle())
+		ebiten.SetScreenClearedEveryFrame(false) // Idle mode skips frames and keeps the last one up
+	}
+	var run window
+	if *split {
+		run = game.NewRace(opts...)
+	} else {
+		g := game.NewGame(opts...)
+		if *replay != "" {
+			r, err := game.LoadReplay(*replay)
+			if err != nil {
+				log.Fatal(err)
+			}
+			g.WatchReplay(r)
+		}
+		run = g
+	}
+
+	ebiten.SetWindowSize(run.WindowSize())
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	if err := run.PlaceWindow(*monitor); err != nil {
+		log.Fatal(err)
+	}
+	ebiten.SetWindowTitle("Doodle Jump")
+	ebiten.SetFullscreen(*fullscreen || *kiosk || run.Fullscreen())
+	ebiten.SetVsyncEnabled(*vsync)
+	ebiten.SetWindowClosingHandled(true) // The game saves a run in progress and the window's place before quitting
+
+	if err := ebiten.RunGame(run); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,50 @@
+// Command levellint validates custom level, theme and spawn-table files
+// with the same checks the game runs when loading them.
+//
+// Usage:
+//
+//	levellint [-q] file.json...
+//
+// Every problem is printed as file: field: message. The exit status is 1
+// when any file has problems and 2 on bad usage.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"doodlejump/game"
+	"doodlejump/game/level"
+)
+
+func main() {
+	quiet := flag.Bool("q", false, "only print problems, not files that pass")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: levellint [-q] file.json...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, path := range flag.Args() {
+		issues := level.Lint(path, game.LevelRules())
+		if len(issues) == 0 {
+			if !*quiet {
+				fmt.Printf("%s: ok\n", path)
+			}
+			continue
+		}
+		failed = true
+		for _, issue := range issues {
+			fmt.Println(issue.Error())
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
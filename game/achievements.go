@@ -0,0 +1,97 @@
+package game
+
+import (
+	"image/color"
+
+	"doodlejump/game/achievements"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Achievement toast parameters
+const (
+	ToastSeconds = 3.5 // How long a toast stays up
+	ToastSlide   = 0.3 // Seconds of sliding in and out
+	toastWidth   = 180
+	toastHeight  = 34
+)
+
+// toast is an unlocked achievement on its way across the screen
+type toast struct {
+	achievement achievements.Achievement
+	timer       float64
+}
+
+// countsAchievements reports whether this run can unlock achievements:
+// the screensaver's, the sandbox's, races and replayed runs can't
+func (g *Game) countsAchievements() bool {
+	return !g.opts.idle && !g.opts.sandbox && g.replayView == nil && g.opts.race == nil
+}
+
+// recordAchievement counts e toward the run's achievements. It is
+// subscribed to the run's event bus.
+func (g *Game) recordAchievement(e Event) {
+	switch e.Kind {
+	case EventKill:
+		g.achievementRun.Kills++
+	case EventBoostPickup:
+		g.achievementRun.Boosts++
+	case EventNewDay:
+		g.achievementRun.Days++
+	}
+}
+
+// checkAchievements judges the run so far, once per tick
+func (g *Game) checkAchievements() {
+	if !g.countsAchievements() {
+		return
+	}
+	g.achievementRun.Score = g.score
+	g.achievementRun.Altitude = g.altitude()
+	g.toast(g.save.Achievements.Check(g.achievementRun)) // Written with the run
+}
+
+// finishAchievements folds the run that just ended into the totals
+func (g *Game) finishAchievements() {
+	if !g.countsAchievements() {
+		return
+	}
+	g.toast(g.save.Achievements.Finish(g.achievementRun))
+}
+
+// toast queues a toast for every achievement in earned
+func (g *Game) toast(earned []achievements.Achievement) {
+	g.session.achievements = append(g.session.achievements, earned...)
+	for _, a := range earned {
+		g.toasts = append(g.toasts, toast{achievement: a, timer: ToastSeconds})
+		g.feedback(FeedbackBoostPickup)
+	}
+}
+
+// updateToasts counts the toast on screen down; the next one follows it
+func (g *Game) updateToasts() {
+	if len(g.toasts) == 0 {
+		return
+	}
+	if g.toasts[0].timer -= g.dt; g.toasts[0].timer <= 0 {
+		g.toasts = g.toasts[1:]
+	}
+}
+
+// drawToast slides the current toast in from the right edge and back out
+func (g *Game) drawToast(screen *ebiten.Image) {
+	if len(g.toasts) == 0 {
+		return
+	}
+	t := g.toasts[0]
+	shown := min(1, t.timer/ToastSlide, (ToastSeconds-t.timer)/ToastSlide)
+	if g.save.Settings.ReducedMotion {
+		shown = 1
+	}
+	x := ScreenWidth - (toastWidth+8)*shown
+	y := 8.0
+	drawRect(screen, x, y, toastWidth, toastHeight, color.RGBA{40, 40, 60, 230})
+	drawRect(screen, x, y, 4, toastHeight, color.RGBA{255, 220, 100, 255})
+	drawText(screen, "Achievement: "+t.achievement.Name, x+10, y+4, TextSmall, color.RGBA{255, 220, 100, 255})
+	drawText(screen, t.achievement.Description, x+10, y+4+textLineHeight, TextSmall, color.RGBA{245, 240, 225, 255})
+}
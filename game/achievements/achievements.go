@@ -0,0 +1,82 @@
+// Package achievements defines the game's milestones and tracks which
+// ones a player has unlocked. It knows nothing about the game loop: the
+// game reports what happens in a run and shows whatever Check unlocks.
+package achievements
+
+import "time"
+
+// Run is what has happened in the run in progress
+type Run struct {
+	Score    int `json:"score"`
+	Altitude int `json:"altitude"` // Meters
+	Kills    int `json:"kills"`    // Birds shot
+	Boosts   int `json:"boosts"`   // Boosts collected
+	Days     int `json:"days"`     // Full day cycles survived
+}
+
+// State is a player's progress, persisted with their save
+type State struct {
+	Unlocked map[string]time.Time `json:"unlocked"` // When each unlocked achievement was earned, by ID
+	Kills    int                  `json:"kills"`    // Birds shot in finished runs
+	Runs     int                  `json:"runs"`     // Runs finished
+}
+
+// Achievement is one milestone. Done judges it on the player's progress
+// and the run in progress.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Done        func(s *State, r Run) bool
+}
+
+// All are every achievement in display order
+var All = []Achievement{
+	{"score_500", "Off the Ground", "Score 500 in one run",
+		func(s *State, r Run) bool { return r.Score >= 500 }},
+	{"score_5000", "High Flyer", "Score 5000 in one run",
+		func(s *State, r Run) bool { return r.Score >= 5000 }},
+	{"altitude_1000", "Kilometer Club", "Climb 1000m",
+		func(s *State, r Run) bool { return r.Altitude >= 1000 }},
+	{"kills_run_10", "Bird Strike", "Shoot 10 birds in one run",
+		func(s *State, r Run) bool { return r.Kills >= 10 }},
+	{"kills_50", "Sharpshooter", "Shoot 50 birds",
+		func(s *State, r Run) bool { return s.Kills+r.Kills >= 50 }},
+	{"full_day", "Around the Clock", "Survive a full day cycle",
+		func(s *State, r Run) bool { return r.Days >= 1 }},
+	{"no_boost", "Purist", "Score 1000 without using a boost",
+		func(s *State, r Run) bool { return r.Score >= 1000 && r.Boosts == 0 }},
+	{"runs_25", "Regular", "Finish 25 runs",
+		func(s *State, r Run) bool { return s.Runs >= 25 }},
+}
+
+// Has reports whether achievement id is unlocked
+func (s *State) Has(id string) bool {
+	_, ok := s.Unlocked[id]
+	return ok
+}
+
+// Check unlocks every locked achievement r earns and returns them
+func (s *State) Check(r Run) []Achievement {
+	var earned []Achievement
+	for _, a := range All {
+		if s.Has(a.ID) || !a.Done(s, r) {
+			continue
+		}
+		if s.Unlocked == nil {
+			s.Unlocked = map[string]time.Time{}
+		}
+		s.Unlocked[a.ID] = time.Now()
+		earned = append(earned, a)
+	}
+	return earned
+}
+
+// Finish folds the run that just ended into the totals, returning what
+// that unlocks
+func (s *State) Finish(r Run) []Achievement {
+	earned := s.Check(r)
+	s.Kills += r.Kills
+	s.Runs++
+	return append(earned, s.Check(Run{})...)
+}
@@ -0,0 +1,64 @@
+package game
+
+import "math"
+
+// Aim assist strengths
+const (
+	AimAssistOff = iota
+	AimAssistLow
+	AimAssistHigh
+)
+
+// aimAssistCones are the half-angles (radians) within which a bird is
+// snapped to, per assist strength
+var aimAssistCones = [...]float64{
+	AimAssistOff:  0,
+	AimAssistLow:  10 * math.Pi / 180,
+	AimAssistHigh: 25 * math.Pi / 180,
+}
+
+// aimAssistLabel names strength for the settings screen. Strengths out of
+// range aim like AimAssistOff.
+func aimAssistLabel(strength int) string {
+	switch strength {
+	case AimAssistLow:
+		return "Low"
+	case AimAssistHigh:
+		return "High"
+	}
+	return "Off"
+}
+
+// aimBullet points b at the nearest bird inside the assist cone around its
+// firing direction. Bullets keep their speed; only the direction changes.
+func (g *Game) aimBullet(b *Bullet) {
+	strength := g.save.Settings.AimAssist
+	if strength <= AimAssistOff || strength >= len(aimAssistCones) {
+		return
+	}
+	cone := aimAssistCones[strength]
+
+	bestDist := math.Inf(1)
+	var bestDX, bestDY float64
+	for _, bird := range g.birds {
+		// Aim at the bird's center
+		dx := bird.X + BirdWidth/2 - b.X
+		dy := bird.Y + BirdHeight/2 - b.Y
+		if dx*float64(b.Direction) <= 0 {
+			continue // Behind the shooter
+		}
+		angle := math.Atan2(math.Abs(dy), math.Abs(dx))
+		if angle > cone {
+			continue
+		}
+		if d := math.Hypot(dx, dy); d < bestDist {
+			bestDist, bestDX, bestDY = d, dx, dy
+		}
+	}
+	if math.IsInf(bestDist, 1) {
+		return
+	}
+
+	b.Speed = g.tuning.BulletSpeed * math.Abs(bestDX) / bestDist
+	b.SpeedY = g.tuning.BulletSpeed * bestDY / bestDist
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"doodlejump/game/audio"
+	"doodlejump/game/soundgen"
+)
+
+// Ambient soundscape parameters
+const (
+	AmbientLoopSeconds = 6.0  // Length of each synthesized ambient loop
+	AmbientFadeSpeed   = 0.25 // Gain change per second while crossfading
+	AmbientPhaseFade   = 0.08 // Portion of the day cycle spent fading a layer in/out
+)
+
+// Ambient layers
+const (
+	AmbientBirdsong = iota
+	AmbientCrickets
+	AmbientRain
+	AmbientWind
+	ambientLayerCount
+)
+
+// ambientPCM holds the synthesized loops; built once, shared across runs
+var (
+	ambientPCM     [ambientLayerCount][]byte
+	ambientPCMOnce sync.Once
+)
+
+// buildAmbientPCM synthesizes every ambient loop
+func buildAmbientPCM() {
+	rng := rand.New(rand.NewSource(7))
+
+	// Morning birdsong: scattered chirps of quick rising sweeps
+	birds := soundgen.Silence(AmbientLoopSeconds)
+	for i := 0; i < 14; i++ {
+		base := 2200 + rng.Float64()*1800
+		chirp := soundgen.Concat(
+			soundgen.Tone(soundgen.WaveSine, base, base*1.4, 0.06),
+			soundgen.Tone(soundgen.WaveSine, base*1.2, base*1.6, 0.05),
+		)
+		soundgen.MixInto(birds, chirp, rng.Float64()*AmbientLoopSeconds, 0.25)
+	}
+	ambientPCM[AmbientBirdsong] = birds
+
+	// Night crickets: high trills pulsed in groups
+	crickets := soundgen.Silence(AmbientLoopSeconds)
+	for t := 0.0; t < AmbientLoopSeconds; t += 0.6 + rng.Float64()*0.4 {
+		for p := 0; p < 4; p++ {
+			soundgen.MixInto(crickets, soundgen.Tone(soundgen.WaveTriangle, 4500, 4400, 0.03), t+float64(p)*0.05, 0.12)
+		}
+	}
+	ambientPCM[AmbientCrickets] = crickets
+
+	ambientPCM[AmbientRain] = soundgen.FilteredNoise(AmbientLoopSeconds, 0.35, 0, 0xBEEF)
+	ambientPCM[AmbientWind] = soundgen.FilteredNoise(AmbientLoopSeconds, 0.985, 0.5, 0xF00D)
+}
+
+// Ambience crossfades looping background layers to match the sky
+type Ambience struct {
+	layers [ambientLayerCount]*audio.Loop
+}
+
+// newAmbience creates silent loops for every ambient layer
+func newAmbience(m *audio.Mixer) *Ambience {
+	ambientPCMOnce.Do(buildAmbientPCM)
+
+	a := &Ambience{}
+	for i := range a.layers {
+		a.layers[i] = m.NewLoop(ambientPCM[i], audio.ChannelMusic)
+	}
+	return a
+}
+
+// phaseWeight is 1 while timeOfDay is inside [start, end], fading linearly
+// to 0 over AmbientPhaseFade outside it. Ranges may wrap past midnight.
+func phaseWeight(timeOfDay, start, end float64) float64 {
+	if end < start {
+		end++
+		if timeOfDay < start-AmbientPhaseFade {
+			timeOfDay++
+		}
+	}
+	switch {
+	case timeOfDay >= start && timeOfDay <= end:
+		return 1
+	case timeOfDay < start:
+		return math.Max(0, 1-(start-timeOfDay)/AmbientPhaseFade)
+	default:
+		return math.Max(0, 1-(timeOfDay-end)/AmbientPhaseFade)
+	}
+}
+
+// targets computes the wanted gain of each layer for the current sky
+func (a *Ambience) targets(timeOfDay float64, weather int) [ambientLayerCount]float64 {
+	var t [ambientLayerCount]float64
+
+	// Birds sing from sunrise through the morning, crickets through the night
+	t[AmbientBirdsong] = phaseWeight(timeOfDay, SunriseEnd-0.1, DayStart+0.2) * 0.8
+	t[AmbientCrickets] = phaseWeight(timeOfDay, SunsetEnd, SunriseStart+0.1) * 0.6
+
+	switch weather {
+	case WeatherRain:
+		t[AmbientRain] = 0.7
+		t[AmbientBirdsong] *= 0.3 // Birds shelter from the rain
+		t[AmbientCrickets] *= 0.5
+	case WeatherStorm:
+		t[AmbientRain] = 1
+		t[AmbientWind] = 0.5
+		t[AmbientBirdsong] = 0 // Every bird has taken cover
+		t[AmbientCrickets] *= 0.3
+	case WeatherSnow:
+		t[AmbientWind] = 0.6
+		t[AmbientBirdsong] *= 0.2
+		t[AmbientCrickets] = 0 // Too cold
+	}
+	return t
+}
+
+// Update eases each layer dt seconds toward its target gain
+func (a *Ambience) Update(timeOfDay float64, weather int, dt float64) {
+	targets := a.targets(timeOfDay, weather)
+	step := AmbientFadeSpeed * dt
+	for i, l := range a.layers {
+		gain := l.Gain()
+		if gain < targets[i] {
+			gain = math.Min(targets[i], gain+step)
+		} else if gain > targets[i] {
+			gain = math.Max(targets[i], gain-step)
+		}
+		if gain != l.Gain() {
+			l.SetGain(gain)
+		}
+	}
+}
+
+// Stop silences every layer immediately
+func (a *Ambience) Stop() {
+	for _, l := range a.layers {
+		l.SetGain(0)
+	}
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"doodlejump/game/audio"
+	"doodlejump/game/i18n"
+	"doodlejump/game/soundgen"
+)
+
+// Announcer parameters
+const (
+	PixelsPerMeter      = 10.0 // Camera pixels per meter of altitude
+	AltitudeMilestone   = 500  // Meters between altitude announcements
+	AnnouncementSeconds = 2.0  // How long a subtitle stays on screen
+)
+
+// announcement is a queued announcer line
+type announcement struct {
+	text  string
+	sound *audio.Sound
+}
+
+// Announcer reads out milestones with a stinger and a localized subtitle
+type Announcer struct {
+	queue        []announcement
+	current      string
+	timer        float64
+	nextAltitude int // Next altitude milestone in meters
+	biome        int // Index in biomes of the highest biome reached
+}
+
+// Announcer stingers; the announcer is synthesized, so each line class gets a jingle
+var (
+	stingerMilestone = &audio.Sound{
+		PCM: soundgen.Concat(
+			soundgen.Tone(soundgen.WaveSquare, 523, 523, 0.1),
+			soundgen.Tone(soundgen.WaveSquare, 659, 659, 0.1),
+			soundgen.Tone(soundgen.WaveSquare, 784, 784, 0.25),
+		),
+		Channel: audio.ChannelUI,
+		Volume:  0.5,
+	}
+	stingerWarning = &audio.Sound{
+		PCM:     soundgen.Concat(soundgen.Tone(soundgen.WaveSquare, 660, 440, 0.15), soundgen.Tone(soundgen.WaveSquare, 440, 330, 0.2)),
+		Channel: audio.ChannelUI,
+		Volume:  0.5,
+	}
+)
+
+// newAnnouncer creates an announcer waiting for the first milestone
+func newAnnouncer() *Announcer {
+	return &Announcer{nextAltitude: AltitudeMilestone}
+}
+
+// altitude returns how high the player has climbed, in meters
+func (g *Game) altitude() int {
+	return int(g.camera / PixelsPerMeter)
+}
+
+// announce queues a line unless the announcer is disabled
+func (g *Game) announce(sound *audio.Sound, key string, args ...any) {
+	if !g.save.Settings.Announcer {
+		return
+	}
+	g.announcer.queue = append(g.announcer.queue, announcement{
+		text:  i18n.T(key, args...),
+		sound: sound,
+	})
+}
+
+// Update checks milestones and advances the subtitle queue
+func (a *Announcer) Update(g *Game) {
+	for g.altitude() >= a.nextAltitude {
+		g.addScore(ScoreBonuses, MilestoneScore)
+		g.events.Publish(Event{Kind: EventMilestone, Value: a.nextAltitude, Points: MilestoneScore})
+		g.announce(stingerMilestone, "announce.altitude", a.nextAltitude)
+		a.nextAltitude += AltitudeMilestone
+	}
+	for a.biome+1 < len(biomes) && float64(g.altitude()) >= biomes[a.biome+1].From {
+		a.biome++
+		g.events.Publish(Event{Kind: EventBiome, Value: a.biome})
+	}
+
+	if a.timer > 0 {
+		a.timer -= g.dt
+		if a.timer <= 0 {
+			a.current = ""
+		}
+		return
+	}
+
+	if len(a.queue) > 0 {
+		next := a.queue[0]
+		a.queue = a.queue[1:]
+		a.current = next.text
+		a.timer = AnnouncementSeconds
+		if g.audio != nil {
+			g.audio.Play(next.sound)
+		}
+	}
+}
+
+// announcerLines shows the current subtitle
+func announcerLines(g *Game) []hudLine {
+	if g.announcer.current == "" {
+		return nil
+	}
+	return []hudLine{{g.announcer.current, TextLarge}}
+}
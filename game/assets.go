@@ -0,0 +1,95 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"strings"
+
+	"doodlejump/game/spritegen"
+)
+
+//go:generate go run ../cmd/assetgen -out assets -palette classic -sounds audio/sounds
+
+// assetFallbacks maps embedded asset paths to procedural placeholders
+// drawn with the same generator that produced the shipped PNGs
+var assetFallbacks = map[string]func() image.Image{
+	"assets/player.png":     func() image.Image { return spritegen.Classic.Player() },
+	"assets/platform.png":   func() image.Image { return spritegen.Classic.Platform() },
+	"assets/bird_left.png":  func() image.Image { return spritegen.Classic.BirdLeft() },
+	"assets/bird_right.png": func() image.Image { return spritegen.Classic.BirdRight() },
+	"assets/cloud.png":      func() image.Image { return spritegen.Classic.Cloud() },
+
+	"assets/bird_perched_left.png":  func() image.Image { return spritegen.Classic.BirdPerchedLeft() },
+	"assets/bird_perched_right.png": func() image.Image { return spritegen.Classic.BirdPerchedRight() },
+
+	"assets/platform_wooden.png":   func() image.Image { return spritegen.Classic.PlatformSkin(spritegen.SkinWooden) },
+	"assets/platform_icy.png":      func() image.Image { return spritegen.Classic.PlatformSkin(spritegen.SkinIcy) },
+	"assets/platform_metallic.png": func() image.Image { return spritegen.Classic.PlatformSkin(spritegen.SkinMetallic) },
+	"assets/platform_cloud.png":    func() image.Image { return spritegen.Classic.PlatformSkin(spritegen.SkinCloud) },
+}
+
+// assetErrors records every asset that had to be replaced by a placeholder, keyed by path
+var assetErrors = map[string]error{}
+
+// AssetErrors reports assets that failed to load and were replaced by placeholders
+func AssetErrors() []error {
+	errs := make([]error, 0, len(assetErrors))
+	for _, err := range assetErrors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// decodeAsset reads and decodes an image from embedded assets
+func decodeAsset(path string) (image.Image, error) {
+	imgBytes, err := gameAssets.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read embedded image %s: %w", path, err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(imgBytes))
+	if err != nil {
+		return nil, fmt.Errorf("decode image %s: %w", path, err)
+	}
+	return img, nil
+}
+
+// placeholderImage returns a generated stand-in for path. Unknown assets get
+// a magenta checkerboard so they are obvious but never fatal.
+func placeholderImage(path string) image.Image {
+	if gen, ok := assetFallbacks[path]; ok {
+		return gen()
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			if (x/4+y/4)%2 == 0 {
+				img.Set(x, y, color.RGBA{255, 0, 255, 255})
+			} else {
+				img.Set(x, y, color.RGBA{0, 0, 0, 255})
+			}
+		}
+	}
+	return img
+}
+
+// loadImage loads an image from embedded assets, degrading to a
+// placeholder sprite instead of crashing when the asset is missing or corrupt
+func loadImage(path string) image.Image {
+	// Remove leading "./" from path if present
+	path = strings.TrimPrefix(path, "./")
+
+	img, err := decodeAsset(path)
+	if err != nil {
+		if _, seen := assetErrors[path]; !seen {
+			log.Printf("Using placeholder sprite: %v", err)
+		}
+		assetErrors[path] = err
+		img = placeholderImage(path)
+	}
+	return img
+}
@@ -0,0 +1,47 @@
+package game
+
+import "math"
+
+// Landing assist parameters
+const (
+	MagnetEdgeZone = PlayerWidth / 3 // Player centers this close to an edge count as edge landings
+)
+
+// releaseSticky launches the player off the sticky platform they are stuck to
+func (g *Game) releaseSticky() {
+	// Release from platform with a higher jump
+	g.player.VelocityY = g.jumpVelocity * 1.2
+	g.stuckToPlatform = nil
+	g.timers.Cancel(TimerStuck)
+}
+
+// stickyHoldAssist releases the player when jump has been held on a sticky
+// platform for the configured delay, so a jump held through the landing
+// doesn't need to be pressed again
+func (g *Game) stickyHoldAssist() {
+	s := g.save.Settings
+	if !s.StickyHoldRelease || g.stuckToPlatform == nil || !g.jumpPressed {
+		return
+	}
+	if g.timers.Elapsed(TimerStuck) >= s.StickyReleaseDelay {
+		g.releaseSticky()
+	}
+}
+
+// magnetizeLanding nudges a player landing near a platform edge toward its
+// center by at most the configured magnet strength
+func (g *Game) magnetizeLanding(p *Platform) {
+	strength := g.save.Settings.LandingMagnet
+	if strength <= 0 {
+		return
+	}
+
+	left := p.X + MagnetEdgeZone
+	right := p.X + PlatformWidth - MagnetEdgeZone
+	switch {
+	case g.player.X < left:
+		g.player.X += math.Min(strength, left-g.player.X)
+	case g.player.X > right:
+		g.player.X -= math.Min(strength, g.player.X-right)
+	}
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// AttractDelay is how many seconds the title waits without input before
+// the attract demo takes over
+const AttractDelay = 15.0
+
+// withAttract plays the attract demo: the screensaver's bot climbs behind
+// the title, or the coin screen in a kiosk. base is the game's own
+// options, which leaving the demo goes back to.
+func withAttract(base []Option) Option {
+	return func(o *gameOptions) {
+		o.idle = true
+		o.attract = base
+	}
+}
+
+// startAttract plays the attract demo, endlessly
+func (g *Game) startAttract() {
+	g.restartWith(withAttract(g.optionList))
+}
+
+// leaveAttract goes back from the demo to the title or the coin screen
+func (g *Game) leaveAttract() {
+	g.optionList = g.opts.attract
+	g.restartWith()
+	if k := g.opts.kiosk; k != nil {
+		g.scenes.Switch(coinScene)
+		k.waited = 0
+		return
+	}
+	g.scenes.Switch(titleScene)
+}
+
+// anyInput reports whether a key, mouse button or gamepad button went
+// down this frame
+func anyInput() bool {
+	if len(inpututil.AppendJustPressedKeys(nil)) > 0 || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return true
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if len(inpututil.AppendJustPressedGamepadButtons(id, nil)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// updateTitleWait counts the title's seconds without input and starts the
+// attract demo once they reach AttractDelay. Sessions of turns and
+// kiosks have screens of their own between runs.
+func (g *Game) updateTitleWait() {
+	if anyInput() || g.opts.tournament != nil || g.opts.hotseat != nil || g.opts.kiosk != nil {
+		g.titleWait = 0
+		return
+	}
+	if g.titleWait += g.dt; g.titleWait >= AttractDelay {
+		g.startAttract()
+	}
+}
+
+// drawAttract prompts over the attract demo: for a coin in a kiosk, for
+// any key elsewhere
+func (g *Game) drawAttract(screen *ebiten.Image) {
+	blink := int(g.gameTime*2)%2 == 0
+	if g.opts.kiosk != nil {
+		if blink {
+			drawTextCentered(screen, "Insert coin", ScreenHeight-60, TextLarge, color.RGBA{255, 220, 100, 255})
+		}
+		return
+	}
+	drawTextCentered(screen, "GodleJump", ScreenHeight/3, TextLarge, textColor)
+	drawTextCentered(screen, "Demo", ScreenHeight/3+30, TextSmall, textColor)
+	if blink {
+		drawTextCentered(screen, "Press any key", ScreenHeight-60, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+}
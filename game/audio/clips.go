@@ -0,0 +1,43 @@
+package audio
+
+import (
+	"embed"
+	"log"
+	"sync"
+
+	"doodlejump/game/soundgen"
+)
+
+//go:embed sounds/*.wav
+var soundAssets embed.FS
+
+// clipCache holds decoded clips by name; the music is too large to decode per run
+var (
+	clipCache   = map[string][]byte{}
+	clipCacheMu sync.Mutex
+)
+
+// Clip returns the PCM of the embedded sounds/<name>.wav, synthesizing the
+// clip with soundgen instead when the file is missing or can't be decoded
+func Clip(name string) []byte {
+	clipCacheMu.Lock()
+	defer clipCacheMu.Unlock()
+	if pcm, ok := clipCache[name]; ok {
+		return pcm
+	}
+
+	path := "sounds/" + name + ".wav"
+	wav, err := soundAssets.ReadFile(path)
+	var pcm []byte
+	if err == nil {
+		pcm, err = soundgen.DecodeWAV(wav)
+	}
+	if err != nil {
+		log.Printf("Synthesizing sound %s: %v", path, err)
+		if gen, ok := soundgen.Clips[name]; ok {
+			pcm = gen()
+		}
+	}
+	clipCache[name] = pcm
+	return pcm
+}
@@ -0,0 +1,39 @@
+package audio
+
+// Music playback parameters
+const (
+	MusicGain = 0.5  // Loop gain while a run is in progress
+	MusicFade = 0.02 // Gain change per tick while fading in or out
+)
+
+// AudioManager is the game's single entry point to sound: it owns the
+// mixer, embedded on it, and the background music
+type AudioManager struct {
+	*Mixer
+	music *Loop
+}
+
+// NewAudioManager creates the mixer and prepares the music loop, silent
+// until the first Update
+func NewAudioManager() *AudioManager {
+	m := NewMixer(DefaultMaxVoices)
+	return &AudioManager{Mixer: m, music: m.NewLoop(Clip("music"), ChannelMusic)}
+}
+
+// Update releases finished voices and fades the music in while playing
+// and out when not; call it once per tick
+func (a *AudioManager) Update(playing bool) {
+	a.Mixer.Update()
+
+	target := 0.0
+	if playing {
+		target = MusicGain
+	}
+	gain := a.music.Gain()
+	switch {
+	case gain < target:
+		a.music.SetGain(min(target, gain+MusicFade))
+	case gain > target:
+		a.music.SetGain(max(target, gain-MusicFade))
+	}
+}
@@ -0,0 +1,287 @@
+// Package audio mixes the game's sound effects, music and UI sounds on top
+// of ebiten's audio context.
+package audio
+
+import (
+	"bytes"
+	"log"
+	"math"
+	"math/rand"
+
+	"doodlejump/game/soundgen"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// SampleRate is the rate of the audio context and of every Sound
+const SampleRate = soundgen.SampleRate
+
+// bytesPerFrame is 16-bit little-endian stereo
+const bytesPerFrame = 4
+
+// DefaultMaxVoices caps simultaneous sounds per channel
+const DefaultMaxVoices = 8
+
+// Channel groups sounds that share a volume and mute setting
+type Channel int
+
+const (
+	ChannelSFX Channel = iota
+	ChannelMusic
+	ChannelUI
+	channelCount
+)
+
+// Sound is a decoded clip ready to be played through the mixer
+type Sound struct {
+	PCM         []byte  // 16-bit little-endian stereo at SampleRate
+	Channel     Channel // Channel the sound is mixed into
+	Volume      float64 // Per-sound gain (0.0 - 1.0)
+	PitchJitter float64 // Random pitch spread, e.g. 0.1 plays at 0.9x - 1.1x
+}
+
+// voice is a playing instance of a sound
+type voice struct {
+	player *audio.Player
+	gain   float64 // Sound volume before channel volume is applied
+}
+
+// Mixer plays sounds on per-category channels with volume, mute and
+// voice limiting
+type Mixer struct {
+	ctx       *audio.Context
+	volume    [channelCount]float64
+	muted     [channelCount]bool
+	voices    [channelCount][]*voice
+	loops     []*Loop
+	maxVoices int
+	rng       *rand.Rand
+}
+
+// NewMixer creates a mixer on the process-wide audio context
+func NewMixer(maxVoices int) *Mixer {
+	ctx := audio.CurrentContext()
+	if ctx == nil {
+		ctx = audio.NewContext(SampleRate)
+	}
+
+	m := &Mixer{
+		ctx:       ctx,
+		maxVoices: maxVoices,
+		rng:       rand.New(rand.NewSource(rand.Int63())),
+	}
+	for i := range m.volume {
+		m.volume[i] = 1
+	}
+	return m
+}
+
+// SetVolume sets a channel's volume (0.0 - 1.0) and applies it to playing voices
+func (m *Mixer) SetVolume(ch Channel, v float64) {
+	m.volume[ch] = clamp01(v)
+	m.applyVolume(ch)
+}
+
+// Volume reports a channel's volume
+func (m *Mixer) Volume(ch Channel) float64 {
+	return m.volume[ch]
+}
+
+// SetMuted mutes or unmutes a channel
+func (m *Mixer) SetMuted(ch Channel, muted bool) {
+	m.muted[ch] = muted
+	m.applyVolume(ch)
+}
+
+// Muted reports whether a channel is muted
+func (m *Mixer) Muted(ch Channel) bool {
+	return m.muted[ch]
+}
+
+// effectiveVolume is the channel gain after mute
+func (m *Mixer) effectiveVolume(ch Channel) float64 {
+	if m.muted[ch] {
+		return 0
+	}
+	return m.volume[ch]
+}
+
+// applyVolume pushes the current channel gain to the channel's voices
+func (m *Mixer) applyVolume(ch Channel) {
+	for _, v := range m.voices[ch] {
+		v.player.SetVolume(v.gain * m.effectiveVolume(ch))
+	}
+	for _, l := range m.loops {
+		if l.channel == ch {
+			l.apply()
+		}
+	}
+}
+
+// Play starts s centered at full gain
+func (m *Mixer) Play(s *Sound) {
+	m.PlayAt(s, 0, 1)
+}
+
+// PlayAt starts s panned between left (-1) and right (1) and scaled by gain,
+// randomizing its pitch and stealing the oldest voice when the channel is
+// full. Muted channels don't start new voices at all.
+func (m *Mixer) PlayAt(s *Sound, pan, gain float64) {
+	if s == nil || len(s.PCM) == 0 || m.muted[s.Channel] || gain <= 0 {
+		return
+	}
+	m.prune(s.Channel)
+
+	voices := m.voices[s.Channel]
+	if m.maxVoices > 0 && len(voices) >= m.maxVoices {
+		oldest := voices[0]
+		oldest.player.Pause()
+		oldest.player.Close()
+		voices = voices[1:]
+	}
+
+	pcm := s.PCM
+	if s.PitchJitter > 0 {
+		pitch := 1 + (m.rng.Float64()*2-1)*s.PitchJitter
+		pcm = resample(pcm, pitch)
+	}
+	if pan != 0 {
+		pcm = panned(pcm, pan)
+	}
+
+	if s.Volume > 0 {
+		gain *= s.Volume
+	}
+	p := m.ctx.NewPlayerFromBytes(pcm)
+	p.SetVolume(gain * m.effectiveVolume(s.Channel))
+	p.Play()
+
+	m.voices[s.Channel] = append(voices, &voice{player: p, gain: gain})
+}
+
+// Update releases finished voices; call it once per tick
+func (m *Mixer) Update() {
+	for ch := Channel(0); ch < channelCount; ch++ {
+		m.prune(ch)
+	}
+}
+
+// prune drops voices that finished playing
+func (m *Mixer) prune(ch Channel) {
+	alive := m.voices[ch][:0]
+	for _, v := range m.voices[ch] {
+		if v.player.IsPlaying() {
+			alive = append(alive, v)
+			continue
+		}
+		v.player.Close()
+	}
+	m.voices[ch] = alive
+}
+
+// Loop is a clip that repeats forever with a fadeable gain. Loops at zero
+// gain are paused so silent layers cost nothing.
+type Loop struct {
+	mixer   *Mixer
+	channel Channel
+	player  *audio.Player
+	gain    float64
+}
+
+// NewLoop prepares pcm to loop seamlessly on ch; it starts silent
+func (m *Mixer) NewLoop(pcm []byte, ch Channel) *Loop {
+	l := &Loop{mixer: m, channel: ch}
+	p, err := m.ctx.NewPlayer(audio.NewInfiniteLoop(bytes.NewReader(pcm), int64(len(pcm))))
+	if err != nil {
+		log.Printf("Failed to create audio loop: %v", err)
+		return l
+	}
+	l.player = p
+	m.loops = append(m.loops, l)
+	return l
+}
+
+// Gain reports the loop's own gain before channel volume
+func (l *Loop) Gain() float64 {
+	return l.gain
+}
+
+// SetGain fades the loop to gain (0.0 - 1.0), starting or pausing playback
+func (l *Loop) SetGain(gain float64) {
+	l.gain = clamp01(gain)
+	l.apply()
+}
+
+// apply pushes the loop's gain through its channel volume
+func (l *Loop) apply() {
+	if l.player == nil {
+		return
+	}
+	v := l.gain * l.mixer.effectiveVolume(l.channel)
+	l.player.SetVolume(v)
+	if v > 0 && !l.player.IsPlaying() {
+		l.player.Play()
+	} else if v == 0 && l.player.IsPlaying() {
+		l.player.Pause()
+	}
+}
+
+// resample changes the pitch of 16-bit stereo PCM by linear interpolation;
+// pitch > 1 plays higher and shorter
+func resample(pcm []byte, pitch float64) []byte {
+	frames := len(pcm) / bytesPerFrame
+	outFrames := int(float64(frames) / pitch)
+	out := make([]byte, outFrames*bytesPerFrame)
+
+	sample := func(frame, ch int) float64 {
+		i := frame*bytesPerFrame + ch*2
+		return float64(int16(uint16(pcm[i]) | uint16(pcm[i+1])<<8))
+	}
+
+	for i := 0; i < outFrames; i++ {
+		src := float64(i) * pitch
+		f0 := int(src)
+		f1 := f0 + 1
+		if f1 >= frames {
+			f1 = frames - 1
+		}
+		t := src - float64(f0)
+		for ch := 0; ch < 2; ch++ {
+			v := int16(sample(f0, ch)*(1-t) + sample(f1, ch)*t)
+			o := i*bytesPerFrame + ch*2
+			out[o] = byte(v)
+			out[o+1] = byte(uint16(v) >> 8)
+		}
+	}
+	return out
+}
+
+// panned returns a copy of stereo pcm with an equal-power pan applied;
+// pan is -1 (left) to 1 (right)
+func panned(pcm []byte, pan float64) []byte {
+	pan = math.Max(-1, math.Min(1, pan))
+	angle := (pan + 1) * math.Pi / 4
+	gains := [2]float64{math.Cos(angle) * math.Sqrt2, math.Sin(angle) * math.Sqrt2}
+
+	out := make([]byte, len(pcm))
+	for i := 0; i+bytesPerFrame <= len(pcm); i += bytesPerFrame {
+		for ch := 0; ch < 2; ch++ {
+			o := i + ch*2
+			v := float64(int16(uint16(pcm[o])|uint16(pcm[o+1])<<8)) * gains[ch]
+			v = math.Max(math.MinInt16, math.Min(math.MaxInt16, v))
+			out[o] = byte(int16(v))
+			out[o+1] = byte(uint16(int16(v)) >> 8)
+		}
+	}
+	return out
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,242 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"doodlejump/game/physics"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Shared player physics. Update moves the player with these helpers and
+// every preview (landing marker, trajectory arcs, apex rings) replays them,
+// so what is drawn is exactly what will happen.
+
+// PredictTicks is how many frames ahead previews simulate
+const PredictTicks = 180
+
+// fall advances vertical motion by one tick of step frames
+func fall(y, vy, gravity, step float64) (float64, float64) {
+	vy += gravity * step
+	return y + vy*step, vy
+}
+
+// wrapX applies the horizontal wrap of EdgeWrap to a predicted position
+func (g *Game) wrapX(x float64) float64 {
+	if g.world.Edges != EdgeWrap {
+		return x
+	}
+	if x < 0 {
+		return ScreenWidth
+	} else if x > ScreenWidth {
+		return 0
+	}
+	return x
+}
+
+// landsOn reports whether a player centered at x, y that fell dy this tick
+// touches platform p; this is the collision test Update uses. The feet are
+// swept over the whole tick's fall, so falls faster than a platform is
+// thick can't pass through it.
+func landsOn(x, y, dy float64, p *Platform) bool {
+	if !p.solid() || dy <= 0 {
+		return false
+	}
+	feet := y + PlayerHeight/2
+	foot := physics.Rect{X: x - PlayerWidth/3, Y: feet - dy, W: PlayerWidth * 2 / 3}
+	_, hit := physics.Sweep(foot, 0, dy, p.rect())
+	return hit
+}
+
+// rect is the platform's collision box
+func (p *Platform) rect() physics.Rect {
+	return physics.Rect{X: p.X, Y: p.Y, W: PlatformWidth, H: PlatformHeight}
+}
+
+// rect is the player's pickup box, the full sprite height and the middle
+// two thirds of its width
+func (p *Player) rect() physics.Rect {
+	return physics.Rect{X: p.X - PlayerWidth/3, Y: p.Y - PlayerHeight/2, W: PlayerWidth * 2 / 3, H: PlayerHeight}
+}
+
+// circle is the boost's pickup disc, the same one that is drawn
+func (b *Boost) circle() physics.Circle {
+	return physics.Circle{X: b.X, Y: b.Y, R: BoostRadius}
+}
+
+// hitsPlayer reports whether the player's body box touched r at any point
+// during the last tick's move
+func (g *Game) hitsPlayer(r physics.Rect) bool {
+	dx := (g.player.MoveX + g.player.LaunchX) * g.step
+	if math.Abs(dx) > ScreenWidth/2 {
+		dx = 0 // Wrapped around the screen this tick
+	}
+	dy := g.player.VelocityY * g.step
+	body := physics.Rect{
+		X: g.player.X - PlayerWidth/4 - dx,
+		Y: g.player.Y - PlayerHeight/4 - dy,
+		W: PlayerWidth / 2,
+		H: PlayerHeight / 2,
+	}
+	_, hit := physics.Sweep(body, dx, dy, r)
+	return hit
+}
+
+// apexHeight is how far a bounce at vy (negative is up) rises before it
+// starts falling, integrated tick by tick of step frames like the real jump
+func apexHeight(vy, gravity, step float64) float64 {
+	y := 0.0
+	for vy < 0 {
+		y, vy = fall(y, vy, gravity, step)
+	}
+	return -y
+}
+
+// projectileStep advances anything thrown or launched by one tick: constant
+// horizontal speed, the level's gravity and the screen wrap
+func (g *Game) projectileStep(x, y, vx, vy float64) (float64, float64, float64) {
+	x = g.wrapX(x + vx*g.step)
+	y, vy = fall(y, vy, g.gravity, g.step)
+	return x, y, vy
+}
+
+// trajectory replays projectileStep from x, y with horizontal speed vx and
+// vertical speed vy for up to ticks steps, calling visit after each one
+// until it returns false
+func (g *Game) trajectory(x, y, vx, vy float64, ticks int, visit func(x, y, vy float64) bool) {
+	for i := 0; i < ticks; i++ {
+		x, y, vy = g.projectileStep(x, y, vx, vy)
+		if !visit(x, y, vy) {
+			return
+		}
+	}
+}
+
+// predictLanding finds the platform the player will land on if they keep
+// their current input, and where along it
+func (g *Game) predictLanding() (*Platform, float64) {
+	if g.stuckToPlatform != nil || g.canFly() {
+		return nil, 0
+	}
+	var hit *Platform
+	var hitX float64
+	g.trajectory(g.player.X, g.player.Y, g.player.MoveX+g.player.LaunchX, g.player.VelocityY, g.ticks(PredictTicks), func(x, y, vy float64) bool {
+		if g.screenY(y) > ScreenHeight+PlayerHeight {
+			return false
+		}
+		for i := range g.platforms {
+			if landsOn(x, y, vy*g.step, &g.platforms[i]) {
+				hit, hitX = &g.platforms[i], x
+				return false
+			}
+		}
+		return true
+	})
+	return hit, hitX
+}
+
+// Dotted arc parameters
+const (
+	ArcDotEvery = 4 // Frames between preview dots
+	ArcDotSize  = 1.5
+)
+
+// drawArc draws a dotted preview of trajectory from x, y. stop ends the
+// arc where the projectile would hit something.
+func (g *Game) drawArc(screen *ebiten.Image, x, y, vx, vy float64, clr color.RGBA, stop func(x, y, vy float64) bool) {
+	tick, ticks, every := 0, g.ticks(PredictTicks), g.ticks(ArcDotEvery)
+	g.trajectory(x, y, vx, vy, ticks, func(x, y, vy float64) bool {
+		tick++
+		sy := g.screenY(y)
+		if sy > ScreenHeight || sy < -ScreenHeight || stop(x, y, vy) {
+			return false
+		}
+		if tick%every == 0 {
+			// Fade along the arc so the near part reads first
+			fade := 1 - float64(tick)/float64(ticks)
+			c := clr
+			c.A = uint8(float64(c.A) * fade)
+			drawCircle(screen, x, sy, ArcDotSize, c)
+		}
+		return true
+	})
+}
+
+// SpringBoost multiplies the bounce of spring platforms
+const SpringBoost = 1.6
+
+// bounceVelocity is the vertical speed the player leaves p with; Update
+// and the apex rings both use it
+func (g *Game) bounceVelocity(p *Platform) float64 {
+	v := g.jumpVelocity
+	if p.Type == PlatformSpring {
+		v *= SpringBoost
+	}
+	if g.player.BoostType == BoostJump {
+		v *= 1.5
+	}
+	return v
+}
+
+// Apex ring parameters
+const (
+	ApexRings       = 3
+	ApexRingSpacing = 5.0
+	ApexRingAlpha   = 90
+)
+
+// drawBounceRings marks the apex of a bounce above spring platforms and,
+// while the jump boost is active, above the platform the player will land on
+func (g *Game) drawBounceRings(screen *ebiten.Image) {
+	var boosted *Platform
+	if g.player.BoostType == BoostJump {
+		boosted, _ = g.predictLanding()
+	}
+	for i := range g.platforms {
+		p := &g.platforms[i]
+		if p.Type != PlatformSpring && p != boosted {
+			continue
+		}
+		// The player's center rests half a body above the platform
+		apex := p.Y - PlayerHeight/2 - apexHeight(g.bounceVelocity(p), g.gravity, g.step)
+		sy := g.screenY(apex)
+		if sy < -ApexRings*ApexRingSpacing || sy > ScreenHeight {
+			continue
+		}
+		cx := p.X + PlatformWidth/2
+		for r := 1; r <= ApexRings; r++ {
+			rx := float64(r) * ApexRingSpacing * 2
+			a := uint8(ApexRingAlpha / r)
+			strokeEllipse(screen, cx, sy, rx, rx*0.3, color.RGBA{255, 255, 255, a})
+		}
+	}
+}
+
+// strokeEllipse outlines an axis-aligned ellipse
+func strokeEllipse(screen *ebiten.Image, cx, cy, rx, ry float64, clr color.RGBA) {
+	const segments = 24
+	px, py := cx+rx, cy
+	for i := 1; i <= segments; i++ {
+		a := float64(i) / segments * 2 * math.Pi
+		x, y := cx+rx*math.Cos(a), cy+ry*math.Sin(a)
+		drawLine(screen, px, py, x, y, clr)
+		px, py = x, y
+	}
+}
+
+// drawSpringCoil draws the zigzag spring on top of a spring platform
+func drawSpringCoil(screen *ebiten.Image, cx, top float64) {
+	clr := color.RGBA{200, 200, 210, 255}
+	const turns, width, height = 4, 10.0, 8.0
+	px, py := cx-width/2, top
+	for i := 1; i <= turns*2; i++ {
+		x := cx - width/2
+		if i%2 == 1 {
+			x = cx + width/2
+		}
+		y := top - height*float64(i)/(turns*2)
+		drawLine(screen, px, py, x, y, clr)
+		px, py = x, y
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"doodlejump/game/config"
+)
+
+// BenchSeed is the world every benchmark scenario climbs, so runs compare
+const BenchSeed = 1
+
+// benchScenario is a scripted situation for the bench command. setup
+// builds it once; hold keeps it up every tick, against the weather
+// changing, particles falling away and birds being shot down.
+type benchScenario struct {
+	name  string
+	about string
+	setup func(g *Game)
+	hold  func(g *Game)
+}
+
+// benchScenarios are the situations measured, in the order they run
+var benchScenarios = []*benchScenario{
+	{name: "baseline", about: "a clear-sky classic run", setup: pinWeather(WeatherClear), hold: func(*Game) {}},
+	{name: "particles", about: "rain with every raindrop on screen", setup: pinWeather(WeatherRain), hold: fillParticles},
+	{name: "birds", about: "the level's bird cap at full speed", setup: pinWeather(WeatherClear), hold: fillBirds},
+	{name: "night_storm", about: "a storm at midnight, lightning and all", setup: pinWeather(WeatherStorm), hold: holdNight},
+}
+
+// BenchScenarios lists the benchmark scenarios by name with what each is
+func BenchScenarios() [][2]string {
+	list := make([][2]string, len(benchScenarios))
+	for i, s := range benchScenarios {
+		list[i] = [2]string{s.name, s.about}
+	}
+	return list
+}
+
+// NewBench builds the game of benchmark scenario name: the bot climbs the
+// BenchSeed world, the run never ends, nothing is saved and every setting
+// is the default, muted. headless leaves sprites and audio out to measure
+// the simulation alone.
+func NewBench(name string, headless bool) (*Game, error) {
+	var scenario *benchScenario
+	names := make([]string, len(benchScenarios))
+	for i, s := range benchScenarios {
+		names[i] = s.name
+		if s.name == name {
+			scenario = s
+		}
+	}
+	if scenario == nil {
+		return nil, fmt.Errorf("unknown scenario %q (want %s)", name, strings.Join(names, ", "))
+	}
+
+	s := DefaultSettings()
+	s.SFXMuted, s.MusicMuted, s.UIMuted, s.Haptics = true, true, true, false
+	opts := []Option{WithSeed(BenchSeed), WithSettings(s), WithConfig(config.Default()), withBench(scenario)}
+	if headless {
+		opts = append(opts, WithHeadless())
+	}
+	g := NewGame(opts...)
+	scenario.setup(g)
+	return g, nil
+}
+
+// withBench plays scenario for the bench command
+func withBench(scenario *benchScenario) Option {
+	return func(o *gameOptions) {
+		o.bench = scenario
+	}
+}
+
+// BenchTick runs one tick of a benchmark game, its scenario held
+func (g *Game) BenchTick() error {
+	g.opts.bench.hold(g)
+	return g.tick()
+}
+
+// pinWeather sets weather w for good
+func pinWeather(w int) func(g *Game) {
+	return func(g *Game) {
+		g.setWeather(w)
+		g.timers.Cancel(TimerWeather)
+	}
+}
+
+// fillParticles keeps the screen at its raindrop limit
+func fillParticles(g *Game) {
+	for len(g.particles) < RaindropCount {
+		g.particles = append(g.particles, g.generateParticle())
+	}
+}
+
+// fillBirds keeps the level's bird cap in the air at the top speed
+func fillBirds(g *Game) {
+	g.birdSpeedMin, g.birdSpeedMax = g.tuning.MaxBirdSpeed[0], g.tuning.MaxBirdSpeed[1]
+	for len(g.birds) < g.level.SpawnTable.MaxBirds {
+		direction := 1
+		if g.rng.Float64() < 0.5 {
+			direction = -1
+		}
+		g.birds = append(g.birds, Bird{
+			X:         g.rng.Float64() * ScreenWidth,
+			Y:         g.worldTop() + g.rng.Float64()*ScreenHeight/2,
+			SpeedX:    g.birdSpeedMax,
+			Direction: direction,
+		})
+	}
+	g.birdCount = len(g.birds)
+}
+
+// holdNight keeps the clock at midnight however far the bot climbs
+func holdNight(g *Game) {
+	const midnight = (SunsetEnd + 1) / 2
+	g.initialTimeOfDay = midnight - float64(g.climbed())/DayCycleLength
+	g.nightMode = true
+}
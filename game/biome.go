@@ -0,0 +1,39 @@
+package game
+
+import "doodlejump/game/spritegen"
+
+// Biome is a band of altitude with its own look
+type Biome struct {
+	Name string
+	From float64 // Altitude in meters where the biome begins
+	Skin string  // Platform skin, one of spritegen.PlatformSkins
+}
+
+// biomes are ordered by altitude; the frost starts where Hardcore's cold does
+var biomes = []Biome{
+	{Name: "meadow", From: 0, Skin: spritegen.SkinWooden},
+	{Name: "sky", From: 100, Skin: spritegen.SkinCloud},
+	{Name: "frost", From: WarmthAltitude, Skin: spritegen.SkinIcy},
+	{Name: "orbit", From: 400, Skin: spritegen.SkinMetallic},
+}
+
+// biomeAt returns the biome at altitude meters
+func biomeAt(altitude float64) Biome {
+	b := biomes[0]
+	for _, next := range biomes[1:] {
+		if altitude < next.From {
+			break
+		}
+		b = next
+	}
+	return b
+}
+
+// platformSkin is the skin platform p is drawn in: the theme's, if it
+// pins one, otherwise that of the biome p sits in
+func (g *Game) platformSkin(p *Platform) string {
+	if t := g.level.ThemeData; t != nil && t.Skin != "" {
+		return t.Skin
+	}
+	return biomeAt((ScreenHeight - p.Y) / PixelsPerMeter).Skin
+}
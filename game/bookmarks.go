@@ -0,0 +1,193 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SeedBookmark is a seed the player saved to play again
+type SeedBookmark struct {
+	Seed int64  `json:"seed"`
+	Name string `json:"name"`
+	Note string `json:"note,omitempty"`
+}
+
+// hasBookmark reports whether seed is already bookmarked
+func (s *SaveData) hasBookmark(seed int64) bool {
+	return slices.ContainsFunc(s.Bookmarks, func(b SeedBookmark) bool { return b.Seed == seed })
+}
+
+// addBookmark saves b unless its seed is already bookmarked, reporting
+// whether it was added
+func (s *SaveData) addBookmark(b SeedBookmark) bool {
+	if s.hasBookmark(b.Seed) {
+		return false
+	}
+	s.Bookmarks = append(s.Bookmarks, b)
+	return true
+}
+
+// bookmarkRun bookmarks the seed of the run that just ended, named after
+// the run and noting how it went
+func (g *Game) bookmarkRun() {
+	b := SeedBookmark{
+		Seed: g.seed,
+		Name: fmt.Sprintf("Run %d", g.save.GamesPlayed),
+		Note: fmt.Sprintf("Score %d, %dm", g.score, g.altitude()),
+	}
+	if !g.save.addBookmark(b) {
+		return
+	}
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+	g.feedback(FeedbackUIClick)
+}
+
+// WriteBookmarks writes bookmarks as a text list: one "seed<TAB>name<TAB>note"
+// line each, under a comment header
+func WriteBookmarks(w io.Writer, bookmarks []SeedBookmark) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "# godlejump seeds: seed<TAB>name<TAB>note")
+	for _, b := range bookmarks {
+		fmt.Fprintf(bw, "%d\t%s\t%s\n", b.Seed, clean(b.Name), clean(b.Note))
+	}
+	return bw.Flush()
+}
+
+// clean keeps tabs and newlines out of a text list field
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ReadBookmarks parses a text list written by WriteBookmarks. Blank lines
+// and # comments are skipped; a line with only a seed is named after it.
+func ReadBookmarks(r io.Reader) ([]SeedBookmark, error) {
+	var bookmarks []SeedBookmark
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		seed, err := strconv.ParseInt(strings.TrimSpace(fields[0]), 10, 64)
+		if err != nil || seed == 0 {
+			return bookmarks, fmt.Errorf("line %d: %q is not a seed", n, fields[0])
+		}
+		b := SeedBookmark{Seed: seed, Name: "Seed " + fields[0]}
+		if len(fields) > 1 && strings.TrimSpace(fields[1]) != "" {
+			b.Name = strings.TrimSpace(fields[1])
+		}
+		if len(fields) > 2 {
+			b.Note = strings.TrimSpace(fields[2])
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks, sc.Err()
+}
+
+// ExportBookmarks writes the bookmarks of profile to the text list at path
+func ExportBookmarks(profile, path string) error {
+	save, err := LoadSave(profile)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := WriteBookmarks(f, save.Bookmarks); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// ImportBookmarks adds the seeds of the text list at path to profile,
+// skipping seeds it already has, and returns how many were added
+func ImportBookmarks(profile, path string) (int, error) {
+	save, err := LoadSave(profile)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	bookmarks, err := ReadBookmarks(f)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	added := 0
+	for _, b := range bookmarks {
+		if save.addBookmark(b) {
+			added++
+		}
+	}
+	return added, save.Write()
+}
+
+// sceneSeeds lists the bookmarked seeds; left and right pick one, start plays it
+type sceneSeeds struct{}
+
+func (sceneSeeds) Update(g *Game) error {
+	count := len(g.save.Bookmarks)
+	switch {
+	case g.controller.JustPressed(input.ActionBookmarks), g.controller.JustPressed(input.ActionPause):
+		g.scenes.Switch(titleScene)
+		g.feedback(FeedbackUIClick)
+	case count == 0:
+	case g.controller.JustPressed(input.ActionLeft):
+		g.bookmarkCursor = (g.bookmarkCursor + count - 1) % count
+		g.feedback(FeedbackUIClick)
+	case g.controller.JustPressed(input.ActionRight):
+		g.bookmarkCursor = (g.bookmarkCursor + 1) % count
+		g.feedback(FeedbackUIClick)
+	case g.controller.JustPressed(input.ActionRestart):
+		g.restartWith(WithSeed(g.save.Bookmarks[g.bookmarkCursor].Seed))
+	}
+	return nil
+}
+
+func (sceneSeeds) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Seeds", 40, TextLarge, textColor)
+	if len(g.save.Bookmarks) == 0 {
+		drawTextCentered(screen, "No bookmarks yet", 90, TextSmall, textColor)
+		drawTextCentered(screen, "Press "+g.actionKey(input.ActionBookmarks)+" after a run to keep its seed", 90+textLineHeight, TextSmall, textColor)
+	}
+
+	// A window of rows around the cursor
+	const rows = 8
+	first := max(0, min(g.bookmarkCursor-rows/2, len(g.save.Bookmarks)-rows))
+	y := 80.0
+	for i := first; i < len(g.save.Bookmarks) && i < first+rows; i++ {
+		b := g.save.Bookmarks[i]
+		clr := textColor
+		prefix := "  "
+		if i == g.bookmarkCursor {
+			prefix = "> "
+			clr = color.RGBA{255, 220, 100, 255}
+		}
+		drawText(screen, prefix+b.Name+"  "+strconv.FormatInt(b.Seed, 10), 30, y, TextSmall, clr)
+		if b.Note != "" {
+			drawText(screen, "    "+b.Note, 30, y+textLineHeight, TextSmall, textColor)
+		}
+		y += 2.5 * textLineHeight
+	}
+	drawTextCentered(screen, joinHints("  ", g.moveHint("pick"), g.actionHint(input.ActionRestart, "play"), g.actionHint(input.ActionBookmarks, "back")), ScreenHeight-40, TextSmall, textColor)
+}
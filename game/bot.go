@@ -0,0 +1,108 @@
+package game
+
+import (
+	"math"
+
+	"doodlejump/game/input"
+)
+
+// Bot tuning
+const (
+	BotReach      = 180.0 // Highest a platform above can be and still be aimed for
+	BotSteerRange = 12.0  // Horizontal distance at which steering is at full speed
+	BotShootRange = 24.0  // Birds this close horizontally, above the player, get shot
+)
+
+// Bot plays the game on its own: it steers for the next platform it can
+// land on and shoots birds overhead. It is an input.Source, so it drives
+// the game exactly as a player would.
+type Bot struct {
+	g *Game
+
+	targetX float64 // Where the bot wants the player's center, world x
+	shoot   bool
+}
+
+var _ input.Source = (*Bot)(nil)
+
+// newBot creates a bot playing g
+func newBot(g *Game) *Bot {
+	return &Bot{g: g}
+}
+
+// Update picks the platform to aim for and whether to shoot this tick
+func (b *Bot) Update() {
+	g := b.g
+	if t := b.target(); t != nil {
+		b.targetX = t.X + PlatformWidth/2
+	} else {
+		b.targetX = g.player.X
+	}
+
+	b.shoot = false
+	if !g.timers.Active(TimerShoot) {
+		for i := range g.birds {
+			bird := &g.birds[i]
+			dx := bird.X + BirdWidth/2 - g.player.X
+			sy := g.screenY(bird.Y)
+			if bird.Y < g.player.Y && sy > 0 && math.Abs(dx) < BotShootRange {
+				b.shoot = true
+				break
+			}
+		}
+	}
+}
+
+// target is the platform worth steering for: the nearest one below while
+// falling, the nearest within reach above while rising
+func (b *Bot) target() *Platform {
+	g := b.g
+	feet := g.player.Y + PlayerHeight/2
+	rising := g.player.VelocityY < 0
+	var best *Platform
+	bestCost := math.Inf(1)
+	for i := range g.platforms {
+		p := &g.platforms[i]
+		if !p.solid() {
+			continue
+		}
+		dy := p.Y - feet
+		if rising && (dy > 0 || dy < -BotReach) || !rising && dy < 0 {
+			continue
+		}
+		cost := math.Abs(dy) + math.Abs(b.dx(p.X+PlatformWidth/2))*0.5
+		if cost < bestCost {
+			best, bestCost = p, cost
+		}
+	}
+	return best
+}
+
+// dx is the horizontal way from the player to x, across the screen edge
+// when the world wraps and that is shorter
+func (b *Bot) dx(x float64) float64 {
+	d := x - b.g.player.X
+	if b.g.world.Edges == EdgeWrap {
+		if d > ScreenWidth/2 {
+			d -= ScreenWidth
+		} else if d < -ScreenWidth/2 {
+			d += ScreenWidth
+		}
+	}
+	return d
+}
+
+// Pressed holds jump to leave sticky platforms
+func (b *Bot) Pressed(a input.Action) bool {
+	return a == input.ActionJump && b.g.stuckToPlatform != nil
+}
+
+// JustPressed fires the shots Update decided on
+func (b *Bot) JustPressed(a input.Action) bool {
+	return a == input.ActionShoot && b.shoot
+}
+
+// Horizontal steers toward the target platform
+func (b *Bot) Horizontal(float64) float64 {
+	return max(-1, min(1, b.dx(b.targetX)/BotSteerRange))
+}
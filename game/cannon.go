@@ -0,0 +1,132 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Launch cannon parameters
+const (
+	CannonSpawnChance = 0.03        // Chance a recycled platform carries a cannon
+	CannonRadius      = 12          // Catch radius and barrel base size
+	CannonSpeed       = 10.0        // Launch speed along the barrel
+	CannonMaxAngle    = math.Pi / 3 // Barrel sweep either side of straight up
+	CannonSweepSpeed  = 2.0         // Radians per second of the sweep phase
+	CannonReload      = 0.5         // Seconds before a fired cannon can catch again
+)
+
+// Cannon sits on a platform, catches the falling player and launches them
+// along its sweeping barrel when jump is pressed
+type Cannon struct {
+	X, Y   float64 // World coordinates of the barrel pivot
+	Phase  float64 // Sweep phase, advanced every tick
+	Reload float64
+	Mount  Mount // Slot it stands in on its platform
+}
+
+// angle is the barrel direction, 0 straight up, positive to the right
+func (c *Cannon) angle() float64 {
+	return math.Sin(c.Phase) * CannonMaxAngle
+}
+
+// launchVelocity is the speed a cannon gives the player right now
+func (c *Cannon) launchVelocity() (vx, vy float64) {
+	a := c.angle()
+	return math.Sin(a) * CannonSpeed, -math.Cos(a) * CannonSpeed
+}
+
+// spawnCannon maybe places a cannon on freshly recycled platform i, in
+// its middle slot unless that is taken
+func (g *Game) spawnCannon(i int) {
+	p := &g.platforms[i]
+	if p.Type != PlatformNormal || g.rng.Float64() >= CannonSpawnChance {
+		return
+	}
+	phase := g.rng.Float64() * 2 * math.Pi
+	s, ok := g.freeSlot(i, SlotMiddle)
+	if !ok {
+		return
+	}
+	g.cannons = append(g.cannons, &Cannon{
+		X:     p.slotX(s),
+		Y:     p.Y - CannonRadius,
+		Phase: phase,
+		Mount: Mount{Platform: i, Slot: s, Mounted: true},
+	})
+}
+
+// updateCannons sweeps barrels, catches the player and fires on jump.
+// It runs after input movement so a loaded player stays in the barrel.
+func (g *Game) updateCannons() {
+	for i := 0; i < len(g.cannons); i++ {
+		c := g.cannons[i]
+		c.Phase += CannonSweepSpeed * g.dt
+		if c.Reload > 0 {
+			c.Reload -= g.dt
+		}
+
+		// Cannons that scrolled off the bottom are gone for good
+		if g.screenY(c.Y) > ScreenHeight+CannonRadius && g.loadedCannon != c {
+			g.cannons[i] = g.cannons[len(g.cannons)-1]
+			g.cannons = g.cannons[:len(g.cannons)-1]
+			i--
+			continue
+		}
+
+		if g.loadedCannon == nil && c.Reload <= 0 && g.player.VelocityY > 0 &&
+			math.Hypot(g.player.X-c.X, g.player.Y-c.Y) < CannonRadius+PlayerWidth/3 {
+			g.loadedCannon = c
+			g.feedback(FeedbackLanding)
+		}
+	}
+
+	c := g.loadedCannon
+	if c == nil {
+		return
+	}
+	g.player.X, g.player.Y = c.X, c.Y
+	g.player.MoveX = 0
+	g.player.VelocityY = 0
+	if g.controller.JustPressed(input.ActionJump) {
+		g.player.LaunchX, g.player.VelocityY = c.launchVelocity()
+		// Take this tick's horizontal step now, as projectileStep does, so
+		// the flight matches the previewed arc
+		g.player.X = g.wrapX(g.player.X + g.player.LaunchX)
+		g.player.FacingRight = g.player.LaunchX >= 0
+		c.Reload = CannonReload
+		g.loadedCannon = nil
+		g.feedback(FeedbackBoostPickup)
+	}
+}
+
+// drawCannons draws every cannon and the launch arc of a loaded one
+func (g *Game) drawCannons(screen *ebiten.Image) {
+	for _, c := range g.cannons {
+		sy := g.screenY(c.Y)
+		a := c.angle()
+
+		// Barrel as a thick line from the pivot along the aim
+		tipX, tipY := c.X+math.Sin(a)*CannonRadius*1.6, sy-math.Cos(a)*CannonRadius*1.6
+		for w := -3.0; w <= 3; w++ {
+			ox, oy := math.Cos(a)*w, math.Sin(a)*w
+			drawLine(screen, c.X+ox, sy+oy, tipX+ox, tipY+oy, color.RGBA{60, 60, 70, 255})
+		}
+		drawCircle(screen, c.X, sy, CannonRadius*0.8, color.RGBA{90, 90, 100, 255})
+	}
+
+	if c := g.loadedCannon; c != nil {
+		vx, vy := c.launchVelocity()
+		g.drawArc(screen, c.X, c.Y, vx, vy, color.RGBA{255, 230, 120, 220}, func(x, y, vy float64) bool {
+			for i := range g.platforms {
+				if landsOn(x, y, vy*g.step, &g.platforms[i]) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+}
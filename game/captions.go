@@ -0,0 +1,87 @@
+package game
+
+import (
+	"doodlejump/game/i18n"
+)
+
+// Caption parameters
+const (
+	CaptionSeconds = 1.5 // How long a caption stays visible
+	MaxCaptions    = 4   // Older captions are dropped past this many per side
+	CaptionSideDX  = 40  // Horizontal offset from the player beyond which a caption goes to that edge
+)
+
+// feedbackCaptions maps sound events worth captioning to i18n keys
+var feedbackCaptions = map[FeedbackEvent]string{
+	FeedbackBirdChirp:     "caption.bird",
+	FeedbackPlatformCrack: "caption.crack",
+	FeedbackShieldHit:     "caption.shield_hit",
+	FeedbackBoostPickup:   "caption.boost",
+}
+
+// caption is a visible line of sound description
+type caption struct {
+	text  string
+	timer float64
+}
+
+// Captions shows short descriptions of sounds at the screen edge they came from
+type Captions struct {
+	left, right []caption
+}
+
+// add shows the caption for ev, on the side of the screen the sound is on
+func (c *Captions) add(ev FeedbackEvent, x, playerX float64) {
+	key, ok := feedbackCaptions[ev]
+	if !ok {
+		return
+	}
+	text := i18n.T(key)
+
+	side := &c.right
+	switch {
+	case x < playerX-CaptionSideDX:
+		side = &c.left
+		text = "< " + text
+	case x > playerX+CaptionSideDX:
+		text = text + " >"
+	}
+
+	// Refresh a caption that is already showing instead of stacking duplicates
+	for i := range *side {
+		if (*side)[i].text == text {
+			(*side)[i].timer = CaptionSeconds
+			return
+		}
+	}
+	*side = append(*side, caption{text: text, timer: CaptionSeconds})
+	if len(*side) > MaxCaptions {
+		*side = (*side)[1:]
+	}
+}
+
+// Update ages captions dt seconds and drops expired ones
+func (c *Captions) Update(dt float64) {
+	c.left = tickCaptions(c.left, dt)
+	c.right = tickCaptions(c.right, dt)
+}
+
+func tickCaptions(list []caption, dt float64) []caption {
+	alive := list[:0]
+	for _, cp := range list {
+		cp.timer -= dt
+		if cp.timer > 0 {
+			alive = append(alive, cp)
+		}
+	}
+	return alive
+}
+
+// captionLines renders one side's captions as HUD lines
+func captionLines(list []caption) []hudLine {
+	lines := make([]hudLine, len(list))
+	for i, cp := range list {
+		lines[i] = hudLine{cp.text, TextSmall}
+	}
+	return lines
+}
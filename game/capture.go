@@ -0,0 +1,52 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CaptureScale is the resolution multiplier of the clean capture target
+const CaptureScale = 2
+
+// cleanCapture renders the scene without HUD into an offscreen image that
+// recording tools can read back every frame
+type cleanCapture struct {
+	target *ebiten.Image // World drawn at CaptureScale times the internal resolution
+}
+
+// EnableCleanCapture starts mirroring every frame, minus the HUD, into an
+// offscreen image at CaptureScale times the internal resolution
+func (g *Game) EnableCleanCapture() {
+	if g.capture != nil {
+		return
+	}
+	g.capture = &cleanCapture{
+		target: ebiten.NewImage(ScreenWidth*CaptureScale, ScreenHeight*CaptureScale),
+	}
+}
+
+// DisableCleanCapture stops mirroring frames and releases the capture image
+func (g *Game) DisableCleanCapture() {
+	if g.capture == nil {
+		return
+	}
+	g.capture.target.Deallocate()
+	g.capture = nil
+}
+
+// CleanFrame returns the last HUD-free frame, or nil when capture is off.
+// The image is reused; copy it before the next Draw if it must be kept.
+func (g *Game) CleanFrame() *ebiten.Image {
+	if g.capture == nil {
+		return nil
+	}
+	return g.capture.target
+}
+
+// draw renders the world on screen, then again at CaptureScale into the
+// capture target, so recordings get the finer detail and not an upscale
+func (c *cleanCapture) draw(screen *ebiten.Image, drawWorld func(*ebiten.Image)) {
+	drawWorld(screen)
+
+	c.target.Clear()
+	drawScaled(CaptureScale, func() { drawWorld(c.target) })
+}
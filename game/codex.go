@@ -0,0 +1,240 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CodexStats is what the save remembers about one codex entry
+type CodexStats struct {
+	Seen   int `json:"seen"`             // Times encountered: landed on, shot, collected, weathered
+	Kills  int `json:"kills,omitempty"`  // Times the player killed it
+	Deaths int `json:"deaths,omitempty"` // Runs it ended
+}
+
+// codexEntry is one page of the codex: an entity type the player can meet
+type codexEntry struct {
+	ID    string
+	Name  string
+	Text  []string
+	icon  func(g *Game, screen *ebiten.Image, cx, cy float64)
+	stats func(s CodexStats) string
+}
+
+// codexEntries are every entry in codex order
+var codexEntries = []codexEntry{
+	{"platform_normal", "Platform", []string{"Plain and dependable.", "Bounces you up every time."},
+		platformIcon(PlatformNormal), landedStats},
+	{"platform_sticky", "Sticky Platform", []string{"Holds on to your feet", "until you jump off."},
+		platformIcon(PlatformSticky), landedStats},
+	{"platform_disappearing", "Crumbling Platform", []string{"Good for exactly one bounce,", "then it falls apart."},
+		platformIcon(PlatformDisappearing), landedStats},
+	{"platform_spring", "Spring", []string{"A coiled platform that", "throws you extra high."},
+		platformIcon(PlatformSpring), landedStats},
+	{"platform_gamble", "Gamble Platform", []string{"Flickers gold and black. Bounce", "for double score - or a bird wave."},
+		platformIcon(PlatformGamble), landedStats},
+	{"platform_moving", "Moving Platform", []string{"Slides back and forth. Jump", "off and you drift along with it."},
+		platformIcon(PlatformMoving), landedStats},
+	{"platform_phase", "Phase Platform", []string{"Blinks in and out. Flickers", "just before it lets you fall."},
+		platformIcon(PlatformPhase), landedStats},
+	{"bird", "Bird", []string{"Flaps across your way.", "Touch one and the run is over."},
+		birdIcon, func(s CodexStats) string { return fmt.Sprintf("Shot: %d  Fatal: %d", s.Kills, s.Deaths) }},
+	{"boost_speed", "Speed Boost", []string{"Red orb. Moves you faster", "for a while."},
+		boostIcon(BoostSpeed), collectedStats},
+	{"boost_jump", "Jump Boost", []string{"Green orb. Higher jumps", "and a turn at flying."},
+		boostIcon(BoostJump), collectedStats},
+	{"boost_shield", "Shield Boost", []string{"Blue orb. Birds and lightning", "bounce off you."},
+		boostIcon(BoostShield), collectedStats},
+	{"boost_multiplier", "Multiplier Boost", []string{"Gold orb. Every point counts", "double for a while."},
+		boostIcon(BoostMultiplier), collectedStats},
+	{"lightning", "Lightning", []string{"Storms mark a column, then", "strike it. Move aside!"},
+		lightningIcon, func(s CodexStats) string { return fmt.Sprintf("Storms: %d  Fatal: %d", s.Seen, s.Deaths) }},
+}
+
+// platformCodexIDs and boostCodexIDs map entity types to their entries
+var (
+	platformCodexIDs = map[int]string{
+		PlatformNormal:       "platform_normal",
+		PlatformSticky:       "platform_sticky",
+		PlatformDisappearing: "platform_disappearing",
+		PlatformSpring:       "platform_spring",
+		PlatformGamble:       "platform_gamble",
+		PlatformMoving:       "platform_moving",
+		PlatformPhase:        "platform_phase",
+	}
+	boostCodexIDs = map[int]string{
+		BoostSpeed:      "boost_speed",
+		BoostJump:       "boost_jump",
+		BoostShield:     "boost_shield",
+		BoostMultiplier: "boost_multiplier",
+	}
+)
+
+func landedStats(s CodexStats) string    { return fmt.Sprintf("Landed on: %d", s.Seen) }
+func collectedStats(s CodexStats) string { return fmt.Sprintf("Collected: %d", s.Seen) }
+
+// recordCodex books an encounter in the codex, unlocking the entry the
+// first time. It is subscribed to the run's event bus.
+func (g *Game) recordCodex(e Event) {
+	if g.opts.idle || g.replayView != nil {
+		return // The bot's encounters and replayed ones aren't new
+	}
+	var id string
+	var seen, kills, deaths int
+	switch {
+	case e.Kind == EventLanding:
+		id, seen = platformCodexIDs[e.Value], 1
+	case e.Kind == EventKill:
+		id, seen, kills = "bird", 1, 1
+	case e.Kind == EventBoostPickup:
+		id, seen = boostCodexIDs[e.Value], 1
+	case e.Kind == EventWeather && e.Value == WeatherStorm:
+		id, seen = "lightning", 1
+	case e.Kind == EventDeath && DeathCause(e.Value) == DeathBird:
+		id, seen, deaths = "bird", 1, 1
+	case e.Kind == EventDeath && DeathCause(e.Value) == DeathLightning:
+		id, deaths = "lightning", 1
+	}
+	if id == "" {
+		return
+	}
+	if g.save.Codex == nil {
+		g.save.Codex = map[string]CodexStats{}
+	}
+	s := g.save.Codex[id] // Written with the run
+	s.Seen += seen
+	s.Kills += kills
+	s.Deaths += deaths
+	g.save.Codex[id] = s
+}
+
+// codexUnlocked reports whether the player has met entry id
+func (g *Game) codexUnlocked(id string) bool {
+	_, ok := g.save.Codex[id]
+	return ok
+}
+
+// platformIcon draws a shrunk platform of type kind, tinted as in play
+func platformIcon(kind int) func(g *Game, screen *ebiten.Image, cx, cy float64) {
+	return func(g *Game, screen *ebiten.Image, cx, cy float64) {
+		img := g.sprites.Lookup(SpriteKey{Entity: EntityPlatform})
+		if img == nil {
+			return
+		}
+		const scale = 0.8
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(cx-PlatformWidth*scale/2, cy)
+		switch kind {
+		case PlatformSticky:
+			op.ColorM.Scale(1.2, 1.0, 0.4, 1)
+		case PlatformDisappearing:
+			op.ColorM.Scale(1.0, 0.6, 0.6, 1)
+		case PlatformSpring:
+			op.ColorM.Scale(0.6, 1.0, 0.6, 1)
+		case PlatformGamble:
+			op.ColorM.Scale(1.3, 1.1, 0.2, 1)
+		case PlatformMoving:
+			op.ColorM.Scale(0.8, 0.6, 1.3, 1)
+		case PlatformPhase:
+			op.ColorM.Scale(0.5, 1.1, 1.3, 1)
+		}
+		g.drawSprite(screen, img, op)
+		if kind == PlatformSpring {
+			drawSpringCoil(screen, cx, cy)
+		}
+	}
+}
+
+// birdIcon draws a bird facing right
+func birdIcon(g *Game, screen *ebiten.Image, cx, cy float64) {
+	img := g.sprites.Lookup(SpriteKey{Entity: EntityBird, Facing: FacingRight})
+	if img == nil {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(cx-BirdWidth/2, cy-BirdHeight/2)
+	g.drawSprite(screen, img, op)
+}
+
+// boostIcon draws the pickup orb of boost type t with its status letter
+func boostIcon(t int) func(g *Game, screen *ebiten.Image, cx, cy float64) {
+	return func(g *Game, screen *ebiten.Image, cx, cy float64) {
+		drawCircle(screen, cx, cy, BoostRadius*1.5, boostColors[t])
+		drawText(screen, boostLetters[t], cx-3, cy-5, TextSmall, textColor)
+	}
+}
+
+// lightningIcon draws a zigzag bolt
+func lightningIcon(g *Game, screen *ebiten.Image, cx, cy float64) {
+	clr := color.RGBA{255, 240, 120, 255}
+	points := [][2]float64{{4, -16}, {-4, -2}, {4, -2}, {-4, 16}}
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		drawLine(screen, cx+a[0], cy+a[1], cx+b[0], cy+b[1], clr)
+		drawLine(screen, cx+a[0]+1, cy+a[1], cx+b[0]+1, cy+b[1], clr)
+	}
+}
+
+// sceneCodex shows every entity type met so far, with what it does and
+// how often it was met
+type sceneCodex struct{}
+
+func (sceneCodex) Update(g *Game) error {
+	count := len(codexEntries)
+	switch {
+	case g.controller.JustPressed(input.ActionCodex), g.controller.JustPressed(input.ActionPause):
+		g.scenes.Switch(titleScene)
+	case g.controller.JustPressed(input.ActionLeft):
+		g.codexCursor = (g.codexCursor + count - 1) % count
+	case g.controller.JustPressed(input.ActionRight):
+		g.codexCursor = (g.codexCursor + 1) % count
+	default:
+		return nil
+	}
+	g.feedback(FeedbackUIClick)
+	return nil
+}
+
+func (sceneCodex) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Codex", 40, TextLarge, textColor)
+
+	const cellW, cellH = 96.0, 64.0
+	for i, e := range codexEntries {
+		x := 16 + float64(i%3)*(cellW+4)
+		y := 64 + float64(i/3)*(cellH+4)
+		frame := color.RGBA{0, 0, 0, 80}
+		if i == g.codexCursor {
+			frame = color.RGBA{255, 220, 100, 255}
+		}
+		drawRect(screen, x-2, y-2, cellW+4, cellH+4, frame)
+		drawRect(screen, x, y, cellW, cellH, color.RGBA{245, 240, 225, 255})
+		if !g.codexUnlocked(e.ID) {
+			drawText(screen, "?", x+cellW/2-4, y+cellH/2-6, TextLarge, textOutline)
+			continue
+		}
+		e.icon(g, screen, x+cellW/2, y+cellH/2)
+	}
+
+	e := codexEntries[g.codexCursor]
+	rows := (len(codexEntries) + 2) / 3
+	y := 64 + float64(rows)*(cellH+4) + 16
+	if !g.codexUnlocked(e.ID) {
+		drawTextCentered(screen, "???", y, TextLarge, textColor)
+		drawTextCentered(screen, "Not met yet", y+24, TextSmall, textColor)
+	} else {
+		drawTextCentered(screen, e.Name, y, TextLarge, color.RGBA{255, 220, 100, 255})
+		y += 24
+		for _, line := range e.Text {
+			drawTextCentered(screen, line, y, TextSmall, textColor)
+			y += textLineHeight
+		}
+		drawTextCentered(screen, e.stats(g.save.Codex[e.ID]), y+textLineHeight/2, TextSmall, textColor)
+	}
+	drawTextCentered(screen, joinHints("  ", g.moveHint("pick"), g.actionHint(input.ActionCodex, "back")), ScreenHeight-40, TextSmall, textColor)
+}
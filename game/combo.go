@@ -0,0 +1,54 @@
+package game
+
+import "fmt"
+
+// Score multiplier parameters
+const (
+	MultiplierBoost    = 2 // Points count this many times over while the multiplier boost lasts
+	ComboBounces       = 5 // Bounces in a row for each step up the combo multiplier
+	ComboMaxMultiplier = 4 // Highest the combo multiplier climbs
+)
+
+// comboMultiplier is what the current chain of bounces multiplies points by
+func (g *Game) comboMultiplier() int {
+	return min(1+g.combo/ComboBounces, ComboMaxMultiplier)
+}
+
+// chainBounce counts a landing on p toward the combo. A sticky platform
+// breaks the chain; anything else extends it, announcing each step up.
+func (g *Game) chainBounce(p *Platform) {
+	if p.Type == PlatformSticky {
+		g.combo = 0
+		return
+	}
+	before := g.comboMultiplier()
+	g.combo++
+	if m := g.comboMultiplier(); m > before {
+		g.announce(stingerMilestone, "announce.combo", m)
+	}
+}
+
+// comboBonus is the extra the combo adds to points scored in cat
+func (g *Game) comboBonus(cat ScoreCategory, points int) int {
+	if cat.bonus() {
+		return 0
+	}
+	return points * (g.comboMultiplier() - 1)
+}
+
+// multiplierBonus is the extra the multiplier boost adds to points scored
+// in cat
+func (g *Game) multiplierBonus(cat ScoreCategory, points int) int {
+	if cat.bonus() || g.player.BoostType != BoostMultiplier {
+		return 0
+	}
+	return points * (MultiplierBoost - 1)
+}
+
+// comboLines show the chain of bounces once it has started
+func comboLines(g *Game) []hudLine {
+	if g.combo == 0 || g.gameOver || g.mode.scoresHeight() {
+		return nil
+	}
+	return []hudLine{{fmt.Sprintf("Combo x%d (%d)", g.comboMultiplier(), g.combo), TextSmall}}
+}
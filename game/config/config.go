@@ -0,0 +1,162 @@
+// Package config holds the machine-wide preferences of the settings
+// screen. They live in settings.json next to the save profiles, so every
+// profile on the machine shares them.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// FileName is the name of the config file in the game's config directory
+const FileName = "settings.json"
+
+// Difficulty is a preset scaling how fast the game gets harder
+type Difficulty string
+
+// Difficulty presets
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyNormal Difficulty = "normal"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// Difficulties lists the presets in menu order
+var Difficulties = []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+// difficultyScales multiply the climb score difficulty is judged on
+var difficultyScales = map[Difficulty]float64{
+	DifficultyEasy:   0.75,
+	DifficultyNormal: 1,
+	DifficultyHard:   1.5,
+}
+
+// Scale is the preset's multiplier on the climb score difficulty follows
+func (d Difficulty) Scale() float64 {
+	if s, ok := difficultyScales[d]; ok {
+		return s
+	}
+	return 1
+}
+
+// Next is the preset after d in menu order, wrapping around
+func (d Difficulty) Next() Difficulty {
+	i := slices.Index(Difficulties, d)
+	return Difficulties[(i+1)%len(Difficulties)]
+}
+
+// DefaultTickRate is the simulation rate the game is tuned at, in ticks
+// per second
+const DefaultTickRate = 60
+
+// TickRates are the simulation rates on offer: battery saving, the
+// default and high refresh
+var TickRates = []int{30, DefaultTickRate, 120}
+
+// Config is everything the settings screen changes
+type Config struct {
+	Volume     float64    `json:"volume"` // Master volume over every channel, 0 to 1
+	Difficulty Difficulty `json:"difficulty"`
+	Weather    bool       `json:"weather"` // Random weather changes; off keeps the sky clear
+	Fullscreen bool       `json:"fullscreen"`
+	ShowFPS    bool       `json:"show_fps"`
+	TickRate   int        `json:"tick_rate"`         // Simulation ticks per second, one of TickRates
+	Window     *Window    `json:"window,omitempty"`  // Where the window was when the game last quit
+	Webhook    *Webhook   `json:"webhook,omitempty"` // Chat channel told about personal bests; nil tells no one
+}
+
+// Webhook formats, by the chat service the URL belongs to
+const (
+	WebhookDiscord = "discord"
+	WebhookSlack   = "slack"
+)
+
+// Webhook is a chat channel's incoming webhook, told about new personal
+// bests and finished daily challenges
+type Webhook struct {
+	URL    string `json:"url"`
+	Format string `json:"format"` // WebhookDiscord or WebhookSlack
+}
+
+// Window is the placement of the game window: its monitor, its position
+// from that monitor's top-left corner and its size, as a multiple of the
+// game's resolution so it fits whatever runs in it
+type Window struct {
+	Monitor      string  `json:"monitor"`       // Name the system gives the monitor
+	MonitorIndex int     `json:"monitor_index"` // Position in the system's list, from 1, for monitors sharing a name
+	X            int     `json:"x"`
+	Y            int     `json:"y"`
+	Scale        float64 `json:"scale"`
+}
+
+// Default returns the config used when there is no settings.json and for
+// keys missing from one
+func Default() Config {
+	return Config{
+		Volume:     1,
+		Difficulty: DifficultyNormal,
+		Weather:    true,
+		TickRate:   DefaultTickRate,
+	}
+}
+
+// Path returns the location of settings.json in the user config dir
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "godlejump", FileName), nil
+}
+
+// Load reads the config at path. A missing file is not an error; the
+// defaults are returned instead.
+func Load(path string) (Config, error) {
+	c := Default()
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return Default(), fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if !slices.Contains(Difficulties, c.Difficulty) {
+		bad := c.Difficulty
+		c.Difficulty = DifficultyNormal
+		return c, fmt.Errorf("config %s: unknown difficulty %q", path, bad)
+	}
+	if !slices.Contains(TickRates, c.TickRate) {
+		bad := c.TickRate
+		c.TickRate = DefaultTickRate
+		return c, fmt.Errorf("config %s: unsupported tick rate %d", path, bad)
+	}
+	if w := c.Webhook; w != nil && w.Format != WebhookDiscord && w.Format != WebhookSlack {
+		c.Webhook = nil
+		return c, fmt.Errorf("config %s: unknown webhook format %q", path, w.Format)
+	}
+	c.Volume = max(0, min(1, c.Volume))
+	return c, nil
+}
+
+// Write saves c to path atomically
+func (c Config) Write(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
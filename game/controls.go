@@ -0,0 +1,248 @@
+package game
+
+import (
+	"image/color"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// BindingsFile holds remapped controls, next to the profile's save.json
+const BindingsFile = "bindings.json"
+
+// bindingsPath is where the profile's remapped controls live, "" without a save file
+func (s *SaveData) bindingsPath() string {
+	if s.path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(s.path), BindingsFile)
+}
+
+// keyboardController is the rebindable controller, nil when a bot or
+// replay is driving the game. In co-op it is player one's.
+func (g *Game) keyboardController() *input.Controller {
+	if coop, ok := g.controller.(*input.Coop); ok {
+		return coop.Mover
+	}
+	c, _ := g.controller.(*input.Controller)
+	return c
+}
+
+// keyLabels are the short names hints give keys whose own are long
+var keyLabels = map[ebiten.Key]string{
+	ebiten.KeyArrowLeft:    "Left",
+	ebiten.KeyArrowRight:   "Right",
+	ebiten.KeyArrowUp:      "Up",
+	ebiten.KeyArrowDown:    "Down",
+	ebiten.KeyEscape:       "Esc",
+	ebiten.KeyShiftRight:   "RShift",
+	ebiten.KeyControlRight: "RCtrl",
+	ebiten.KeyPageUp:       "PgUp",
+	ebiten.KeyPageDown:     "PgDn",
+	ebiten.KeyInsert:       "Ins",
+	ebiten.KeyDelete:       "Del",
+	ebiten.KeyBackslash:    "\\",
+	ebiten.KeySlash:        "/",
+	ebiten.KeyQuote:        "'",
+	ebiten.KeySemicolon:    ";",
+	ebiten.KeyPeriod:       ".",
+	ebiten.KeyComma:        ",",
+	ebiten.KeyMinus:        "-",
+	ebiten.KeyEqual:        "=",
+}
+
+// mouseLabels are the short names hints give mouse buttons
+var mouseLabels = map[ebiten.MouseButton]string{
+	ebiten.MouseButtonLeft:   "LMB",
+	ebiten.MouseButtonRight:  "RMB",
+	ebiten.MouseButtonMiddle: "MMB",
+	ebiten.MouseButton3:      "MB4",
+	ebiten.MouseButton4:      "MB5",
+}
+
+// hintBindings are the bindings hints describe: the rebindable
+// controller's, or the default preset's when nothing rebindable drives the
+// game
+func (g *Game) hintBindings() input.Bindings {
+	if c := g.keyboardController(); c != nil {
+		return c.Bindings
+	}
+	return input.Presets[0].Bindings
+}
+
+// actionKey names the input a hint should show for a: the first key bound
+// to it, else its first mouse button, else its first gamepad button, and
+// "" when nothing is bound
+func (g *Game) actionKey(a input.Action) string {
+	b := g.hintBindings().Actions[a]
+	switch {
+	case len(b.Keys) > 0:
+		if label, ok := keyLabels[b.Keys[0]]; ok {
+			return label
+		}
+		return strings.TrimPrefix(b.Keys[0].String(), "Digit")
+	case len(b.MouseButtons) > 0:
+		return mouseLabels[b.MouseButtons[0]]
+	case len(b.GamepadButtons) > 0:
+		return strings.ToUpper(input.GamepadButtonName(b.GamepadButtons[0]))
+	}
+	return ""
+}
+
+// actionHint is a hint such as "B: Seeds" for a, or "" when a is unbound
+func (g *Game) actionHint(a input.Action, what string) string {
+	key := g.actionKey(a)
+	if key == "" {
+		return ""
+	}
+	return key + ": " + what
+}
+
+// moveHint is the hint for moving, "" when steering is left to the mouse
+func (g *Game) moveHint(what string) string {
+	left, right := g.actionKey(input.ActionLeft), g.actionKey(input.ActionRight)
+	if left == "" || right == "" {
+		return ""
+	}
+	return left + "/" + right + ": " + what
+}
+
+// joinHints puts hints on one line sep apart, leaving out empty ones
+func joinHints(sep string, hints ...string) string {
+	return strings.Join(slices.DeleteFunc(hints, func(h string) bool { return h == "" }), sep)
+}
+
+// menuPressed reports a fixed key or gamepad button press. The controls
+// screen reads these instead of actions, so a bad binding can never lock
+// the player out of fixing it.
+func menuPressed(k ebiten.Key, b ebiten.StandardGamepadButton) bool {
+	if inpututil.IsKeyJustPressed(k) {
+		return true
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && inpututil.IsStandardGamepadButtonJustPressed(id, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureBinding waits for the next key, mouse button or gamepad button
+// and makes it the only input of that kind bound to a. It reports whether
+// capturing is over, including when Escape cancelled it.
+func captureBinding(c *input.Controller, a input.Action) bool {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+	b := &c.Bindings.Actions[a]
+	if keys := inpututil.AppendJustPressedKeys(nil); len(keys) > 0 {
+		b.Keys = keys[:1]
+		return true
+	}
+	for m := ebiten.MouseButton0; m <= ebiten.MouseButtonMax; m++ {
+		if inpututil.IsMouseButtonJustPressed(m) {
+			b.MouseButtons = []ebiten.MouseButton{m}
+			return true
+		}
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if buttons := inpututil.AppendJustPressedStandardGamepadButtons(id, nil); len(buttons) > 0 {
+			b.GamepadButtons = buttons[:1]
+			return true
+		}
+	}
+	return false
+}
+
+// sceneControls lists every action with its inputs and rebinds them
+type sceneControls struct{}
+
+func (sceneControls) Update(g *Game) error {
+	c := g.keyboardController()
+	if c == nil {
+		return nil // Sped-up dev frames wrap the controller; the title never opens this without one
+	}
+	actions := input.Actions()
+	a := actions[g.controlsCursor]
+	preset := input.PresetByID(g.save.Settings.InputPreset).Bindings
+
+	if g.controlsCapture {
+		if captureBinding(c, a) {
+			g.controlsCapture = false
+			g.feedback(FeedbackUIClick)
+		}
+		return nil
+	}
+
+	switch {
+	case menuPressed(ebiten.KeyEscape, ebiten.StandardGamepadButtonRightRight):
+		if path := g.save.bindingsPath(); path != "" {
+			if err := input.WriteBindings(path, c.Bindings, preset); err != nil {
+				log.Printf("Failed to write bindings: %v", err)
+			}
+		}
+		g.scenes.Switch(titleScene)
+		g.feedback(FeedbackUIClick)
+	case menuPressed(ebiten.KeyArrowUp, ebiten.StandardGamepadButtonLeftTop):
+		g.controlsCursor = (g.controlsCursor + len(actions) - 1) % len(actions)
+		g.feedback(FeedbackUIClick)
+	case menuPressed(ebiten.KeyArrowDown, ebiten.StandardGamepadButtonLeftBottom):
+		g.controlsCursor = (g.controlsCursor + 1) % len(actions)
+		g.feedback(FeedbackUIClick)
+	case menuPressed(ebiten.KeyEnter, ebiten.StandardGamepadButtonRightBottom):
+		g.controlsCapture = true
+		g.feedback(FeedbackUIClick)
+	case menuPressed(ebiten.KeyBackspace, ebiten.StandardGamepadButtonRightLeft):
+		c.Bindings.Actions[a] = preset.Actions[a]
+		g.feedback(FeedbackUIClick)
+	}
+	return nil
+}
+
+func (sceneControls) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Controls", 40, TextLarge, textColor)
+	c := g.keyboardController()
+	if c == nil {
+		return
+	}
+
+	clash := color.RGBA{255, 90, 90, 255}
+	y := 70.0
+	for i, a := range input.Actions() {
+		clr := textColor
+		prefix := "  "
+		if i == g.controlsCursor {
+			prefix = "> "
+			clr = color.RGBA{255, 220, 100, 255}
+		}
+		inputs := c.Bindings.Actions[a].String()
+		if i == g.controlsCursor && g.controlsCapture {
+			inputs = "Press a key or button..."
+		} else if len(c.Bindings.Conflicts(a)) > 0 {
+			clr = clash
+		}
+		drawText(screen, prefix+a.String(), 20, y, TextSmall, clr)
+		drawText(screen, inputs, 110, y, TextSmall, clr)
+		y += 1.5 * textLineHeight
+	}
+
+	if clashes := c.Bindings.Conflicts(input.Actions()[g.controlsCursor]); len(clashes) > 0 {
+		names := make([]string, len(clashes))
+		for i, o := range clashes {
+			names[i] = o.String()
+		}
+		drawTextCentered(screen, "Clashes with "+strings.Join(names, ", "), ScreenHeight-60, TextSmall, clash)
+	}
+	drawTextCentered(screen, "Up/Down: pick  Enter: rebind", ScreenHeight-40, TextSmall, textColor)
+	drawTextCentered(screen, "Backspace: reset  Esc: save and back", ScreenHeight-40+textLineHeight, TextSmall, textColor)
+}
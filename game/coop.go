@@ -0,0 +1,42 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ReticleRadius is the size of the co-op gunner's crosshair
+const ReticleRadius = 7.0
+
+// aimAtReticle fires b from the player toward the gunner's reticle at the
+// usual bullet speed. A reticle right on the player keeps the shot level.
+func (g *Game) aimAtReticle(b *Bullet) {
+	dx := g.gunner.X - b.X
+	dy := g.gunner.Y - g.screenY(b.Y)
+	dist := math.Hypot(dx, dy)
+	if dist < 1 {
+		return
+	}
+	b.Direction = 1
+	if dx < 0 {
+		b.Direction = -1
+	}
+	b.Speed = g.tuning.BulletSpeed * math.Abs(dx) / dist
+	b.SpeedY = g.tuning.BulletSpeed * dy / dist
+}
+
+// drawReticle draws the gunner's crosshair
+func (g *Game) drawReticle(screen *ebiten.Image) {
+	x, y := g.gunner.X, g.gunner.Y
+	clr := color.RGBA{255, 90, 90, 255}
+	if g.timers.Active(TimerShoot) {
+		clr = color.RGBA{140, 60, 60, 255} // Reloading
+	}
+	for a := 0.0; a < 2*math.Pi; a += math.Pi / 2 {
+		sx, sy := math.Cos(a), math.Sin(a)
+		drawLine(screen, x+sx*ReticleRadius/2, y+sy*ReticleRadius/2, x+sx*ReticleRadius*1.5, y+sy*ReticleRadius*1.5, clr)
+	}
+	drawRect(screen, x-1, y-1, 2, 2, clr)
+}
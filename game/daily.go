@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"doodlejump/game/input"
+)
+
+// DailyRecord is the profile's best at the daily challenge of Date
+type DailyRecord struct {
+	Date     string `json:"date"` // UTC day, YYYY-MM-DD
+	Best     int    `json:"best"`
+	Attempts int    `json:"attempts"`
+}
+
+// DailyDate is the UTC day of t the daily challenge goes by, so the whole
+// world shares each challenge
+func DailyDate(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// DailySeed is the seed of the daily challenge on t's UTC day
+func DailySeed(t time.Time) int64 {
+	y, m, d := t.UTC().Date()
+	return int64(y*10000 + int(m)*100 + d)
+}
+
+// untilNextDaily is how long the challenge of t's day has left
+func untilNextDaily(t time.Time) time.Duration {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC).Sub(t)
+}
+
+// withDaily plays today's challenge by the classic rules. base is the
+// game's own options, which leaving the challenge goes back to.
+func withDaily(base []Option) Option {
+	return func(o *gameOptions) {
+		now := time.Now()
+		o.daily = DailyDate(now)
+		o.dailyBase = base
+		o.seed, o.seeded = DailySeed(now), true
+		o.level, o.hardcore, o.tuning = "", false, DefaultGameConfig()
+		o.mode, o.modeSet = ModeClassic, true
+	}
+}
+
+// startDaily leaves the title for a run of today's challenge; restarts
+// retry it until the player leaves
+func (g *Game) startDaily() {
+	base := g.optionList
+	g.restartWith(withDaily(base))
+}
+
+// leaveDaily goes back to the title and the game's own options
+func (g *Game) leaveDaily() {
+	g.optionList = g.opts.dailyBase
+	g.restartWith()
+	g.scenes.Switch(titleScene)
+}
+
+// recordDaily books a finished challenge run, starting the record over
+// when the challenge changed since the last attempt
+func (g *Game) recordDaily() {
+	if g.opts.daily == "" {
+		return
+	}
+	d := &g.save.Daily
+	if d.Date != g.opts.daily {
+		*d = DailyRecord{Date: g.opts.daily}
+	}
+	d.Attempts++
+	d.Best = max(d.Best, g.score) // Written with the run
+}
+
+// todaysDaily is the profile's record at today's challenge
+func (g *Game) todaysDaily() DailyRecord {
+	if today := DailyDate(time.Now()); g.save.Daily.Date != today {
+		return DailyRecord{Date: today}
+	}
+	return g.save.Daily
+}
+
+// dailyTitleLines are the title's offer of today's challenge
+func (g *Game) dailyTitleLines() []string {
+	d := g.todaysDaily()
+	left := untilNextDaily(time.Now()).Truncate(time.Second)
+	h, m, s := int(left.Hours()), int(left.Minutes())%60, int(left.Seconds())%60
+	lines := []string{g.actionHint(input.ActionDaily, "Daily challenge "+d.Date)}
+	if d.Attempts > 0 {
+		lines[0] += " (best " + strconv.Itoa(d.Best) + ")"
+	}
+	return append(lines, fmt.Sprintf("Next challenge in %d:%02d:%02d", h, m, s))
+}
+
+// dailyLines name the challenge during a run and the record after it
+func dailyLines(g *Game) []hudLine {
+	if g.opts.daily == "" {
+		return nil
+	}
+	if !g.gameOver {
+		return []hudLine{{"Daily " + g.opts.daily, TextSmall}}
+	}
+	d := g.save.Daily
+	return []hudLine{
+		{"Daily best: " + strconv.Itoa(d.Best) + " (" + strconv.Itoa(d.Attempts) + " tries)", TextSmall},
+		{g.actionHint(input.ActionDaily, "Leave the challenge"), TextSmall},
+	}
+}
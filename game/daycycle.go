@@ -0,0 +1,30 @@
+package game
+
+// Day cycle rewards
+const (
+	DayBonusScore      = 100                // Survival bonus for seeing a full day through
+	DayDifficultyScore = ScorePerDifficulty // Each completed day counts as this much extra climb for difficulty
+)
+
+// daysPassed is how many times the day cycle has wrapped during this run
+func (g *Game) daysPassed() int {
+	return int(float64(g.climbed())/DayCycleLength + g.initialTimeOfDay)
+}
+
+// updateDays greets each new day with a banner and a survival bonus. The
+// harder birds follow from difficulty counting completed days.
+func (g *Game) updateDays() {
+	for days := g.daysPassed(); g.day < days; {
+		g.day++
+		g.addScore(ScoreBonuses, DayBonusScore)
+		g.events.Publish(Event{Kind: EventNewDay, Value: g.day + 1, Points: DayBonusScore})
+		g.announce(stingerMilestone, "announce.day", g.day+1)
+	}
+}
+
+// difficultyScore is the climb difficulty is judged on, scaled by the
+// settings' difficulty preset
+func (g *Game) difficultyScore() int {
+	score := g.climbed() + g.day*DayDifficultyScore + g.save.Prestige*PrestigeDifficultyScore
+	return int(float64(score) * g.config.Difficulty.Scale())
+}
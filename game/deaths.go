@@ -0,0 +1,41 @@
+package game
+
+// deathTitles head the game-over screen with what ended the run; tips for
+// next time come from the tips file
+var deathTitles = [deathCauseCount]string{
+	DeathBird:      "Hit by a bird!",
+	DeathFall:      "You fell!",
+	DeathEdge:      "The edge got you!",
+	DeathLightning: "Struck by lightning!",
+	DeathCold:      "You froze!",
+	DeathTime:      "Out of time!",
+}
+
+// title is what the game-over screen says about c
+func (c DeathCause) title() string {
+	if c < 0 || c >= deathCauseCount {
+		return "Game Over!"
+	}
+	return deathTitles[c]
+}
+
+// recordDeath counts a run ending by cause toward the profile's lifetime
+// totals; the heatmap only keeps the latest MaxDeathRecords
+func (s *SaveData) recordDeath(cause DeathCause) {
+	if s.DeathCounts == nil {
+		s.DeathCounts = map[DeathCause]int{}
+	}
+	s.DeathCounts[cause]++
+}
+
+// deadliest is the cause that ended the most of the profile's runs, false
+// before the first
+func (s *SaveData) deadliest() (DeathCause, bool) {
+	worst, n := DeathCause(0), 0
+	for c := DeathCause(0); c < deathCauseCount; c++ {
+		if s.DeathCounts[c] > n {
+			worst, n = c, s.DeathCounts[c]
+		}
+	}
+	return worst, n > 0
+}
@@ -0,0 +1,62 @@
+//go:build devtools
+
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// devTimeScales are the speeds F8 cycles through
+var devTimeScales = []float64{1, 0.25, 0.5, 2}
+
+// devClock pauses, single-steps and rescales the simulation. It is package
+// state so a paused clock stays paused across restarts.
+var devClock struct {
+	paused bool
+	scale  int     // Index into devTimeScales
+	carry  float64 // Fractional ticks owed from slow time scales
+}
+
+// devTicks handles the clock keys and returns how many ticks to run:
+// F6 pauses, F7 runs one tick while paused, F8 cycles the time scale
+func devTicks() int {
+	c := &devClock
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		c.paused = !c.paused
+		c.carry = 0
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF8) {
+		c.scale = (c.scale + 1) % len(devTimeScales)
+		c.carry = 0
+	}
+	if c.paused {
+		if inpututil.IsKeyJustPressed(ebiten.KeyF7) {
+			return 1
+		}
+		return 0
+	}
+	c.carry += devTimeScales[c.scale]
+	ticks := int(c.carry)
+	c.carry -= float64(ticks)
+	return ticks
+}
+
+// drawDevClock shows the clock state whenever it isn't running at 1x
+func drawDevClock(screen *ebiten.Image) {
+	var label string
+	switch c := &devClock; {
+	case c.paused:
+		label = "PAUSED (F7: step)"
+	case devTimeScales[c.scale] != 1:
+		label = fmt.Sprintf("x%g", devTimeScales[c.scale])
+	default:
+		return
+	}
+	w := textWidth(label, TextSmall)
+	drawRect(screen, ScreenWidth-w-8, ScreenHeight-18, w+6, 14, color.RGBA{0, 0, 0, 160})
+	drawText(screen, label, ScreenWidth-w-5, ScreenHeight-15, TextSmall, textColor)
+}
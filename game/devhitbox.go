@@ -0,0 +1,44 @@
+//go:build devtools
+
+package game
+
+import (
+	"image/color"
+
+	"doodlejump/game/physics"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// devHitboxes toggles the collision overlay (F10)
+var devHitboxes bool
+
+// strokeRect outlines world-space box r
+func (g *Game) strokeRect(screen *ebiten.Image, r physics.Rect, clr color.Color) {
+	y := g.screenY(r.Y)
+	drawLine(screen, r.X, y, r.X+r.W, y, clr)
+	drawLine(screen, r.X, y+r.H, r.X+r.W, y+r.H, clr)
+	drawLine(screen, r.X, y, r.X, y+r.H, clr)
+	drawLine(screen, r.X+r.W, y, r.X+r.W, y+r.H, clr)
+}
+
+// drawHitboxes outlines what every collision test actually uses: sprite
+// bounds dimmed, masks and pickup shapes bright
+func drawHitboxes(g *Game, screen *ebiten.Image) {
+	dim := color.RGBA{255, 255, 255, 90}
+	for i := range g.birds {
+		b := &g.birds[i]
+		g.strokeRect(screen, physics.Rect{X: b.X, Y: b.Y, W: BirdWidth, H: BirdHeight}, dim)
+		g.strokeRect(screen, g.birdRect(b), color.RGBA{255, 60, 60, 255})
+	}
+	for i := range g.platforms {
+		g.strokeRect(screen, g.platforms[i].rect(), color.RGBA{60, 255, 60, 200})
+	}
+	for i := range g.boosts {
+		c := g.boosts[i].circle()
+		strokeEllipse(screen, c.X, g.screenY(c.Y), c.R, c.R, color.RGBA{80, 200, 255, 255})
+	}
+	g.strokeRect(screen, g.player.rect(), color.RGBA{80, 200, 255, 160})
+	body := physics.Rect{X: g.player.X - PlayerWidth/4, Y: g.player.Y - PlayerHeight/4, W: PlayerWidth / 2, H: PlayerHeight / 2}
+	g.strokeRect(screen, body, color.RGBA{255, 220, 60, 255})
+}
@@ -0,0 +1,148 @@
+//go:build devtools
+
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"doodlejump/game/level"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Difficulty plot layout
+const (
+	devPlotScores = SpeedRampLevels * ScorePerDifficulty * 3 / 2 // Score range on the x axis
+	devPlotLeft   = 30.0
+	devPlotWidth  = ScreenWidth - devPlotLeft - 8
+	devPlotHeight = 80.0
+)
+
+func init() {
+	devUpdate = func(g *Game) int {
+		if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+			g.showDev = !g.showDev
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyF10) {
+			devHitboxes = !devHitboxes
+		}
+		return devTicks()
+	}
+	devOverlay = func(g *Game, screen *ebiten.Image) {
+		if devHitboxes {
+			drawHitboxes(g, screen)
+		}
+		if g.showDev {
+			drawDifficultyCurves(g, screen)
+		}
+		drawDevClock(screen)
+	}
+}
+
+// platformColors tells platform types apart in the probability plot
+var platformColors = map[string]color.RGBA{
+	"normal":       {120, 220, 120, 255},
+	"sticky":       {220, 160, 60, 255},
+	"disappearing": {160, 160, 160, 255},
+	"spring":       {80, 180, 255, 255},
+	"gamble":       {255, 200, 40, 255},
+	"moving":       {190, 130, 255, 255},
+	"phase":        {90, 230, 240, 255},
+}
+
+// devSeries is one line on a plot
+type devSeries struct {
+	name  string
+	color color.RGBA
+	value func(p DifficultyPoint) float64
+}
+
+// drawDifficultyCurves plots the active difficulty profile against score
+func drawDifficultyCurves(g *Game, screen *ebiten.Image) {
+	drawRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{10, 10, 20, 235})
+	p := g.difficultyCurve
+	drawText(screen, fmt.Sprintf("Difficulty: %s", g.level.Name), 5, 5, TextSmall, textColor)
+
+	apex := apexHeight(g.jumpVelocity, g.gravity, g.step)
+	plots := []struct {
+		title  string
+		top    float64
+		series []devSeries
+	}{
+		{"Birds", 22, []devSeries{
+			{"count", color.RGBA{255, 90, 90, 255}, func(d DifficultyPoint) float64 { return float64(d.Birds) }},
+		}},
+		{"Bird speed", 22 + devPlotHeight + 18, []devSeries{
+			{"min", color.RGBA{255, 200, 80, 255}, func(d DifficultyPoint) float64 { return d.SpeedMin }},
+			{"max", color.RGBA{255, 120, 40, 255}, func(d DifficultyPoint) float64 { return d.SpeedMax }},
+		}},
+		{"Platform odds", 22 + 2*(devPlotHeight+18), platformSeries()},
+		{"Gap vs jump apex (px)", 22 + 3*(devPlotHeight+18), []devSeries{
+			{"gap", color.RGBA{200, 200, 255, 255}, func(d DifficultyPoint) float64 { return d.Gap }},
+			{"apex", color.RGBA{120, 255, 200, 255}, func(DifficultyPoint) float64 { return apex }},
+		}},
+	}
+	for _, pl := range plots {
+		drawDevPlot(screen, p, pl.title, pl.top, pl.series, g.climbed())
+	}
+	drawText(screen, fmt.Sprintf("0 .. %d score   F9: close", devPlotScores), 5, ScreenHeight-14, TextSmall, textColor)
+}
+
+// platformSeries returns one line per platform type
+func platformSeries() []devSeries {
+	series := make([]devSeries, 0, len(level.PlatformTypes))
+	for _, name := range level.PlatformTypes {
+		series = append(series, devSeries{name, platformColors[name], func(d DifficultyPoint) float64 {
+			return d.PlatformChance[name]
+		}})
+	}
+	return series
+}
+
+// drawDevPlot draws one framed plot of series over the score range, with a
+// marker at the current score
+func drawDevPlot(screen *ebiten.Image, p DifficultyProfile, title string, top float64, series []devSeries, score int) {
+	bottom := top + devPlotHeight
+	frame := color.RGBA{90, 90, 120, 255}
+	drawLine(screen, devPlotLeft, top, devPlotLeft, bottom, frame)
+	drawLine(screen, devPlotLeft, bottom, devPlotLeft+devPlotWidth, bottom, frame)
+
+	// Scale the y axis to the largest value any series reaches
+	peak := 0.0
+	for s := 0; s <= devPlotScores; s++ {
+		d := p.At(s)
+		for _, sr := range series {
+			peak = max(peak, sr.value(d))
+		}
+	}
+	if peak == 0 {
+		peak = 1
+	}
+	drawText(screen, fmt.Sprintf("%.4g", peak), 2, top, TextSmall, textColor)
+
+	for _, sr := range series {
+		prevX, prevY := 0.0, 0.0
+		for s := 0; s <= devPlotScores; s++ {
+			x := devPlotLeft + float64(s)/devPlotScores*devPlotWidth
+			y := bottom - sr.value(p.At(s))/peak*devPlotHeight
+			if s > 0 {
+				drawLine(screen, prevX, prevY, x, y, sr.color)
+			}
+			prevX, prevY = x, y
+		}
+	}
+
+	if score <= devPlotScores {
+		x := devPlotLeft + float64(score)/devPlotScores*devPlotWidth
+		drawLine(screen, x, top, x, bottom, color.RGBA{255, 255, 255, 120})
+	}
+
+	// Title above the plot, legend stacked in its top-right corner
+	drawText(screen, title, devPlotLeft+2, top-12, TextSmall, textColor)
+	right := devPlotLeft + devPlotWidth
+	for i, sr := range series {
+		drawText(screen, sr.name, right-textWidth(sr.name, TextSmall), top+2+float64(i)*textLineHeight, TextSmall, sr.color)
+	}
+}
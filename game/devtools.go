@@ -0,0 +1,27 @@
+package game
+
+import (
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Developer hooks. Only builds with the devtools tag set them, so release
+// builds pay a nil check and nothing else.
+var (
+	// devUpdate handles debug keys and returns how many ticks to run this frame
+	devUpdate func(g *Game) int
+	// devOverlay draws debug screens over the game
+	devOverlay func(g *Game, screen *ebiten.Image)
+)
+
+// heldOnly hides one-shot presses from the ticks after the first in a
+// frame, so a sped-up frame doesn't fire a toggle twice
+type heldOnly struct {
+	input.Source
+}
+
+func (heldOnly) JustPressed(input.Action) bool { return false }
+
+// Update is skipped so latched inputs only advance once per frame
+func (heldOnly) Update() {}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"doodlejump/game/level"
+)
+
+// SpeedRampLevels is how many difficulty levels bird speeds take to reach their maximum
+const SpeedRampLevels = 10
+
+// DifficultyProfile is how the game gets harder as the score rises. It is
+// built from the level so the dev screen plots exactly what the game plays.
+type DifficultyProfile struct {
+	ScorePerLevel   int        // Climb score between difficulty levels
+	InitialBirds    int        // Birds at difficulty 0
+	MaxBirds        int        // Bird cap
+	InitialSpeed    [2]float64 // Min and max bird speed at difficulty 0
+	MaxSpeed        [2]float64 // Min and max bird speed after SpeedRampLevels
+	SpeedScale      float64    // Level multiplier on every bird speed
+	PlatformWeights map[string]int
+	PlatformSpacing float64 // Vertical gap between platforms, in pixels
+}
+
+// DifficultyPoint is the state of the curve at one score
+type DifficultyPoint struct {
+	Level          int
+	Birds          int
+	SpeedMin       float64
+	SpeedMax       float64
+	PlatformChance map[string]float64 // Probability of each platform type
+	Gap            float64
+}
+
+// newDifficultyProfile derives the difficulty curve of lvl under tuning t
+func newDifficultyProfile(lvl *level.Level, t GameConfig) DifficultyProfile {
+	return DifficultyProfile{
+		ScorePerLevel:   t.ScorePerDifficulty,
+		InitialBirds:    t.InitialBirds,
+		MaxBirds:        lvl.SpawnTable.MaxBirds,
+		InitialSpeed:    t.InitialBirdSpeed,
+		MaxSpeed:        t.MaxBirdSpeed,
+		SpeedScale:      lvl.SpawnTable.BirdSpeedScale,
+		PlatformWeights: lvl.SpawnTable.Platforms,
+		PlatformSpacing: LevelRules().PlatformSpacing,
+	}
+}
+
+// At evaluates the profile at a climb score
+func (p DifficultyProfile) At(score int) DifficultyPoint {
+	lvl := score / p.ScorePerLevel
+	progress := min(float64(lvl)/SpeedRampLevels, 1)
+
+	weights := movingWeights(p.PlatformWeights, score)
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	chance := make(map[string]float64, len(level.PlatformTypes))
+	for _, name := range level.PlatformTypes {
+		if total > 0 {
+			chance[name] = float64(weights[name]) / float64(total)
+		}
+	}
+
+	return DifficultyPoint{
+		Level:          lvl,
+		Birds:          min(p.InitialBirds+lvl, p.MaxBirds),
+		SpeedMin:       (p.InitialSpeed[0] + progress*(p.MaxSpeed[0]-p.InitialSpeed[0])) * p.SpeedScale,
+		SpeedMax:       (p.InitialSpeed[1] + progress*(p.MaxSpeed[1]-p.InitialSpeed[1])) * p.SpeedScale,
+		PlatformChance: chance,
+		Gap:            p.PlatformSpacing,
+	}
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// drawScale is the world transform: the pixels of the image being drawn
+// that one internal pixel covers. Every draw helper below, text and
+// sprites take their positions in internal pixels and apply it.
+var drawScale = 1.0
+
+// drawScaled runs draw with the world transform set to scale, so a target
+// scale times the internal resolution gets a frame drawn at its own
+// resolution rather than an upscaled one
+func drawScaled(scale float64, draw func()) {
+	prev := drawScale
+	drawScale = scale
+	defer func() { drawScale = prev }()
+	draw()
+}
+
+// drawRect fills a rectangle like ebitenutil.DrawRect, through the world transform
+func drawRect(dst *ebiten.Image, x, y, w, h float64, clr color.Color) {
+	s := drawScale
+	vector.DrawFilledRect(dst, float32(x*s), float32(y*s), float32(w*s), float32(h*s), clr, false)
+}
+
+// drawLine draws a line like ebitenutil.DrawLine, one internal pixel wide
+func drawLine(dst *ebiten.Image, x1, y1, x2, y2 float64, clr color.Color) {
+	s := drawScale
+	vector.StrokeLine(dst, float32(x1*s), float32(y1*s), float32(x2*s), float32(y2*s), float32(s), clr, false)
+}
+
+// drawCircle fills a circle like ebitenutil.DrawCircle, through the world transform
+func drawCircle(dst *ebiten.Image, cx, cy, r float64, clr color.Color) {
+	s := drawScale
+	vector.DrawFilledCircle(dst, float32(cx*s), float32(cy*s), float32(r*s), clr, false)
+}
+
+// drawImage draws img like dst.DrawImage, with op's GeoM in internal
+// pixels. Images are scaled up with the transform, so sprites stay the
+// pixel art they are drawn as while mountains show their source detail.
+func drawImage(dst, img *ebiten.Image, op *ebiten.DrawImageOptions) {
+	if drawScale != 1 {
+		scaled := ebiten.DrawImageOptions{}
+		if op != nil {
+			scaled = *op
+		}
+		scaled.GeoM.Scale(drawScale, drawScale)
+		op = &scaled
+	}
+	dst.DrawImage(img, op)
+}
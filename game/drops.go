@@ -0,0 +1,101 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"doodlejump/game/physics"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Drop physics: a dropped pickup falls, bounces once on the first platform
+// it meets, settles on the next and vanishes if nobody catches it in time
+const (
+	DropLifetime  = 6.0 // Seconds a drop lasts before it vanishes
+	DropBlinkTime = 1.5 // Drops blink for their last seconds
+	DropBounce    = 0.5 // Share of its fall speed a drop keeps on its bounce
+	dropSparkles  = 3   // Glints circling each drop
+)
+
+// drop turns a resting boost into a falling one thrown at vx, vy. Anything
+// that spills pickups into the world goes through here.
+func (b *Boost) drop(vx, vy float64) {
+	b.VX, b.VY = vx, vy
+	b.Falling, b.Bounced = true, false
+	b.Life = DropLifetime
+	b.Mount.Mounted = false
+}
+
+// updateDrop moves a dropped boost under gravity and counts its life down.
+// A drop that settles takes the nearest free slot of its platform, or
+// stays loose on a full one. Layout boosts, with no Life, never fall.
+func (g *Game) updateDrop(b *Boost) {
+	if b.Life <= 0 {
+		return
+	}
+	if b.Life -= g.dt; b.Life <= 0 {
+		b.Active = false
+		return
+	}
+	if !b.Falling {
+		return
+	}
+
+	b.X += b.VX * g.step
+	if g.world.Edges == EdgeWrap {
+		b.X = g.wrapX(b.X)
+	} else if b.X < BoostRadius || b.X > ScreenWidth-BoostRadius {
+		b.X = min(max(b.X, BoostRadius), ScreenWidth-BoostRadius)
+		b.VX = -b.VX
+	}
+
+	vy := b.VY + g.gravity*g.step
+	if vy > 0 {
+		bottom := physics.Rect{X: b.X - BoostRadius/2, Y: b.Y + BoostRadius, W: BoostRadius}
+		for i := range g.platforms {
+			p := &g.platforms[i]
+			if p.Type == PlatformDisappearing && p.State != PlatformIntact || !p.solid() {
+				continue
+			}
+			if _, hit := physics.Sweep(bottom, 0, vy*g.step, p.rect()); !hit {
+				continue
+			}
+			b.Y = p.Y - BoostRadius
+			if !b.Bounced {
+				b.VY, b.Bounced = -vy*DropBounce, true
+			} else {
+				b.VX, b.VY, b.Falling = 0, 0, false
+				if s, ok := g.freeSlot(i, p.nearestSlot(b.X)); ok {
+					b.X, b.Mount = p.slotX(s), Mount{Platform: i, Slot: s, Mounted: true}
+				}
+			}
+			return
+		}
+	}
+	b.Y, b.VY = fall(b.Y, b.VY, g.gravity, g.step)
+	if g.screenY(b.Y) > ScreenHeight+BoostRadius {
+		b.Active = false
+	}
+}
+
+// dropHidden reports whether a blinking drop is in the off half of a blink
+func (g *Game) dropHidden(b *Boost) bool {
+	return b.Life > 0 && b.Life < DropBlinkTime && math.Mod(g.gameTime, 0.25) < 0.1
+}
+
+// drawDropSparkle draws the glints circling a drop so it catches the eye
+func (g *Game) drawDropSparkle(screen *ebiten.Image, b *Boost) {
+	if b.Life <= 0 {
+		return
+	}
+	y := g.screenY(b.Y)
+	for i := range dropSparkles {
+		a := g.gameTime*3 + float64(i)*2*math.Pi/dropSparkles
+		r := BoostRadius + 4 + 2*math.Sin(g.gameTime*8+float64(i))
+		sx, sy := b.X+math.Cos(a)*r, y+math.Sin(a)*r
+		glint := color.RGBA{255, 255, 220, uint8(160 + 95*math.Abs(math.Sin(g.gameTime*6+float64(i))))}
+		drawLine(screen, sx-2, sy, sx+2, sy, glint)
+		drawLine(screen, sx, sy-2, sx, sy+2, glint)
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+// EventKind identifies a notable gameplay event
+type EventKind int
+
+const (
+	EventKill          EventKind = iota // A bird was shot or stomped; Points awarded
+	EventMilestone                      // Value is the altitude in meters; Points awarded
+	EventWeather                        // Value is the new weather
+	EventShieldExpired                  // The shield boost ran out
+	EventBoostPickup                    // Value is the boost type
+	EventNewDay                         // Value is the day number; Points awarded
+	EventMigration                      // A flock is crossing at dawn or dusk
+	EventBiome                          // Value is the index in biomes of the biome just entered
+	EventLanding                        // Value is the type of the platform landed on
+	EventDeath                          // Value is the DeathCause that ended the run
+	EventWager                          // A gamble platform was settled; Value is WagerWon or WagerLost
+)
+
+// Event is something that happened during a run, published on the EventBus
+type Event struct {
+	Kind   EventKind
+	Value  int
+	Points int
+	X, Y   float64 // World position, when the event has one
+}
+
+// EventBus fans gameplay events out to whoever is interested: the event
+// feed, and embedders through Game.Events
+type EventBus struct {
+	subscribers []func(Event)
+}
+
+// Subscribe calls fn for every event published from now on
+func (b *EventBus) Subscribe(fn func(Event)) {
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Publish delivers e to every subscriber in subscription order
+func (b *EventBus) Publish(e Event) {
+	for _, fn := range b.subscribers {
+		fn(e)
+	}
+}
+
+// Events returns the bus gameplay events are published on
+func (g *Game) Events() *EventBus {
+	return g.events
+}
+
+// setWeather changes the weather, publishing the change
+func (g *Game) setWeather(w int) {
+	g.particles = g.particles[:0] // Clear particles when weather changes
+	if w == g.weather {
+		return
+	}
+	g.weather = w
+	g.events.Publish(Event{Kind: EventWeather, Value: w})
+}
@@ -0,0 +1,120 @@
+package game
+
+import "doodlejump/game/i18n"
+
+// Event feed parameters
+const (
+	FeedSeconds = 4.0 // How long an entry stays
+	FeedFade    = 1.0 // Seconds of fade at the end of an entry's life
+	MaxFeed     = 5   // Older entries are dropped past this many
+)
+
+// feedEntry is one line of the event feed
+type feedEntry struct {
+	text  string
+	timer float64
+}
+
+// Feed lists recent notable events in a corner of the screen
+type Feed struct {
+	entries []feedEntry
+}
+
+// newFeed creates a feed and subscribes it to bus
+func newFeed(bus *EventBus) *Feed {
+	f := &Feed{}
+	bus.Subscribe(f.handle)
+	return f
+}
+
+// feedText describes an event for the feed, or "" if it isn't shown
+func feedText(e Event) string {
+	switch e.Kind {
+	case EventKill:
+		return i18n.T("feed.kill", e.Points)
+	case EventMilestone:
+		return i18n.T("feed.milestone", e.Value, e.Points)
+	case EventWeather:
+		switch e.Value {
+		case WeatherRain:
+			return i18n.T("feed.rain")
+		case WeatherStorm:
+			return i18n.T("feed.storm")
+		case WeatherSnow:
+			return i18n.T("feed.snow")
+		}
+		return i18n.T("feed.clear")
+	case EventShieldExpired:
+		return i18n.T("feed.shield_expired")
+	case EventNewDay:
+		return i18n.T("feed.day", e.Value, e.Points)
+	case EventMigration:
+		return i18n.T("feed.migration")
+	case EventWager:
+		if e.Value == WagerWon {
+			return i18n.T("feed.wager_won")
+		}
+		return i18n.T("feed.wager_lost")
+	case EventBoostPickup:
+		switch e.Value {
+		case BoostSpeed:
+			return i18n.T("feed.boost_speed")
+		case BoostJump:
+			return i18n.T("feed.boost_jump")
+		case BoostShield:
+			return i18n.T("feed.boost_shield")
+		case BoostMultiplier:
+			return i18n.T("feed.boost_multiplier")
+		}
+	}
+	return ""
+}
+
+func (f *Feed) handle(e Event) {
+	text := feedText(e)
+	if text == "" {
+		return
+	}
+	f.entries = append(f.entries, feedEntry{text: text, timer: FeedSeconds})
+	if len(f.entries) > MaxFeed {
+		f.entries = f.entries[1:]
+	}
+}
+
+// Update ages entries dt seconds and drops expired ones
+func (f *Feed) Update(dt float64) {
+	kept := f.entries[:0]
+	for _, e := range f.entries {
+		e.timer -= dt
+		if e.timer > 0 {
+			kept = append(kept, e)
+		}
+	}
+	f.entries = kept
+}
+
+// feedVisible reports whether the feed is enabled; it's a moving overlay,
+// so reduced motion turns it off too
+func feedVisible(s Settings) bool {
+	return s.EventFeed && !s.ReducedMotion
+}
+
+// feedLines shows the feed, newest last
+func feedLines(g *Game) []hudLine {
+	if !feedVisible(g.save.Settings) {
+		return nil
+	}
+	lines := make([]hudLine, len(g.feed.entries))
+	for i, e := range g.feed.entries {
+		lines[i] = hudLine{e.text, TextSmall}
+	}
+	return lines
+}
+
+// feedFade fades each entry out over its last FeedFade seconds
+func feedFade(g *Game, i int) float64 {
+	if i >= len(g.feed.entries) {
+		return 1
+	}
+	return min(1, g.feed.entries[i].timer/FeedFade)
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// Positional audio parameters
+const (
+	PanWidth        = ScreenWidth / 2 // Horizontal distance from the player that pans fully left/right
+	VerticalFalloff = ScreenHeight    // Vertical distance at which a sound is at half volume
+	BirdChirpChance = 0.003           // Per-bird, per-tick chance of a chirp
+)
+
+// FeedbackEvent is a gameplay moment that gets non-visual feedback
+type FeedbackEvent int
+
+const (
+	FeedbackLanding FeedbackEvent = iota
+	FeedbackKill
+	FeedbackShieldHit
+	FeedbackGameOver
+	FeedbackUIClick
+	FeedbackBoostPickup
+	FeedbackBirdChirp
+	FeedbackPlatformCrack
+	FeedbackShoot
+	FeedbackPoof    // Landing on a cloud
+	FeedbackClang   // Landing on a spring
+	FeedbackSquelch // Landing on a sticky platform
+)
+
+// hapticPulse is the vibration played for a feedback event
+type hapticPulse struct {
+	strength float64
+	duration time.Duration
+}
+
+// hapticPatterns maps feedback events to their vibration
+var hapticPatterns = map[FeedbackEvent]hapticPulse{
+	FeedbackLanding:       {strength: 0.2, duration: 40 * time.Millisecond},
+	FeedbackPoof:          {strength: 0.1, duration: 40 * time.Millisecond},
+	FeedbackClang:         {strength: 0.35, duration: 50 * time.Millisecond},
+	FeedbackSquelch:       {strength: 0.25, duration: 70 * time.Millisecond},
+	FeedbackPlatformCrack: {strength: 0.3, duration: 50 * time.Millisecond},
+	FeedbackKill:          {strength: 0.5, duration: 80 * time.Millisecond},
+	FeedbackShieldHit:     {strength: 0.7, duration: 150 * time.Millisecond},
+	FeedbackGameOver:      {strength: 1.0, duration: 400 * time.Millisecond},
+}
+
+// feedback dispatches ev to every enabled feedback channel, centered on the player
+func (g *Game) feedback(ev FeedbackEvent) {
+	g.feedbackAt(ev, g.player.X, g.player.Y)
+}
+
+// feedbackAt dispatches ev as if it happened at x, y: sound is panned by
+// horizontal offset from the player and attenuated by vertical distance
+func (g *Game) feedbackAt(ev FeedbackEvent, x, y float64) {
+	if g.seeking {
+		return
+	}
+	if g.haptics != nil && g.save.Settings.Haptics {
+		if p, ok := hapticPatterns[ev]; ok {
+			g.haptics.Pulse(p.strength, p.duration)
+		}
+	}
+	if g.save.Settings.Captions {
+		g.captions.add(ev, x, g.player.X)
+	}
+	if g.audio != nil {
+		pan := (x - g.player.X) / PanWidth
+		gain := 1 / (1 + math.Abs(y-g.player.Y)/VerticalFalloff)
+		g.audio.PlayAt(feedbackSounds[ev], pan, gain)
+	}
+}
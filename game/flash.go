@@ -0,0 +1,46 @@
+package game
+
+import "image/color"
+
+// Flash limits, after the WCAG three-flashes rule
+const (
+	MaxFlashesPerSecond = 3    // Flashes allowed in any one-second window
+	MaxFlashAlpha       = 0.6  // Brightest a full-screen flash may get
+	SafeFlashAlpha      = 0.15 // Pulse strength in photosensitive-safe mode
+)
+
+// FlashLimiter is the one gate every full-screen brightness change goes
+// through, so lightning (or anything added later) can't strobe
+type FlashLimiter struct {
+	recent []float64 // Game times of the flashes allowed in the last second
+}
+
+// Allow asks to flash at strength (0 to 1) at game time now and returns the
+// strength to actually use, 0 when the flash must be skipped
+func (f *FlashLimiter) Allow(now, strength float64, s Settings) float64 {
+	kept := f.recent[:0]
+	for _, t := range f.recent {
+		if now-t < 1 {
+			kept = append(kept, t)
+		}
+	}
+	f.recent = kept
+	if len(f.recent) >= MaxFlashesPerSecond {
+		return 0
+	}
+	f.recent = append(f.recent, now)
+	if s.PhotosensitiveSafe {
+		return min(strength, 1) * SafeFlashAlpha
+	}
+	return min(strength, 1) * MaxFlashAlpha
+}
+
+// flashColor is the overlay for a flash at alpha. Photosensitive-safe mode
+// swaps white for a dim blue-grey pulse with no sharp luminance jump.
+func flashColor(alpha float64, s Settings) color.RGBA {
+	r, g, b := 255.0, 255.0, 255.0
+	if s.PhotosensitiveSafe {
+		r, g, b = 90, 100, 130
+	}
+	return color.RGBA{uint8(r * alpha), uint8(g * alpha), uint8(b * alpha), uint8(255 * alpha)}
+}
@@ -0,0 +1,45 @@
+package game
+
+import "doodlejump/game/physics"
+
+// Dislodged boost motion
+const (
+	DislodgeSpeedX = 2.5  // Sideways speed a shot gives a boost, along the bullet
+	DislodgeHop    = -2.5 // Vertical velocity a shot boost pops up with
+)
+
+// rect is the boost's bounding box, which bullets are swept against
+func (b *Boost) rect() physics.Rect {
+	return physics.Rect{X: b.X - BoostRadius, Y: b.Y - BoostRadius, W: 2 * BoostRadius, H: 2 * BoostRadius}
+}
+
+// friendlyFire applies the tuning's friendly-fire rules to bullet b, moved
+// this tick by dx, dy. It reports whether something stopped the bullet.
+func (g *Game) friendlyFire(b *Bullet, dx, dy float64) bool {
+	prev := physics.Circle{X: b.X - dx, Y: b.Y - dy, R: BulletRadius}
+	if g.tuning.BulletsDislodgeBoosts {
+		for i := range g.boosts {
+			boost := &g.boosts[i]
+			if !boost.Active || boost.Falling {
+				continue
+			}
+			if _, hit := physics.SweepCircle(prev, dx, dy, boost.rect()); hit {
+				boost.drop(DislodgeSpeedX*float64(b.Direction), DislodgeHop)
+				g.feedbackAt(FeedbackPlatformCrack, boost.X, boost.Y)
+				return true
+			}
+		}
+	}
+	if g.tuning.BulletsHitPlatforms {
+		for i := range g.platforms {
+			p := &g.platforms[i]
+			if !p.solid() {
+				continue
+			}
+			if _, hit := physics.SweepCircle(prev, dx, dy, p.rect()); hit {
+				return true
+			}
+		}
+	}
+	return false
+}
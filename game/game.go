@@ -1,21 +1,25 @@
 package game
 
 import (
-	"bytes"
+	"cmp"
 	"embed"
-	"fmt"
-	"image"
 	"image/color"
 	_ "image/png"
 	"log"
 	"math"
 	"math/rand"
-	"strconv"
+	"slices"
 	"time"
 
+	"doodlejump/game/achievements"
+	"doodlejump/game/audio"
+	"doodlejump/game/config"
+	"doodlejump/game/i18n"
+	"doodlejump/game/input"
+	"doodlejump/game/level"
+	"doodlejump/game/physics"
+
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 //go:embed assets/*.png
@@ -49,9 +53,14 @@ const (
 	CloudSpeedMax  = 1.0
 	BoostSpawnChance = 0.15   // Increased boost chance (15%)
 	BulletSpeed    = 5
+	BulletRadius   = 3       // Bullets are drawn and collide as discs
+	BoostRadius    = 10      // Boost pickups are drawn and collide as discs
 	FlyDuration    = 4.0     // Increased flying time
 	ShootCooldown  = 0.4     // Shorter cooldown for shooting
 	BoostDuration  = 12.0    // Longer boost duration
+	PlatformBreakTime = 0.3  // Seconds a disappearing platform takes to crumble
+	SpawnInvincibility = 2.0 // Seconds birds can't hurt a freshly started run
+	ShieldGrace    = 1.0     // Seconds of invincibility after a shield runs out
 	ScorePerDifficulty = 20  // Score increment when difficulty increases
 
 	// Day cycle constants
@@ -86,6 +95,7 @@ const (
 	WeatherClear = iota
 	WeatherRain
 	WeatherSnow
+	WeatherStorm // Heavy rain with lightning; birds shelter
 )
 
 // Boost types
@@ -94,6 +104,7 @@ const (
 	BoostSpeed
 	BoostJump
 	BoostShield
+	BoostMultiplier
 )
 
 // Platform types
@@ -101,6 +112,10 @@ const (
 	PlatformNormal = iota
 	PlatformSticky
 	PlatformDisappearing
+	PlatformSpring // Bounces the player SpringBoost times higher
+	PlatformGamble // Pays out or calls a bird wave on its first bounce
+	PlatformMoving // Slides back and forth along a patrol
+	PlatformPhase  // Blinks in and out, solid only while on
 )
 
 // Platform animation states
@@ -108,13 +123,16 @@ const (
 	PlatformIntact = iota
 	PlatformBreaking
 	PlatformBroken
+	PlatformSpent // A gamble platform that has been settled
+	PlatformFaded // A phase platform in its off phase
 )
 
 // Bullet represents a projectile fired by the player
 type Bullet struct {
 	X, Y      float64
 	Direction int
-	Speed     float64
+	Speed     float64 // Horizontal speed, applied along Direction
+	SpeedY    float64 // Vertical speed, non-zero only when aim assist bends the shot
 	Active    bool
 }
 
@@ -123,14 +141,21 @@ type Platform struct {
 	X, Y        float64
 	Type        int
 	State       int
-	BreakTimer  float64 // Timer for breaking animation
+	Speed       float64 // Pixels per frame a moving platform slides
+	Direction   int     // 1 sliding right, -1 left
+	MinX, MaxX  float64 // Ends of a moving platform's patrol
+	Phase       float64 // Seconds into a phase platform's on/off cycle
 }
 
 // Bird represents a bird obstacle
 type Bird struct {
 	X, Y      float64
 	SpeedX    float64
-	Direction int // 1 for right, -1 for left
+	Direction int     // 1 for right, -1 for left
+	Wave      bool    // Sent by a lost wager; leaves instead of being recycled
+	Perch     int     // Index in platforms of the bird's perch, while Landing or Perched
+	Landing   bool    // Gliding down to its perch
+	Perched   float64 // Seconds left sitting on the perch, 0 in flight
 }
 
 // Cloud represents a background cloud
@@ -142,26 +167,32 @@ type Cloud struct {
 	Alpha  float64 // transparency
 }
 
-// Weather particle (rain or snow)
+// Particle spaces
+const (
+	ParticleScreen = iota // Fixed to the view; camera scrolling leaves it be
+	ParticleWorld         // Part of the world; scrolls down the screen as the camera climbs
+)
+
+// Weather particle (rain or snow), in screen coordinates
 type Particle struct {
 	X, Y   float64
 	SpeedX float64
 	SpeedY float64
 	Size   float64
 	Alpha  float64
+	Space  int // ParticleScreen or ParticleWorld
 }
 
 // Player represents the player character
 type Player struct {
 	X, Y        float64
+	VelocityX   float64 // Knockback from walls; input moves the player directly
+	MoveX       float64 // Horizontal movement from input applied last tick
+	LaunchX     float64 // Horizontal speed from a cannon or a moving platform, kept until the next landing
 	VelocityY   float64
 	FacingRight bool
-	CanFly      bool
-	FlyTimer    float64
-	ShootTimer  float64
 	Bullets     []Bullet
-	BoostType   int
-	BoostTimer  float64
+	BoostType   int     // Active boost; its time left is the TimerBoost timer
 }
 
 // Boost represents a powerup that the player can collect
@@ -169,6 +200,11 @@ type Boost struct {
 	X, Y     float64
 	Type     int
 	Active   bool
+	VX, VY   float64 // Motion while Falling
+	Falling  bool    // Dropped and still in the air
+	Bounced  bool    // A falling drop has had its one bounce
+	Life     float64 // Seconds a drop has left; 0 for boosts placed by the layout
+	Mount    Mount   // Slot it rests in on a platform; drops settle into one
 }
 
 // Add this type and the color sets before the Game struct
@@ -426,73 +462,146 @@ type Game struct {
 	birds        []Bird
 	clouds       []Cloud
 	particles    []Particle
+	landingBits  []landingBit // Thrown up by landings, by platform type
 	boosts       []Boost
 	bullets      []Bullet
 	stars        []struct{ x, y, brightness float64 }  // Add stars
-	camera       float64
+	camera       float64    // How far the view has scrolled up; screen Y = world Y + camera
 	score        int
+	scoreBy      ScoreBreakdown // Points by category, summing to score
 	difficulty   int        // Current difficulty level
 	birdCount    int        // Current number of birds (increases with difficulty)
 	birdSpeedMin float64    // Current min bird speed (increases with difficulty)
 	birdSpeedMax float64    // Current max bird speed (increases with difficulty)
-	playerImg    *ebiten.Image
-	platformImg  *ebiten.Image
-	birdLeftImg  *ebiten.Image
-	birdRightImg *ebiten.Image
-	cloudImg     *ebiten.Image
-	mountainImgs []*ebiten.Image  // Mountain layer images
+	sprites      *SpriteRegistry // Every sprite by entity, nil when headless
+	birdBoxes    [2]physics.Rect // Left and right bird hitboxes from sprite alpha
+	perchBoxes   [2]physics.Rect // The same for perched birds
+	mountains    *Mountains // Generated skyline with cached lighting, nil when headless
+	lake         *Lake      // Reflective water at the bottom of the first screen
+	cannons      []*Cannon  // Launch cannons sitting on platforms
+	loadedCannon *Cannon    // Cannon the player is sitting in, if any
+	grapple      Grapple    // The player's grappling hook
+	updrafts     []Updraft  // Rising air columns above vents
 	gameOver     bool
+	deathCause   DeathCause   // How the run ended, once it has
+	diedStuck    bool         // The run ended with the player stuck to a sticky platform
+	combo        int          // Bounces in a row since the last sticky platform
+	tips         tipRotation  // Tips on the title and game-over screens
+	notice       string       // Webhook message about the run that just ended, sent with its next frame
+	scenes       SceneManager // Title, play, pause or game over
+	stream       *WorldStreamer // Seeded chunks of entities with stable identity
+	nextSlot     int            // Layout slot the next recycled platform takes
+	bookmarkCursor int          // Selected row of the seeds screen
+	prestiged      bool         // This run was traded in for a prestige level
+	prestigeCarry  float64      // Fractional prestige bonus not yet paid out
+	controlsCursor int          // Selected action of the controls screen
+	controlsCapture bool        // The controls screen is waiting for a new input
+	shields        int          // Hot-seat handicap hits left this run
+	idleOver       float64      // Seconds the idle bot's run has been over
+	idleFrame      int          // Frames since idle mode started, for draw throttling
+	titleWait      float64      // Seconds the title has gone without input
+	config         config.Config // Machine-wide preferences from settings.json
+	configPath     string        // Where config is written back, "" to keep it in memory
+	settingsCursor int           // Selected row of the settings screen
+	tuning         GameConfig    // Gameplay tuning values
+	postcard       *postcardShow // Postcard on screen, if any
+	galleryCursor  int           // Selected card of the postcard gallery
+	codexCursor    int           // Selected entry of the codex
+	profiles       []profileInfo // Rows of the profile picker
+	profileCursor  int           // Selected row of the profile picker
+	profileTyping  bool          // The profile picker is taking a new profile's name
+	profileName    string        // New profile name typed so far
+	recorder       *replayRecorder // Records this run for replays, nil when it isn't the player's own
+	lastReplay     *Replay         // Replay of the run that just ended
+	replayView     *replayViewer   // Playback state when watching a replay
+	seeking        bool            // A replay is fast-forwarding, so feedback stays quiet
+	achievementRun achievements.Run // This run's progress toward achievements
+	toasts         []toast          // Unlocked achievements waiting to slide across, the first on screen
+	initials       []byte           // Kiosk high-score initials entered so far, the last one being picked
+	mode           Mode             // Goal of the run
+	session        *playSession     // Play time since launch, for the parental limits
+	pinEntry       string           // Parental PIN digits typed so far
+	pinNext        Scene            // Where a correct or newly set PIN leads
+	pinSetting     bool             // The PIN screen is setting a new PIN rather than checking one
+	pinWrong       bool             // The last PIN typed didn't match
+	shopCursor     int              // Selected item of the shop
+	coinsEarned    int              // Coins the run that just ended paid
+	outfitted      bool             // The run was handed its starting boost, or didn't get one
+	trail          []trailDot       // Left behind the player by the trail worn
 	nightMode    bool
 	weather      int
 	startTime    time.Time
 	cycleTime    time.Duration
-	weatherTimer float64 // counter for weather changes
 	gameTime     float64 // time elapsed since game start (in seconds)
 	initialTimeOfDay float64  // Random initial time of day (0.0 - 1.0)
 	stuckToPlatform *Platform
-	stuckTimer      float64    // For visual effect
 	jumpPressed     bool       // Track jump button state
 	canJumpRelease  bool       // Whether player can release from sticky platform
-}
-
-// loadImage loads an image from embedded assets
-func loadImage(path string) *ebiten.Image {
-	// Remove leading "./" from path if present
-	if len(path) > 2 && path[:2] == "./" {
-		path = path[2:]
-	}
-
-	imgBytes, err := gameAssets.ReadFile(path)
-	if err != nil {
-		log.Fatalf("Failed to read embedded image: %v", err)
-	}
-
-	img, _, err := image.Decode(bytes.NewReader(imgBytes))
-	if err != nil {
-		log.Fatalf("Failed to decode image: %v", err)
-	}
-
-	return ebiten.NewImageFromImage(img)
+	save            *SaveData  // Persisted best score and stats
+	hud             *HUD       // Screen-space text panels
+	capture         *cleanCapture // HUD-free offscreen render, nil unless enabled
+	haptics         Haptics    // Vibration backends for the feedback module
+	audio           *audio.AudioManager // SFX/Music/UI channels and the background music
+	ambience        *Ambience  // Day/night and weather background loops
+	announcer       *Announcer // Milestone callouts and subtitles
+	captions        Captions   // Accessibility captions for sound events
+	events          *EventBus  // Notable gameplay events for the feed and embedders
+	timers          Timers     // Every timed effect: boosts, flight, cooldowns, i-frames, weather
+	feed            *Feed      // Corner list of recent events
+	controller      input.Source // Maps keys and buttons (or a bot) to actions
+	gunner          *input.Gunner // Co-op player two's reticle, nil outside co-op
+	world           World      // Playfield rules such as the edge policy
+	rng             *rand.Rand // Drives all gameplay randomness so seeds are reproducible
+	rngSource       *countingSource // rng's source, counting draws so a suspended run can replay them
+	suspended       *RunSnapshot // Run left unfinished when the window last closed, offered on the title
+	seed            int64      // Seed rng was created from
+	opts            gameOptions // Resolved options
+	optionList      []Option   // Options as passed, reapplied on restart
+	level           *level.Level // Spawn table, physics and theme of the current level
+	boltX           float64    // Column the next or last lightning bolt strikes
+	flashes         FlashLimiter // Caps full-screen flashes per second
+	flashAlpha      float64    // Strength the limiter allowed for the current flash
+	markerBoard     *markerBoard // Leaderboard altitudes, nil without a leaderboard
+	online          *onlineBoard // Leaderboard service link, nil until first used or without one
+	day             int        // Full day cycles completed this run
+	flocks          []*Flock   // Migrating formations crossing the screen
+	migrationPhase  int        // Day phase the last migration check saw
+	warmth          float64    // Hardcore warmth meter, 0 to 1
+	sandboxTool     int        // Selected sandbox palette slot
+	campfires       []Campfire // Hardcore warming spots on platforms
+	gravity         float64    // Per-tick vertical acceleration, from the level
+	jumpVelocity    float64    // Bounce velocity, from the level
+	tps             int        // Simulation ticks per second
+	dt              float64    // Seconds a tick covers, 1/tps
+	step            float64    // Frames of FrameRate a tick covers, scaling per-tick motion
+	difficultyCurve DifficultyProfile // How birds and platforms ramp with score
+	showDev         bool       // Developer overlay, only in devtools builds
+	trace           []heatSample // This run's trajectory, merged into the save heatmap on death
+	traceTicks      int
+	traceThinned    uint         // Times the trace was halved to fit the low-memory cap
 }
 
 // NewGame creates a new game instance
-func NewGame() *Game {
-	// We don't need to seed in newer Go versions
+func NewGame(opts ...Option) *Game {
+	o := resolveOptions(opts)
+	src := newCountingSource(o.seed)
+	rng := rand.New(src)
 
 	g := &Game{
+		rng:  rng,
+		rngSource: src,
+		seed: o.seed,
+		opts: o,
+		optionList: opts,
 		player: Player{
 			X:           ScreenWidth / 2,
 			Y:           ScreenHeight - 100,
 			FacingRight: true,
-			CanFly:      false,
-			FlyTimer:    0,
-			ShootTimer:  0,
 			Bullets:     make([]Bullet, 0),
 			BoostType:   BoostNone,
-			BoostTimer:  0,
 		},
-		platforms:    make([]Platform, PlatformCount),
-		birds:        make([]Bird, InitialBirdCount),  // Start with fewer birds
+		platforms:    make([]Platform, PlatformCount, PlatformCount+SandboxMaxPlatforms), // Sandbox appends must not move platforms
+		birds:        make([]Bird, o.tuning.InitialBirds),  // Start with fewer birds
 		clouds:       make([]Cloud, CloudCount),
 		particles:    make([]Particle, 0, RaindropCount),
 		boosts:       make([]Boost, 0, 3),
@@ -500,174 +609,336 @@ func NewGame() *Game {
 		stars:        make([]struct{ x, y, brightness float64 }, 100),  // Initialize stars
 		score:        0,
 		difficulty:   0,                      // Start at difficulty 0
-		birdCount:    InitialBirdCount,       // Start with initial bird count
-		birdSpeedMin: InitialBirdSpeedMin,    // Start with slower birds
-		birdSpeedMax: InitialBirdSpeedMax,
+		birdCount:    o.tuning.InitialBirds,   // Start with initial bird count
+		birdSpeedMin: o.tuning.InitialBirdSpeed[0], // Start with slower birds
+		birdSpeedMax: o.tuning.InitialBirdSpeed[1],
+		tuning:       o.tuning,
 		gameOver:     false,
 		startTime:    time.Now(),
 		cycleTime:    time.Minute * 2,        // Day/night cycle every 2 minutes
-		weatherTimer: rand.Float64() * 15,    // Random time until weather changes
 		weather:      WeatherClear,
 		gameTime:     0,
-		initialTimeOfDay: rand.Float64(),
-		mountainImgs: make([]*ebiten.Image, 3),
-	}
+		initialTimeOfDay: rng.Float64(),
+		hud:          newHUD(),
+		world:        World{Edges: o.edges},
+		announcer:    newAnnouncer(),
+		lake:         &Lake{},
+		events:       &EventBus{},
+		session:      &playSession{started: time.Now()},
+		warmth:       1,
+	}
+	g.timers.Add(TimerWeather, rng.Float64()*15, changeWeather) // Random time until weather changes
+	g.migrationPhase = dayPhase(g.initialTimeOfDay)             // No migration for the phase a run starts in
+	g.timers.Add(TimerInvincible, g.tuning.SpawnInvincibility, nil)
+	g.feed = newFeed(g.events)
+	g.events.Subscribe(g.showPostcard)
+	g.events.Subscribe(g.recordCodex)
+	g.events.Subscribe(g.recordAchievement)
 
 	// Load images
-	g.playerImg = loadImage("./assets/player.png")
-	g.platformImg = loadImage("./assets/platform.png")
-	g.birdLeftImg = loadImage("./assets/bird_left.png")
-	g.birdRightImg = loadImage("./assets/bird_right.png")
-	g.cloudImg = loadImage("./assets/cloud.png")
+	if !o.headless {
+		g.sprites = loadSprites(o.lowMemory)
+	}
 
-	// Set night mode initially based on system time
-	hour := time.Now().Hour()
-	g.nightMode = hour < 6 || hour > 18
+	// Load persisted data; a broken save still lets the game start
+	save := newSaveData("")
+	if !o.headless || o.profileSet {
+		var err error
+		if save, err = LoadSave(o.profile); err != nil {
+			log.Printf("Failed to load save: %v", err)
+		}
+	}
+	if o.settings != nil {
+		save.Settings = *o.settings
+	}
+	g.save = save
+	g.mode = g.resolveMode()
+	if o.ghost != nil {
+		o.ghost.runs++
+	}
+	switch {
+	case o.kiosk != nil && !o.idle:
+		g.timers.Add(TimerLimit, o.kiosk.RunLimit.Seconds(), timeUp)
+	case g.mode == ModeTimeAttack:
+		g.timers.Add(TimerLimit, TimeAttackSeconds, timeUp)
+	}
 
-	// Initial platform directly under the player
-	g.platforms[0] = Platform{
-		X:    g.player.X - PlatformWidth/2,
-		Y:    ScreenHeight - 30,
-		Type: PlatformNormal,
+	// The mode may calm the level, so the world is laid out once it's known
+	lvl := loadLevel(o.level, o.tuning)
+	if g.mode == ModeZen {
+		calmLevel(lvl)
+	}
+	g.applyLevel(lvl, o.headless)
+	g.stream = newWorldStreamer(g.seed, g.level)
+	g.stream.Update(g.worldTop())
+	i18n.SetLanguage(save.Settings.Language)
+	switch {
+	case o.config != nil:
+		g.config = *o.config
+	case o.headless:
+		g.config = config.Default()
+	default:
+		g.config, g.configPath = loadConfig()
 	}
 
-	// Generate random platforms
-	for i := 1; i < PlatformCount; i++ {
-		platformType := PlatformNormal
-		
-		// Platform type distribution
-		rnd := rand.Float64()
-		if rnd < 0.2 { // 20% chance for sticky platform
-			platformType = PlatformSticky
-		} else if rnd < 0.35 { // 15% chance for disappearing platform
-			platformType = PlatformDisappearing
+	// The simulation runs at the machine's rate, or a replay's own
+	g.setTickRate(cmp.Or(o.tickRate, g.config.TickRate))
+	if !o.headless && o.seat == 0 && o.bench == nil {
+		ebiten.SetTPS(g.tps)
+	}
+
+	g.controller = o.controller
+	if o.idle || o.bench != nil {
+		g.controller = newBot(g)
+	}
+	if g.controller == nil && o.race != nil {
+		g.controller = seatController(o.seat, save.Settings)
+	}
+	if g.controller == nil {
+		c := newController(save.Settings, save.bindingsPath())
+		g.controller = c
+		if o.coop {
+			g.gunner = input.NewGunner(ScreenWidth, ScreenHeight)
+			g.controller = input.NewCoop(c, g.gunner)
 		}
-		
-		g.platforms[i] = Platform{
-			X:          rand.Float64() * (ScreenWidth - PlatformWidth),
-			Y:          float64(i) * (ScreenHeight / PlatformCount),
-			Type:       platformType,
-			State:      PlatformIntact,
-			BreakTimer: 0,
+	}
+	g.replayView = o.replay
+	if o.replay != nil && o.replay.replay.Coop {
+		g.gunner = input.NewGunner(ScreenWidth, ScreenHeight)
+	}
+	if !o.headless && !o.idle && !o.sandbox && o.replay == nil && o.race == nil && o.bench == nil {
+		g.recorder = newReplayRecorder(g)
+	}
+
+	// Windowed games open on the title screen; headless ones simulate straight away
+	g.scenes.Switch(playScene)
+	if !o.headless && !o.sandbox && !o.idle && o.race == nil && o.bench == nil {
+		g.scenes.Switch(titleScene)
+		if o.kiosk != nil {
+			g.scenes.Switch(coinScene)
+		}
+		if g.resumable() {
+			var err error
+			if g.suspended, err = g.loadRun(save.runPath()); err != nil {
+				log.Printf("Failed to load the saved run: %v", err)
+			}
+		}
+		if s := g.turnScene(); s != nil {
+			g.scenes.Switch(s)
 		}
 	}
+	if o.hotseat != nil {
+		g.shields = o.hotseat.Current().Shields
+	}
+
+	// Audio and haptics only make sense with a window; a race's other
+	// players sound through player one's
+	if !o.headless && o.seat == 0 {
+		g.haptics = newHaptics()
+		g.audio = audio.NewAudioManager()
+		g.applyAudioSettings()
+		g.ambience = newAmbience(g.audio.Mixer)
+	}
+
+	// Set night mode initially based on system time
+	hour := time.Now().Hour()
+	g.nightMode = hour < 6 || hour > 18
+
+	// The first chunk's platforms, starting with the one under the player
+	first := g.stream.Chunk(0)
+	copy(g.platforms, first.Platforms)
+	g.boosts = append(g.boosts, first.Boosts...)
+	g.nextSlot = PlatformCount
 
 	// Initialize birds
-	for i := 0; i < InitialBirdCount; i++ {
+	for i := 0; i < g.tuning.InitialBirds; i++ {
 		direction := 1
-		if rand.Float64() < 0.5 {
+		if g.rng.Float64() < 0.5 {
 			direction = -1
 		}
 
 		g.birds[i] = Bird{
-			X:         rand.Float64() * ScreenWidth,
-			Y:         rand.Float64() * ScreenHeight / 2, // Birds in upper half
-			SpeedX:    g.birdSpeedMin + rand.Float64()*(g.birdSpeedMax-g.birdSpeedMin),
+			X:         g.rng.Float64() * ScreenWidth,
+			Y:         g.rng.Float64() * ScreenHeight / 2, // Birds in upper half
+			SpeedX:    g.birdSpeedMin + g.rng.Float64()*(g.birdSpeedMax-g.birdSpeedMin),
 			Direction: direction,
 		}
 	}
+	if maxBirds := g.level.SpawnTable.MaxBirds; g.birdCount > maxBirds {
+		g.birds = g.birds[:maxBirds]
+		g.birdCount = maxBirds
+	}
 
 	// Initialize clouds
 	for i := 0; i < CloudCount; i++ {
 		g.clouds[i] = Cloud{
-			X:      rand.Float64() * ScreenWidth,
-			Y:      rand.Float64() * ScreenHeight * 0.7, // Clouds in top 70% of screen
-			SpeedX: CloudSpeedMin + rand.Float64()*(CloudSpeedMax-CloudSpeedMin),
-			Width:  CloudWidth * (0.7 + rand.Float64()*0.6), // Random size variation
-			Height: CloudHeight * (0.7 + rand.Float64()*0.6),
-			Alpha:  0.5 + rand.Float64()*0.5, // Random transparency
+			X:      g.rng.Float64() * ScreenWidth,
+			Y:      g.rng.Float64() * ScreenHeight * 0.7, // Clouds in top 70% of screen
+			SpeedX: CloudSpeedMin + g.rng.Float64()*(CloudSpeedMax-CloudSpeedMin),
+			Width:  CloudWidth * (0.7 + g.rng.Float64()*0.6), // Random size variation
+			Height: CloudHeight * (0.7 + g.rng.Float64()*0.6),
+			Alpha:  0.5 + g.rng.Float64()*0.5, // Random transparency
 		}
 	}
 
-	// Load mountain images
-	g.mountainImgs = make([]*ebiten.Image, 3)
-	for i := 0; i < 3; i++ {
-		g.mountainImgs[i] = loadImage(fmt.Sprintf("./assets/mountains_%d.png", i))
+	// Mountains are generated from the run seed so every run has its own skyline
+	if !o.headless {
+		g.mountains = newMountains(g.seed, !o.lowMemory)
 	}
 
 	// Initialize stars with random positions
 	for i := range g.stars {
-		g.stars[i].x = rand.Float64() * float64(ScreenWidth)
-		g.stars[i].y = rand.Float64() * float64(ScreenHeight) * 0.7 // Stars in top 70% of screen
-		g.stars[i].brightness = 0.3 + rand.Float64()*0.7 // Random brightness
+		g.stars[i].x = g.rng.Float64() * float64(ScreenWidth)
+		g.stars[i].y = g.rng.Float64() * float64(ScreenHeight) * 0.7 // Stars in top 70% of screen
+		g.stars[i].brightness = 0.3 + g.rng.Float64()*0.7 // Random brightness
 	}
 
 	return g
 }
 
-// generateParticle creates a new rain or snow particle
+// generateParticle creates a new rain or snow particle, falling from
+// under a cloud overhead. Those belong to the world, like their cloud;
+// with no cloud about, one falls from the top of the view instead.
 func (g *Game) generateParticle() Particle {
 	var particle Particle
+	x, y, ok := g.cloudSource()
+	space := ParticleWorld
+	if !ok {
+		x, y, space = fxFloat64()*ScreenWidth, -5, ParticleScreen
+	}
 
-	if g.weather == WeatherRain {
+	if rainy(g.weather) {
 		// Raindrop
 		particle = Particle{
-			X:      rand.Float64() * ScreenWidth,
-			Y:      -5,
-			SpeedX: 1 + rand.Float64()*2, // slight horizontal movement
-			SpeedY: 8 + rand.Float64()*4, // fast fall
-			Size:   2 + rand.Float64()*3,
-			Alpha:  0.6 + rand.Float64()*0.4,
+			X:      x,
+			Y:      y,
+			SpeedX: 1 + fxFloat64()*2, // slight horizontal movement
+			SpeedY: 8 + fxFloat64()*4, // fast fall
+			Size:   2 + fxFloat64()*3,
+			Alpha:  0.6 + fxFloat64()*0.4,
+			Space:  space,
 		}
 	} else if g.weather == WeatherSnow {
 		// Snowflake
 		particle = Particle{
-			X:      rand.Float64() * ScreenWidth,
-			Y:      -5,
-			SpeedX: -1 + rand.Float64()*2, // random drift
-			SpeedY: 1 + rand.Float64()*2,  // slow fall
-			Size:   2 + rand.Float64()*4,
-			Alpha:  0.7 + rand.Float64()*0.3,
+			X:      x,
+			Y:      y,
+			SpeedX: -1 + fxFloat64()*2, // random drift
+			SpeedY: 1 + fxFloat64()*2,  // slow fall
+			Size:   2 + fxFloat64()*4,
+			Alpha:  0.7 + fxFloat64()*0.3,
+			Space:  space,
 		}
 	}
 
 	return particle
 }
 
-// Update updates the game state
+// Update advances the game by one frame: normally one tick, but devtools
+// builds can pause, single-step or rescale time
 func (g *Game) Update() error {
-	if g.gameOver {
-		if ebiten.IsKeyPressed(ebiten.KeySpace) {
-			*g = *NewGame()
+	if ebiten.IsWindowBeingClosed() && g.opts.kiosk == nil {
+		g.suspendRun()
+		g.saveWindow(ScreenWidth)
+		g.sendSessionSummary()
+		return ebiten.Termination
+	}
+	if g.opts.kiosk != nil {
+		g.updateKiosk()
+	}
+	if g.opts.attract != nil && anyInput() {
+		g.leaveAttract()
+		return nil // The input that ends the demo doesn't pick from the menu
+	}
+	if g.opts.idle {
+		if err := g.updateIdle(); err != nil {
+			return err
 		}
-		return nil
 	}
+	ticks := 1
+	if devUpdate != nil {
+		ticks = devUpdate(g)
+	}
+	for i := 0; i < ticks; i++ {
+		if i == 1 && g.replayView == nil {
+			// Later ticks of the same frame must not replay one-shot presses
+			// (a replay's own input is already once per tick)
+			src := g.controller
+			g.controller = heldOnly{src}
+			defer func() { g.controller = src }()
+		}
+		if err := g.tick(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tick runs one step of the active scene
+func (g *Game) tick() error {
+	return g.scenes.Current().Update(g)
+}
 
+// play runs one simulation step of a run in progress
+func (g *Game) play() error {
 	// Update game time
-	g.gameTime += 1.0 / 60.0 // Assume 60 FPS
+	g.gameTime += g.dt
+
+	g.hud.Update(g)
+	if g.mountains != nil {
+		g.mountains.Update(g.weather, g.dt)
+	}
+	if g.audio != nil {
+		g.audio.Update(true)
+		if g.ambience != nil {
+			g.ambience.Update(g.timeOfDay(), g.weather, g.dt)
+		}
+	}
+	g.updateDays()
+	g.announcer.Update(g)
+	g.updatePostcard()
+	g.captions.Update(g.dt)
+	g.feed.Update(g.dt)
+	g.updateToasts()
 
-	// Toggle weather with 'W' key
-	if inpututil.IsKeyJustPressed(ebiten.KeyW) {
-		g.weather = (g.weather + 1) % 3 // Cycle through weather types
-		g.particles = g.particles[:0]   // Clear particles
+	g.controller.Update()
+	g.sampleTrajectory()
+	g.countPlay()
+	g.sendGhost()
+	if g.mode.scoresHeight() {
+		g.score = g.altitude() // Points still count toward difficulty, but height is the result
 	}
+	g.checkAchievements()
 
-	// Weather timer and changes
-	g.weatherTimer -= 0.016 // Assume ~60 FPS
-	if g.weatherTimer <= 0 {
-		// Change weather randomly
-		g.weather = rand.Intn(3)
-		g.weatherTimer = 15 + rand.Float64()*20 // 15-35 seconds until next change
-		g.particles = g.particles[:0]           // Clear particles when weather changes
+	// Toggle the live score tally
+	if g.controller.JustPressed(input.ActionTally) {
+		g.hud.showTally = !g.hud.showTally
+		g.feedback(FeedbackUIClick)
+	}
+
+	// Cycle the weather on demand
+	if g.controller.JustPressed(input.ActionWeather) {
+		g.setWeather((g.weather + 1) % weatherCount) // Cycle through weather types
+		g.feedback(FeedbackUIClick)
 	}
 
 	// Generate particles based on weather
-	if g.weather == WeatherRain {
+	if rainy(g.weather) {
 		// Generate raindrops
-		if len(g.particles) < RaindropCount && rand.Float64() < 0.3 {
+		if len(g.particles) < RaindropCount && fxFloat64() < g.chance(0.3*g.weatherDensity()) {
 			g.particles = append(g.particles, g.generateParticle())
 		}
 	} else if g.weather == WeatherSnow {
 		// Generate snowflakes
-		if len(g.particles) < SnowflakeCount && rand.Float64() < 0.2 {
+		if len(g.particles) < SnowflakeCount && fxFloat64() < g.chance(0.2*g.weatherDensity()) {
 			g.particles = append(g.particles, g.generateParticle())
 		}
 	}
 
 	// Update particles
 	for i := 0; i < len(g.particles); i++ {
-		g.particles[i].X += g.particles[i].SpeedX
-		g.particles[i].Y += g.particles[i].SpeedY
+		g.particles[i].X += g.particles[i].SpeedX * g.step
+		g.particles[i].Y += g.particles[i].SpeedY * g.step
 
 		// Remove particles that go off screen
 		if g.particles[i].Y > ScreenHeight {
@@ -677,19 +948,19 @@ func (g *Game) Update() error {
 		}
 	}
 	
+	g.updateLandingBits()
+	g.updateTrail()
+
 	// Handle sticky platform release
-	jumpKey := ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW)
-	spaceKey := ebiten.IsKeyPressed(ebiten.KeySpace)
+	jumpKey := g.controller.Pressed(input.ActionJump)
+	spaceKey := g.controller.Pressed(input.ActionShoot)
 	
 	// Check for jump key press
 	if jumpKey || spaceKey {
 		if !g.jumpPressed {
 			// Key was just pressed
 			if g.stuckToPlatform != nil {
-				// Release from platform with a higher jump
-				g.player.VelocityY = float64(JumpVelocity) * 1.2
-				g.stuckToPlatform = nil
-				g.stuckTimer = 0
+				g.releaseSticky()
 			}
 		}
 		g.jumpPressed = true
@@ -697,110 +968,83 @@ func (g *Game) Update() error {
 		g.jumpPressed = false
 	}
 
+	// Slide moving platforms along their patrols and blink phase ones
+	for i := range g.platforms {
+		g.platforms[i].slide(g.step)
+		g.platforms[i].blink(g.dt)
+	}
+	g.followMounts()
+
 	// Update platform states
 	for i := range g.platforms {
 		p := &g.platforms[i]
 		
-		// Update disappearing platform state
-		if p.Type == PlatformDisappearing && p.State == PlatformBreaking {
-			p.BreakTimer -= 1.0 / 60.0
-			if p.BreakTimer <= 0 {
-				p.State = PlatformBroken
-			}
-		}
-		
-		// Check for collision with player
-		if g.player.X+PlayerWidth/3 >= p.X &&
-			g.player.X-PlayerWidth/3 <= p.X+PlatformWidth &&
-			g.player.Y+PlayerHeight/2 >= p.Y &&
-			g.player.Y+PlayerHeight/2 <= p.Y+PlatformHeight &&
-			g.player.VelocityY > 0 {
-			
-			// Skip broken platforms
-			if p.Type == PlatformDisappearing && p.State == PlatformBroken {
-				continue
-			}
+		// Check for collision with player; broken platforms never collide,
+		// and a player sitting in a cannon is out of reach
+		if g.loadedCannon == nil && landsOn(g.player.X, g.player.Y, g.player.VelocityY*g.step, p) {
+			g.magnetizeLanding(p)
+			g.player.LaunchX = p.velocity() // A moving platform carries the jump along
 			
 			if p.Type == PlatformSticky {
 				// Stick to platform
 				g.stuckToPlatform = p
-				g.stuckTimer = 0
+				g.timers.Add(TimerStuck, math.Inf(1), nil)
 				g.player.VelocityY = 0
 				g.player.Y = p.Y - PlayerHeight/2 // Align player with platform
 				g.canJumpRelease = false // Require new jump press to release
+				g.landingFeedback(p)
 			} else if p.Type == PlatformDisappearing && p.State == PlatformIntact {
 				// Start breaking animation for disappearing platform
 				p.State = PlatformBreaking
-				p.BreakTimer = 0.3 // Time until platform breaks
+				g.timers.Add(platformBreak{p}, PlatformBreakTime, func(*Game) { p.State = PlatformBroken })
 				
 				// Allow player to jump off it once
-				jumpForce := float64(JumpVelocity)
-				if g.player.BoostType == BoostJump {
-					jumpForce *= 1.5
-				}
-				g.player.VelocityY = jumpForce
+				g.player.VelocityY = g.bounceVelocity(p)
+				g.landingFeedback(p)
 			} else {
-				// Normal platform bounce
-				jumpForce := float64(JumpVelocity)
-				if g.player.BoostType == BoostJump {
-					jumpForce *= 1.5
-				}
-				g.player.VelocityY = jumpForce
+				// Normal and spring platform bounce
+				g.player.VelocityY = g.bounceVelocity(p)
+				g.landingFeedback(p)
+			}
+			if p.Type == PlatformGamble {
+				g.resolveWager(p)
 			}
+			g.chainBounce(p)
+			g.events.Publish(Event{Kind: EventLanding, Value: p.Type, X: p.X, Y: p.Y})
 		}
 	}
 
-	// Update stuck timer for animation
+	// Keep player stuck to platform
 	if g.stuckToPlatform != nil {
-		g.stuckTimer += 1.0 / 60.0
-		// Keep player stuck to platform
 		g.player.Y = g.stuckToPlatform.Y - PlayerHeight/2
 		g.player.VelocityY = 0
+		g.stickyHoldAssist()
 	}
 
-	// Update boost effects
-	if g.player.BoostType != BoostNone {
-		g.player.BoostTimer -= 1.0 / 60.0
-		if g.player.BoostTimer <= 0 {
-			g.player.BoostType = BoostNone
-			g.player.BoostTimer = 0
-		}
-	}
-
-	// Update fly timer
-	if g.player.CanFly {
-		g.player.FlyTimer -= 1.0 / 60.0
-		if g.player.FlyTimer <= 0 {
-			g.player.CanFly = false
-		}
-	}
-
-	// Update shoot timer
-	if g.player.ShootTimer > 0 {
-		g.player.ShootTimer -= 1.0 / 60.0
+	// Boosts, flight, cooldowns, i-frames, crumbling platforms and weather
+	g.updateLightning()
+	g.timers.Tick(g, g.dt)
+	g.updateWarmth()
+	if g.opts.sandbox {
+		g.updateSandbox()
 	}
+	g.updateMigration()
+	g.stream.Update(g.worldTop())
 	
 	// Update boosts
 	for i := 0; i < len(g.boosts); i++ {
+		g.updateDrop(&g.boosts[i])
+
 		// Check for collision with player
-		if g.boosts[i].Active &&
-			g.player.X+PlayerWidth/3 >= g.boosts[i].X &&
-			g.player.X-PlayerWidth/3 <= g.boosts[i].X+PlatformWidth/2 &&
-			g.player.Y+PlayerHeight/2 >= g.boosts[i].Y &&
-			g.player.Y-PlayerHeight/2 <= g.boosts[i].Y+PlatformHeight*2 {
+		if g.boosts[i].Active && physics.CircleRect(g.boosts[i].circle(), g.player.rect()) {
 			
 			// Apply boost effect
-			g.player.BoostType = g.boosts[i].Type
-			g.player.BoostTimer = BoostDuration
+			g.startBoost(g.boosts[i].Type)
 			
 			// Deactivate boost
 			g.boosts[i].Active = false
-			
-			// If it's the fly boost, enable flying
-			if g.boosts[i].Type == BoostJump {
-				g.player.CanFly = true
-				g.player.FlyTimer = FlyDuration
-			}
+			g.feedbackAt(FeedbackBoostPickup, g.boosts[i].X, g.boosts[i].Y)
+			g.events.Publish(Event{Kind: EventBoostPickup, Value: g.boosts[i].Type, X: g.boosts[i].X, Y: g.boosts[i].Y})
 		}
 		
 		// Remove inactive boosts
@@ -817,34 +1061,30 @@ func (g *Game) Update() error {
 		playerSpeed = 5.0 // Speed boost makes player move faster
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
-		g.player.X -= playerSpeed
+	// Analog sticks give fractional moves for finer control
+	move := g.controller.Horizontal(g.player.X)
+	g.player.MoveX = move * playerSpeed
+	if move < 0 {
 		g.player.FacingRight = false
-		if g.player.X < 0 {
-			g.player.X = ScreenWidth
-		}
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
-		g.player.X += playerSpeed
+	if move > 0 {
 		g.player.FacingRight = true
-		if g.player.X > ScreenWidth {
-			g.player.X = 0
-		}
 	}
+	g.player.X += (g.player.MoveX + g.player.LaunchX) * g.step
+	g.applyEdges()
 
 	// Fly with Up key (if can fly)
-	if (ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW)) && g.player.CanFly {
+	if g.controller.Pressed(input.ActionJump) && g.canFly() {
 		g.player.VelocityY = -4 // Fly upward
 	}
 
 	// Toggle flying with F key
-	if inpututil.IsKeyJustPressed(ebiten.KeyF) && g.player.FlyTimer <= 0 {
-		g.player.CanFly = true
-		g.player.FlyTimer = FlyDuration
+	if g.controller.JustPressed(input.ActionFly) && !g.canFly() {
+		g.timers.Add(TimerFly, g.tuning.FlyDuration, nil)
 	}
 
 	// Shooting with Space key
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) && g.player.ShootTimer <= 0 {
+	if g.controller.JustPressed(input.ActionShoot) && !g.timers.Active(TimerShoot) {
 		// Create a new bullet
 		direction := 1
 		if !g.player.FacingRight {
@@ -855,24 +1095,37 @@ func (g *Game) Update() error {
 			X:         g.player.X + float64(direction*PlayerWidth/2),
 			Y:         g.player.Y,
 			Direction: direction,
-			Speed:     BulletSpeed,
+			Speed:     g.tuning.BulletSpeed,
 			Active:    true,
 		}
+		if g.gunner != nil {
+			g.aimAtReticle(&bullet)
+		} else {
+			g.aimBullet(&bullet)
+		}
 		
 		g.bullets = append(g.bullets, bullet)
-		g.player.ShootTimer = ShootCooldown
+		g.timers.Add(TimerShoot, g.tuning.ShootCooldown, nil)
+		g.feedback(FeedbackShoot)
 	}
 
+	// Cannons and the hook override movement, so they go right before gravity
+	g.updateCannons()
+	g.updateGrapple()
+	g.applyUpdrafts()
+
 	// Apply gravity (unless flying)
-	g.player.VelocityY += Gravity
-	g.player.Y += g.player.VelocityY
+	g.player.Y, g.player.VelocityY = fall(g.player.Y, g.player.VelocityY, g.gravity, g.step)
 
 	// Update bullets
 	for i := 0; i < len(g.bullets); i++ {
-		g.bullets[i].X += g.bullets[i].Speed * float64(g.bullets[i].Direction)
+		dx, dy := g.bullets[i].Speed*float64(g.bullets[i].Direction)*g.step, g.bullets[i].SpeedY*g.step
+		g.bullets[i].X += dx
+		g.bullets[i].Y += dy
 		
 		// Check if bullet is off screen
-		if g.bullets[i].X < 0 || g.bullets[i].X > ScreenWidth {
+		if g.bullets[i].X < 0 || g.bullets[i].X > ScreenWidth ||
+			g.screenY(g.bullets[i].Y) < 0 || g.screenY(g.bullets[i].Y) > ScreenHeight {
 			g.bullets[i] = g.bullets[len(g.bullets)-1]
 			g.bullets = g.bullets[:len(g.bullets)-1]
 			i--
@@ -880,28 +1133,43 @@ func (g *Game) Update() error {
 		}
 		
 		// Check for collision with birds
+		stopped := false
 		for j := range g.birds {
 			b := &g.birds[j]
-			if g.bullets[i].X >= b.X && 
-				g.bullets[i].X <= b.X+BirdWidth &&
-				g.bullets[i].Y >= b.Y &&
-				g.bullets[i].Y <= b.Y+BirdHeight {
+			bx, by := g.bullets[i].X, g.bullets[i].Y
+			prev := physics.Circle{X: bx - dx, Y: by - dy, R: BulletRadius}
+			if _, hit := physics.SweepCircle(prev, dx, dy, g.birdRect(b)); hit {
 				
 				// Remove bird and regenerate it above
-				b.Y = -BirdHeight * 2  // Move bird off screen to be regenerated
+				g.feedbackAt(FeedbackKill, g.bullets[i].X, g.bullets[i].Y)
+				g.addScore(ScoreKills, KillScore)
+				g.events.Publish(Event{Kind: EventKill, Points: KillScore, X: b.X, Y: b.Y})
+				b.leavePerch()
+				b.Y = g.worldTop() - BirdHeight*2 // Move bird off screen to be regenerated
+				if b.Wave {
+					g.birds = slices.Delete(g.birds, j, j+1)
+				}
 				
 				// Remove bullet
 				g.bullets[i] = g.bullets[len(g.bullets)-1]
 				g.bullets = g.bullets[:len(g.bullets)-1]
 				i--
+				stopped = true
 				break
 			}
 		}
+
+		// Friendly fire, when the tuning turns it on, stops bullets too
+		if !stopped && g.friendlyFire(&g.bullets[i], dx, dy) {
+			g.bullets[i] = g.bullets[len(g.bullets)-1]
+			g.bullets = g.bullets[:len(g.bullets)-1]
+			i--
+		}
 	}
 
 	// Update cloud positions
 	for i := range g.clouds {
-		g.clouds[i].X += g.clouds[i].SpeedX
+		g.clouds[i].X += g.clouds[i].SpeedX * g.step
 
 		// Wrap around screen
 		if g.clouds[i].X > ScreenWidth {
@@ -912,90 +1180,109 @@ func (g *Game) Update() error {
 	// Update bird positions
 	for i := range g.birds {
 		b := &g.birds[i]
-		b.X += b.SpeedX * float64(b.Direction)
+		if !g.updateBirdWeather(i, b) {
+			b.leavePerch() // Sheltering birds don't sit out in the weather
+			continue
+		}
+		if !g.updatePerch(b) {
+			b.X += b.SpeedX * float64(b.Direction) * birdWeathers[g.weather].Speed * g.step
+
+			// Wrap around screen
+			if b.X < -BirdWidth && b.Direction < 0 {
+				b.X = ScreenWidth
+			} else if b.X > ScreenWidth && b.Direction > 0 {
+				b.X = -BirdWidth
+			}
+		}
 
-		// Wrap around screen
-		if b.X < -BirdWidth && b.Direction < 0 {
-			b.X = ScreenWidth
-		} else if b.X > ScreenWidth && b.Direction > 0 {
-			b.X = -BirdWidth
+		// Birds on screen chirp now and then, panned to where they are
+		if sy := g.screenY(b.Y); sy > -BirdHeight && sy < ScreenHeight && g.rng.Float64() < g.chance(BirdChirpChance) {
+			g.feedbackAt(FeedbackBirdChirp, b.X+BirdWidth/2, b.Y+BirdHeight/2)
 		}
 
-		// Check for collision with player
-		if g.player.X+PlayerWidth/4 >= b.X &&
-			g.player.X-PlayerWidth/4 <= b.X+BirdWidth &&
-			g.player.Y+PlayerHeight/4 >= b.Y &&
-			g.player.Y-PlayerHeight/4 <= b.Y+BirdHeight {
+		// Check for collision with player; birds pass through during i-frames
+		if !g.timers.Active(TimerInvincible) && g.hitsPlayer(g.birdRect(b)) {
 			
-			// Shield boost protects against birds
-			if g.player.BoostType != BoostShield {
-				g.gameOver = true
+			// Perched birds can be stomped; shield boost protects against the rest
+			if g.stomps(b) {
+				g.stompBird(b)
+			} else if g.player.BoostType != BoostShield {
+				g.endRun(DeathBird)
 			} else {
 				// Remove bird and regenerate it above instead of game over
-				b.Y = -BirdHeight * 2
+				b.leavePerch()
+				b.Y = g.worldTop() - BirdHeight*2
+				g.feedback(FeedbackShieldHit)
 			}
 		}
 	}
 
 	// Platform collisions are handled in the Update platform states section above
 
-	// Camera follows player when jumping high
+	// Camera follows player when jumping high. Entities stay in world space;
+	// only the camera moves, and whatever scrolls off the bottom is recycled.
 	highPoint := ScreenHeight * 0.4
-	if g.player.Y < highPoint {
-		diff := highPoint - g.player.Y
+	if g.screenY(g.player.Y) < highPoint {
+		diff := highPoint - g.screenY(g.player.Y)
 		g.camera += diff
-		g.player.Y += diff
 
-		// Move platforms down
-		for i := range g.platforms {
-			g.platforms[i].Y += diff
+		// Particles live in screen coordinates; those of the world scroll
+		for i := range g.particles {
+			if g.particles[i].Space == ParticleWorld {
+				g.particles[i].Y += diff
+			}
+		}
 
+		// Recycle platforms that scrolled off the bottom
+		for i := range g.platforms {
 			// If platform goes off screen, create new one at the top
-			if g.platforms[i].Y > ScreenHeight {
-				g.platforms[i].Y = 0
-				g.platforms[i].X = rand.Float64() * (ScreenWidth - PlatformWidth)
-				g.score++
+			if g.screenY(g.platforms[i].Y) > ScreenHeight {
+				g.addScore(ScorePlatforms, 1)
 				
 				// Reset platform state if it was broken
 				if g.platforms[i].Type == PlatformDisappearing {
-					g.platforms[i].State = PlatformIntact
+					g.timers.Cancel(platformBreak{&g.platforms[i]})
 				}
 				
-				// Generate a new platform type
-				platformType := PlatformNormal
-				rnd := rand.Float64()
-				if rnd < 0.2 { // 20% chance for sticky platform
-					platformType = PlatformSticky
-				} else if rnd < 0.35 { // 15% chance for disappearing platform
-					platformType = PlatformDisappearing
+				// Take the next slot of the seeded world layout
+				platform, boost := g.stream.Chunk(g.nextSlot / PlatformCount).slot(g.nextSlot % PlatformCount)
+				g.releasePerch(i)
+				g.unmount(i)
+				g.platforms[i] = platform
+				g.nextSlot++
+
+				// The layout may rest a boost on this platform, before
+				// anything else takes its slot
+				if boost.Active {
+					boost.Mount.Platform = i
+					g.boosts = append(g.boosts, boost)
 				}
-				g.platforms[i].Type = platformType
+				g.spawnCannon(i)
+				g.spawnUpdraft(&g.platforms[i])
+				g.spawnCampfire(i)
 				
 				// Check if difficulty should increase
-				newDifficulty := g.score / ScorePerDifficulty
-				if newDifficulty > g.difficulty {
-					g.difficulty = newDifficulty
+				curve := g.difficultyCurve.At(g.difficultyScore())
+				if curve.Level > g.difficulty {
+					g.difficulty = curve.Level
 					
-					// Calculate how many birds based on difficulty (cap at MaxBirdCount)
-					newBirdCount := InitialBirdCount + g.difficulty
-					if newBirdCount > MaxBirdCount {
-						newBirdCount = MaxBirdCount
-					}
+					// Bird count follows the difficulty profile (capped at the level's max)
+					newBirdCount := curve.Birds
 					
 					// If we need more birds than we currently have
 					if newBirdCount > g.birdCount {
 						// Add more birds
 						for j := g.birdCount; j < newBirdCount; j++ {
 							direction := 1
-							if rand.Float64() < 0.5 {
+							if g.rng.Float64() < 0.5 {
 								direction = -1
 							}
 							
 							// Place new bird above the screen
 							newBird := Bird{
-								X:         rand.Float64() * ScreenWidth,
-								Y:         -BirdHeight * float64(1+j%MaxBirdsPerLine), // Stagger birds vertically
-								SpeedX:    g.birdSpeedMin + rand.Float64()*(g.birdSpeedMax-g.birdSpeedMin),
+								X:         g.rng.Float64() * ScreenWidth,
+								Y:         g.worldTop() - BirdHeight*float64(1+j%MaxBirdsPerLine), // Stagger birds vertically
+								SpeedX:    g.birdSpeedMin + g.rng.Float64()*(g.birdSpeedMax-g.birdSpeedMin),
 								Direction: direction,
 							}
 							g.birds = append(g.birds, newBird)
@@ -1004,38 +1291,16 @@ func (g *Game) Update() error {
 					}
 					
 					// Increase bird speed gradually up to max values
-					progressFactor := float64(g.difficulty) / 10 // Full speed increase over ~10 difficulty levels
-					if progressFactor > 1 {
-						progressFactor = 1
-					}
-					
-					// Linear interpolation between initial and max speeds
-					g.birdSpeedMin = InitialBirdSpeedMin + progressFactor*(MaxBirdSpeedMin-InitialBirdSpeedMin)
-					g.birdSpeedMax = InitialBirdSpeedMax + progressFactor*(MaxBirdSpeedMax-InitialBirdSpeedMax)
-				}
-				
-				// Potentially spawn a boost on this platform
-				if rand.Float64() < BoostSpawnChance {
-					boostType := rand.Intn(3) + 1 // Random boost type 1-3
-					
-					boost := Boost{
-						X:      g.platforms[i].X + PlatformWidth/4,
-						Y:      g.platforms[i].Y - PlatformHeight*2,
-						Type:   boostType,
-						Active: true,
-					}
-					
-					g.boosts = append(g.boosts, boost)
+					g.birdSpeedMin, g.birdSpeedMax = curve.SpeedMin, curve.SpeedMax
 				}
 			}
 		}
 
-		// Move birds down
+		// Recycle birds that scrolled off the bottom
+		g.dropPassedWave()
 		for i := range g.birds {
-			g.birds[i].Y += diff
-
 			// If bird goes off screen, create new one at the top
-			if g.birds[i].Y > ScreenHeight {
+			if g.screenY(g.birds[i].Y) > ScreenHeight {
 				// Check for existing birds at similar heights (enforce max birds per line)
 				validPosition := false
 				maxAttempts := 10
@@ -1044,7 +1309,7 @@ func (g *Game) Update() error {
 				// Keep trying new positions until we find a valid one
 				for !validPosition && attempts < maxAttempts {
 					// Start with a random Y position above the screen
-					newY := -BirdHeight - float64(rand.Intn(3))*BirdHeight
+					newY := g.worldTop() - BirdHeight - float64(g.rng.Intn(3))*BirdHeight
 					
 					// Check if this position would cause more than MaxBirdsPerLine at same height
 					birdsAtSameHeight := 0
@@ -1065,46 +1330,163 @@ func (g *Game) Update() error {
 				
 				// If we couldn't find a valid position after max attempts, place bird higher
 				if !validPosition {
-					g.birds[i].Y = -BirdHeight * (5 + rand.Float64()*5)
+					g.birds[i].Y = g.worldTop() - BirdHeight*(5+g.rng.Float64()*5)
 				}
 				
-				g.birds[i].X = rand.Float64() * ScreenWidth
+				g.birds[i].leavePerch()
+				g.birds[i].X = g.rng.Float64() * ScreenWidth
 				g.birds[i].Direction = 1
-				if rand.Float64() < 0.5 {
+				if g.rng.Float64() < 0.5 {
 					g.birds[i].Direction = -1
 				}
 				
 				// Use current dynamic speed range
-				g.birds[i].SpeedX = g.birdSpeedMin + rand.Float64()*(g.birdSpeedMax-g.birdSpeedMin)
+				g.birds[i].SpeedX = g.birdSpeedMin + g.rng.Float64()*(g.birdSpeedMax-g.birdSpeedMin)
 			}
 		}
 
-		// Move clouds down
+		// Recycle clouds that scrolled off the bottom
 		for i := range g.clouds {
-			g.clouds[i].Y += diff
-
 			// If cloud goes off screen, create new one at the top
-			if g.clouds[i].Y > ScreenHeight {
-				g.clouds[i].Y = -CloudHeight
-				g.clouds[i].X = rand.Float64() * ScreenWidth
-				g.clouds[i].SpeedX = CloudSpeedMin + rand.Float64()*(CloudSpeedMax-CloudSpeedMin)
-				g.clouds[i].Alpha = 0.5 + rand.Float64()*0.5
+			if g.screenY(g.clouds[i].Y) > ScreenHeight {
+				g.clouds[i].Y = g.worldTop() - CloudHeight
+				g.clouds[i].X = g.rng.Float64() * ScreenWidth
+				g.clouds[i].SpeedX = CloudSpeedMin + g.rng.Float64()*(CloudSpeedMax-CloudSpeedMin)
+				g.clouds[i].Alpha = 0.5 + g.rng.Float64()*0.5
 			}
 		}
 	}
 
 	// Game over if player falls below screen
-	if g.player.Y > ScreenHeight {
-		g.gameOver = true
+	if g.screenY(g.player.Y) > ScreenHeight {
+		g.endRun(DeathFall)
 	}
 
 	return nil
 }
 
-// Draw draws the game screen
+// timeOfDay returns the current position in the day cycle (0.0 - 1.0),
+// shared by the sky renderer and the ambient soundscape
+func (g *Game) timeOfDay() float64 {
+	return math.Mod(float64(g.climbed())/DayCycleLength+g.initialTimeOfDay, 1.0)
+}
+
+// restart starts a new run, keeping state that outlives a single run
+func (g *Game) restart() {
+	g.restartWith()
+}
+
+// restartWith starts a new run with extra options on top of the game's
+// own, which later restarts keep
+func (g *Game) restartWith(extra ...Option) {
+	capture, sound, ambience, world, lake, markers, online, session := g.capture, g.audio, g.ambience, g.world, g.lake, g.markerBoard, g.online, g.session
+	*g = *NewGame(append(slices.Clip(g.optionList), extra...)...)
+	g.capture = capture
+	g.session = session
+	g.world = world
+	g.lake = lake
+	g.markerBoard = markers
+	g.online = online
+	g.scenes.Switch(playScene) // Restarts skip the title
+	if bot, ok := g.controller.(*Bot); ok {
+		bot.g = g // NewGame built it for the copy just discarded
+	}
+	if sound != nil {
+		g.audio = sound
+		g.ambience = ambience
+		g.applyAudioSettings()
+	}
+}
+
+// endRun ends the current run and records it, with its trajectory and
+// cause of death, in the save file
+func (g *Game) endRun(cause DeathCause) {
+	if g.gameOver {
+		return
+	}
+	if g.opts.sandbox || g.opts.bench != nil {
+		g.sandboxRespawn() // Benchmarks measure one endless run
+		return
+	}
+	if g.useShield(cause) {
+		return
+	}
+	g.events.Publish(Event{Kind: EventDeath, Value: int(cause), X: g.player.X, Y: g.player.Y})
+	g.gameOver, g.deathCause, g.diedStuck = true, cause, g.stuckToPlatform != nil
+	g.sendGhost() // The ghost's friend sees the fall
+	if g.replayView != nil {
+		return // The replay scene stays up for scrubbing back
+	}
+	g.scenes.Switch(gameOverScene)
+	if g.opts.idle {
+		return // The bot's runs aren't the player's
+	}
+	g.session.recordRun(g)
+	if g.opts.race != nil {
+		return // Two players share the profile, so races aren't recorded
+	}
+	g.keepReplay()
+	g.save.Heatmap.addRun(g.trace, DeathRecord{Cause: cause, X: g.player.X, Altitude: g.playerAltitude()})
+	g.save.recordDeath(cause)
+	g.finishAchievements()
+	best := g.save.best(g.mode)
+	g.recordDaily()
+	g.earnCoins()
+	g.save.recordRun(g.mode, g.score)
+	g.notifyRun(best)
+	g.submitRun()
+	if g.opts.tournament != nil {
+		g.opts.tournament.Record(g.score)
+	}
+	if g.opts.hotseat != nil {
+		g.opts.hotseat.Record(g.score)
+	}
+	if g.opts.kiosk != nil {
+		g.kioskRunEnded()
+	}
+	g.feedback(FeedbackGameOver)
+}
+
+// Draw draws the active scene
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Calculate current time of day (0.0 - 1.0)
-	timeOfDay := math.Mod(float64(g.score)/DayCycleLength + g.initialTimeOfDay, 1.0)
+	if g.idleSkipDraw() {
+		return
+	}
+	g.scenes.Current().Draw(g, screen)
+	g.postNotice()
+	g.drawFPS(screen)
+	if devOverlay != nil {
+		devOverlay(g, screen)
+	}
+}
+
+// drawPlay draws a run: the world under the HUD
+func (g *Game) drawPlay(screen *ebiten.Image) {
+	if g.capture != nil {
+		g.capture.draw(screen, g.drawWorld)
+	} else {
+		g.drawWorld(screen)
+	}
+
+	// Draw score, info and hints; the screensaver shows only the world
+	if !g.opts.idle {
+		g.hud.Draw(screen, g)
+		g.drawPostcard(screen)
+		g.drawToast(screen)
+	} else if g.opts.attract != nil {
+		g.drawAttract(screen)
+	}
+	if g.opts.sandbox {
+		g.drawSandbox(screen)
+	}
+	if g.gunner != nil {
+		g.drawReticle(screen)
+	}
+}
+
+// drawWorld draws everything except the HUD
+func (g *Game) drawWorld(screen *ebiten.Image) {
+	timeOfDay := g.timeOfDay()
 
 	// Get color set for current time
 	colorSet := getColorSetForTime(timeOfDay)
@@ -1151,7 +1533,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		color.G = uint8(float64(color.G) * brightness)
 		color.B = uint8(float64(color.B) * brightness)
 		
-		ebitenutil.DrawRect(screen, 0, float64(y), ScreenWidth, 1, color)
+		drawRect(screen, 0, float64(y), ScreenWidth, 1, color)
 	}
 
 	// Draw stars during night time
@@ -1193,7 +1575,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			
 			// Draw star with slight glow effect
 			size := 1.0 + star.brightness*1.0
-			ebitenutil.DrawCircle(screen, starX, star.y, size, starColor)
+			drawCircle(screen, starX, star.y, size, starColor)
 			
 			// Add a subtle glow
 			glowColor := color.RGBA{
@@ -1202,12 +1584,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 				B: uint8(255 * brightness * 0.3),
 				A: uint8(255 * brightness * 0.3),
 			}
-			ebitenutil.DrawCircle(screen, starX, star.y, size*2, glowColor)
+			drawCircle(screen, starX, star.y, size*2, glowColor)
 		}
 	}
 
-	// Draw mountain layers
-	for i := len(g.mountainImgs) - 1; i >= 0; i-- {
+	// Draw mountain layers, relighting them if the sun or snow moved on
+	g.mountains.relight(timeOfDay)
+	for i := len(g.mountains.layers) - 1; i >= 0; i-- {
+		layer := g.mountains.layers[i]
 		op := &ebiten.DrawImageOptions{}
 		
 		// Calculate parallax offset
@@ -1231,12 +1615,24 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			1,
 		)
 		
-		// Draw main layer and tiled copy
-		screen.DrawImage(g.mountainImgs[i], op)
+		// The overlay keeps its own colors but dims with the layer at night
+		light := &ebiten.DrawImageOptions{GeoM: op.GeoM}
+		dim := 0.5 + 0.5*(float64(tint.R)+float64(tint.G)+float64(tint.B))/(3*255)
+		light.ColorM.Scale(dim, dim, dim, 1)
+
+		// Draw main layer and tiled copy, each with its rim light and snow
+		drawImage(screen, layer.img, op)
+		if layer.overlay != nil {
+			drawImage(screen, layer.overlay, light)
+		}
 		op.GeoM.Reset()
 		op.GeoM.Scale(scaleX, scaleY)
 		op.GeoM.Translate(-math.Mod(parallaxOffset, float64(ScreenWidth))+float64(ScreenWidth), -yOffset)
-		screen.DrawImage(g.mountainImgs[i], op)
+		light.GeoM = op.GeoM
+		drawImage(screen, layer.img, op)
+		if layer.overlay != nil {
+			drawImage(screen, layer.overlay, light)
+		}
 	}
 
 	// Draw clouds with adjusted transparency based on time of day
@@ -1245,7 +1641,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		sx := c.Width / CloudWidth
 		sy := c.Height / CloudHeight
 		op.GeoM.Scale(sx, sy)
-		op.GeoM.Translate(c.X, c.Y)
+		op.GeoM.Translate(c.X, g.screenY(c.Y))
 
 		// Adjust cloud visibility based on time of day
 		alpha := c.Alpha
@@ -1254,12 +1650,19 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		}
 		op.ColorM.Scale(1, 1, 1, alpha)
 
-		screen.DrawImage(g.cloudImg, op)
+		g.drawSprite(screen, g.sprites.Lookup(SpriteKey{Entity: EntityCloud}), op)
 	}
+	g.drawLandmarks(screen)
+
+	// The lake reflects everything drawn so far and scrolls away as the player climbs
+	g.lake.draw(screen, g)
+	g.drawUpdrafts(screen)
 
 	// Draw platforms
 	for i := range g.platforms {
 		p := &g.platforms[i]  // Get pointer to platform
+		py := g.screenY(p.Y)
+		img := g.sprite(p)
 		
 		// Skip drawing broken platforms
 		if p.Type == PlatformDisappearing && p.State == PlatformBroken {
@@ -1268,7 +1671,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		
 		if p.Type == PlatformSticky {
 			op := &ebiten.DrawImageOptions{}
-			op.GeoM.Translate(p.X, p.Y)
+			op.GeoM.Translate(p.X, py)
 
 			// Apply night mode color adjustment
 			if g.nightMode {
@@ -1280,27 +1683,27 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			
 			// Add pulsing effect when player is stuck
 			if p == g.stuckToPlatform {
-				pulse := 0.3 + 0.2*math.Sin(g.stuckTimer*6.0)
+				pulse := 0.3 + 0.2*math.Sin(g.timers.Elapsed(TimerStuck)*6.0)
 				op.ColorM.Scale(1.0+pulse, 1.0+pulse, 0.5+pulse, 1)
 				
 				// Draw "Jump!" text
-				ebitenutil.DebugPrintAt(screen, "Jump!", int(p.X)+20, int(p.Y)-15)
+				drawText(screen, "Jump!", p.X+10, py-12, TextSmall, textColor)
 				
 				// Draw sticky effect particles
 				for i := 0; i < 3; i++ {
-					if rand.Float64() < 0.7 {
-						particleX := p.X + rand.Float64()*PlatformWidth
-						particleY := p.Y + rand.Float64()*PlatformHeight/2
+					if fxFloat64() < 0.7 {
+						particleX := p.X + fxFloat64()*PlatformWidth
+						particleY := py + fxFloat64()*PlatformHeight/2
 						particleColor := color.RGBA{255, 220, 100, 180}
-						ebitenutil.DrawCircle(screen, particleX, particleY, 1.5, particleColor)
+						drawCircle(screen, particleX, particleY, 1.5, particleColor)
 					}
 				}
 			}
 
-			screen.DrawImage(g.platformImg, op)
+			g.drawSprite(screen, img, op)
 		} else if p.Type == PlatformDisappearing {
 			op := &ebiten.DrawImageOptions{}
-			op.GeoM.Translate(p.X, p.Y)
+			op.GeoM.Translate(p.X, py)
 
 			// Apply night mode color adjustment
 			if g.nightMode {
@@ -1313,53 +1716,74 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			// Apply cracking animation effect
 			if p.State == PlatformBreaking {
 				// Make platform fade and shake as it breaks
-				breakProgress := 1.0 - (p.BreakTimer / 0.3)
+				breakProgress := 0.0
+				if t := g.timers.Get(platformBreak{p}); t != nil {
+					breakProgress = t.Progress()
+				}
 				op.ColorM.Scale(1, 1, 1, 1.0-breakProgress*0.5)
 				
 				// Add shaking effect
-				shakeX := (rand.Float64()*2 - 1) * breakProgress * 3
-				shakeY := (rand.Float64()*2 - 1) * breakProgress * 2
+				shakeX := (fxFloat64()*2 - 1) * breakProgress * 3
+				shakeY := (fxFloat64()*2 - 1) * breakProgress * 2
 				op.GeoM.Translate(shakeX, shakeY)
 				
 				// Draw cracks
 				for i := 0; i < 5; i++ {
-					crackX1 := p.X + rand.Float64()*PlatformWidth
-					crackY1 := p.Y + rand.Float64()*PlatformHeight
-					crackX2 := crackX1 + (rand.Float64()*2-1)*10*breakProgress
-					crackY2 := crackY1 + (rand.Float64()*2-1)*5*breakProgress
-					ebitenutil.DrawLine(screen, crackX1, crackY1, crackX2, crackY2, color.RGBA{80, 80, 80, 200})
+					crackX1 := p.X + fxFloat64()*PlatformWidth
+					crackY1 := py + fxFloat64()*PlatformHeight
+					crackX2 := crackX1 + (fxFloat64()*2-1)*10*breakProgress
+					crackY2 := crackY1 + (fxFloat64()*2-1)*5*breakProgress
+					drawLine(screen, crackX1, crackY1, crackX2, crackY2, color.RGBA{80, 80, 80, 200})
 				}
 			}
 
-			screen.DrawImage(g.platformImg, op)
+			g.drawSprite(screen, img, op)
+		} else if p.Type == PlatformSpring {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(p.X, py)
+			if g.nightMode {
+				op.ColorM.Scale(0.7, 0.7, 0.9, 1)
+			}
+			// Green platform with a coil on top
+			op.ColorM.Scale(0.6, 1.0, 0.6, 1)
+			g.drawSprite(screen, img, op)
+			drawSpringCoil(screen, p.X+PlatformWidth/2, py)
+		} else if p.Type == PlatformGamble {
+			g.drawGamblePlatform(screen, img, p, py)
+		} else if p.Type == PlatformMoving {
+			g.drawMovingPlatform(screen, img, p, py)
+		} else if p.Type == PlatformPhase {
+			g.drawPhasePlatform(screen, img, p, py)
 		} else {
 			// Normal platform drawing
 			op := &ebiten.DrawImageOptions{}
-			op.GeoM.Translate(p.X, p.Y)
+			op.GeoM.Translate(p.X, py)
 
 			// Apply night mode color adjustment
 			if g.nightMode {
 				op.ColorM.Scale(0.7, 0.7, 0.9, 1)
 			}
 
-			screen.DrawImage(g.platformImg, op)
+			g.drawSprite(screen, img, op)
 		}
 	}
+
+	g.drawLandingBits(screen)
+
+	// Shadows of birds overhead, on top of the platforms they threaten
+	g.drawBirdShadows(screen)
+	g.drawBounceRings(screen)
+	if g.save.Settings.LandingMarker {
+		g.drawLandingMarker(screen)
+	}
 	
 	// Draw boosts
 	for _, b := range g.boosts {
-		if b.Active {
-			var boostColor color.RGBA
-			
+		if b.Active && !g.dropHidden(&b) {
+			g.drawDropSparkle(screen, &b)
+
 			// Different colors for different boost types
-			switch b.Type {
-			case BoostSpeed:
-				boostColor = color.RGBA{255, 50, 50, 255} // Red for speed
-			case BoostJump:
-				boostColor = color.RGBA{50, 255, 50, 255} // Green for jump/fly
-			case BoostShield:
-				boostColor = color.RGBA{50, 50, 255, 255} // Blue for shield
-			}
+			boostColor := boostColors[b.Type]
 			
 			// Adjust color for night mode
 			if g.nightMode {
@@ -1369,7 +1793,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			}
 			
 			// Draw boost as a colored circle
-			ebitenutil.DrawCircle(screen, b.X, b.Y, 10, boostColor)
+			drawCircle(screen, b.X, g.screenY(b.Y), BoostRadius, boostColor)
 		}
 	}
 	
@@ -1381,30 +1805,28 @@ func (g *Game) Draw(screen *ebiten.Image) {
 				bulletColor = color.RGBA{200, 200, 50, 255} // Darker yellow at night
 			}
 			
-			ebitenutil.DrawCircle(screen, b.X, b.Y, 3, bulletColor)
+			drawCircle(screen, b.X, g.screenY(b.Y), BulletRadius, bulletColor)
 		}
 	}
 
+	g.drawFlocks(screen)
+
 	// Draw birds
 	for _, b := range g.birds {
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(b.X, b.Y)
+		op.GeoM.Translate(b.X, g.screenY(b.Y))
 
 		// Apply night mode color adjustment
 		if g.nightMode {
 			op.ColorM.Scale(0.7, 0.7, 0.8, 1) // Darker at night
 		}
 
-		if b.Direction > 0 {
-			screen.DrawImage(g.birdRightImg, op)
-		} else {
-			screen.DrawImage(g.birdLeftImg, op)
-		}
+		g.drawSprite(screen, g.sprite(&b), op)
 	}
 
 	// Draw weather particles (rain or snow)
 	for _, p := range g.particles {
-		if g.weather == WeatherRain {
+		if rainy(g.weather) {
 			// Draw raindrops as blue lines
 			x1 := p.X
 			y1 := p.Y
@@ -1412,102 +1834,55 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			y2 := p.Y - p.SpeedY*0.5
 
 			if g.nightMode {
-				ebitenutil.DrawLine(screen, x1, y1, x2, y2, color.RGBA{100, 150, 255, uint8(p.Alpha * 255)})
+				drawLine(screen, x1, y1, x2, y2, color.RGBA{100, 150, 255, uint8(p.Alpha * 255)})
 			} else {
-				ebitenutil.DrawLine(screen, x1, y1, x2, y2, color.RGBA{70, 130, 230, uint8(p.Alpha * 255)})
+				drawLine(screen, x1, y1, x2, y2, color.RGBA{70, 130, 230, uint8(p.Alpha * 255)})
 			}
 		} else if g.weather == WeatherSnow {
 			// Draw snowflakes as small white dots
 			size := p.Size
 			if g.nightMode {
-				ebitenutil.DrawRect(screen, p.X, p.Y, size, size, color.RGBA{200, 200, 255, uint8(p.Alpha * 255)})
+				drawRect(screen, p.X, p.Y, size, size, color.RGBA{200, 200, 255, uint8(p.Alpha * 255)})
 			} else {
-				ebitenutil.DrawRect(screen, p.X, p.Y, size, size, color.RGBA{255, 255, 255, uint8(p.Alpha * 255)})
+				drawRect(screen, p.X, p.Y, size, size, color.RGBA{255, 255, 255, uint8(p.Alpha * 255)})
 			}
 		}
 	}
 
+	g.drawCannons(screen)
+	g.drawCampfires(screen)
+	g.drawAltitudeMarkers(screen)
+	g.drawGrapple(screen)
+	g.drawGhost(screen)
+	g.drawTrail(screen)
+
 	// Draw player
 	op := &ebiten.DrawImageOptions{}
 	if !g.player.FacingRight {
 		op.GeoM.Scale(-1, 1)
 		op.GeoM.Translate(PlayerWidth, 0)
 	}
-	op.GeoM.Translate(g.player.X-PlayerWidth/2, g.player.Y-PlayerHeight/2)
+	op.GeoM.Translate(g.player.X-PlayerWidth/2, g.screenY(g.player.Y)-PlayerHeight/2)
+	g.tintPlayer(op)
 
 	// Apply night mode color adjustment
 	if g.nightMode {
 		op.ColorM.Scale(0.7, 0.7, 0.9, 1) // Darker at night
 	}
 
-	screen.DrawImage(g.playerImg, op)
-
-	// Draw score and info
-	ebitenutil.DebugPrintAt(screen, "Score: "+strconv.Itoa(g.score), 5, 5)
-
-	// Display current weather
-	var weatherText string
-	switch g.weather {
-	case WeatherClear:
-		weatherText = "Clear"
-	case WeatherRain:
-		weatherText = "Rainy"
-	case WeatherSnow:
-		weatherText = "Snowy"
-	}
-
-	// Display time mode
-	var timeText string
-	if g.nightMode {
-		timeText = "Night"
-	} else {
-		timeText = "Day"
-	}
-
-	modeText := timeText + " / " + weatherText
-	ebitenutil.DebugPrintAt(screen, modeText, 5, 20)
-	
-	// Display active boost
-	var boostText string
-	switch g.player.BoostType {
-	case BoostNone:
-		boostText = "No Boost"
-	case BoostSpeed:
-		boostText = "Speed Boost: " + fmt.Sprintf("%.1f", g.player.BoostTimer)
-	case BoostJump:
-		boostText = "Jump Boost: " + fmt.Sprintf("%.1f", g.player.BoostTimer)
-	case BoostShield:
-		boostText = "Shield Boost: " + fmt.Sprintf("%.1f", g.player.BoostTimer)
-	}
-	ebitenutil.DebugPrintAt(screen, boostText, 5, 35)
-	
-	// Display if flying is active
-	if g.player.CanFly {
-		flyText := "Flying: " + fmt.Sprintf("%.1f", g.player.FlyTimer)
-		ebitenutil.DebugPrintAt(screen, flyText, 5, 50)
-	}
-	
-	// Display difficulty level
-	difficultyText := fmt.Sprintf("Difficulty: %d (Birds: %d)", g.difficulty, len(g.birds))
-	ebitenutil.DebugPrintAt(screen, difficultyText, 5, 65)
-	
-	// Controls info at bottom
-	ebitenutil.DebugPrintAt(screen, "Left/Right: Move, F: Fly, Space: Shoot", 5, ScreenHeight-35)
-	ebitenutil.DebugPrintAt(screen, "W: Toggle Weather", 5, ScreenHeight-20)
-
-	// Draw game over message
-	if g.gameOver {
-		msg := "Game Over! Press SPACE to restart"
-		ebitenutil.DebugPrintAt(
-			screen,
-			msg,
-			ScreenWidth/2-len(msg)*3,
-			ScreenHeight/2,
-		)
+	// Blink while invincible; reduced motion shows a steady fade instead
+	if t := g.timers.Get(TimerInvincible); t != nil {
+		alpha := 0.6
+		if !g.save.Settings.ReducedMotion && int(t.Remaining*10)%2 == 0 {
+			alpha = 0.25
+		}
+		op.ColorM.Scale(1, 1, 1, alpha)
 	}
 
-	// Draw help text at the bottom
-	ebitenutil.DebugPrintAt(screen, "Press UP/W or SPACE to release from sticky platforms!", 5, ScreenHeight-50)
+	g.drawSprite(screen, g.sprite(&g.player), op)
+	g.drawPrestigeBadge(screen)
+	g.drawStatusIcons(screen)
+	g.drawLightning(screen)
 }
 
 // Layout implements ebiten.Game interface
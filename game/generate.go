@@ -0,0 +1,77 @@
+package game
+
+import (
+	"math/rand"
+
+	"doodlejump/game/level"
+)
+
+// PlatformSpacing is the vertical distance between platform slots
+const PlatformSpacing = ScreenHeight / PlatformCount
+
+// GenerateChunk returns chunk index of the classic world of seed: its
+// platforms, the boosts resting on them and its landmarks. It is pure, so
+// replays, netplay and ghosts can regenerate the world instead of storing it.
+func GenerateChunk(seed int64, index int) *Chunk {
+	return generateChunk(seed, index, level.Classic())
+}
+
+// generateChunk lays out chunk index of seed with lvl's spawn table. Chunk
+// 0 starts with the platform under the player.
+func generateChunk(seed int64, index int, lvl *level.Level) *Chunk {
+	c := &Chunk{Index: index, Top: -float64(index) * ChunkHeight}
+	rng := rand.New(rand.NewSource(chunkSeed(seed, index)))
+	spawns := lvl.SpawnTable
+	weights := movingWeights(spawns.Platforms, index*PlatformCount)
+
+	for k := 0; k < PlatformCount; k++ {
+		p := Platform{
+			X:     rng.Float64() * (ScreenWidth - PlatformWidth),
+			Y:     c.Top + ChunkHeight - float64(k)*PlatformSpacing,
+			Type:  platformType(level.Pick(weights, level.PlatformTypes, rng.Float64())),
+			State: PlatformIntact,
+		}
+		if index == 0 && k == 0 {
+			p = Platform{X: ScreenWidth/2 - PlatformWidth/2, Y: ScreenHeight - 30, Type: PlatformNormal}
+		}
+		switch p.Type {
+		case PlatformMoving:
+			p.patrol(rng)
+		case PlatformPhase:
+			p.Phase = rng.Float64() * (PhaseOnTime + PhaseOffTime) // Neighbors blink out of step
+		}
+
+		boost := BoostNone
+		if rng.Float64() < spawns.BoostChance {
+			boost = boostType(level.Pick(spawns.Boosts, level.BoostTypes, rng.Float64()))
+			c.Boosts = append(c.Boosts, layoutBoost(&p, k, boost))
+		}
+		c.Platforms = append(c.Platforms, p)
+		c.boostOn = append(c.boostOn, boost)
+	}
+
+	for _, populate := range chunkPopulators {
+		populate(c, rng)
+	}
+	return c
+}
+
+// layoutBoost is a boost of type t resting in the left slot of p, platform
+// k of its chunk
+func layoutBoost(p *Platform, k, t int) Boost {
+	return Boost{
+		X: p.slotX(SlotLeft), Y: p.Y - PlatformHeight*2, Type: t, Active: true,
+		Mount: Mount{Platform: k, Slot: SlotLeft, Mounted: true},
+	}
+}
+
+// slot returns platform k of the chunk and the boost resting on it, an
+// inactive boost when there is none. The boost's mount counts platforms
+// from the chunk's first.
+func (c *Chunk) slot(k int) (Platform, Boost) {
+	p := c.Platforms[k]
+	if c.boostOn[k] == BoostNone {
+		return p, Boost{}
+	}
+	return p, layoutBoost(&p, k, c.boostOn[k])
+}
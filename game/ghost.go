@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"strconv"
+
+	"doodlejump/game/ghostnet"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Ghost race parameters
+const (
+	GhostSendEvery = 2   // Frames between poses sent to the peer
+	GhostAlpha     = 0.4 // Opacity of the ghost
+)
+
+// ghostRace is a ghost race over a network link. It outlives the games
+// rebuilt for every run.
+type ghostRace struct {
+	link   *ghostnet.Link
+	runs   uint16 // Runs started, so the peer can tell a new run from a late packet
+	failed bool   // Sending has failed and said so once
+}
+
+// WithGhost races a friend over link: the game streams the player's
+// position and draws the friend's as a ghost. Both sides need the same
+// seed for the ghost to climb the same world.
+func WithGhost(link *ghostnet.Link) Option {
+	race := &ghostRace{link: link}
+	return func(o *gameOptions) {
+		o.ghost = race
+	}
+}
+
+// sendGhost streams the player's pose every GhostSendEvery ticks, and
+// always once the run is over
+func (g *Game) sendGhost() {
+	race := g.opts.ghost
+	tick := int(math.Round(g.gameTime * float64(g.tps)))
+	if race == nil || g.replayView != nil || !g.gameOver && tick%g.ticks(GhostSendEvery) != 0 {
+		return
+	}
+	err := race.link.Send(ghostnet.Pose{
+		Seed:        g.seed,
+		Run:         race.runs,
+		Tick:        uint32(tick),
+		X:           float32(g.player.X),
+		Y:           float32(g.player.Y),
+		Altitude:    int32(g.altitude()),
+		FacingRight: g.player.FacingRight,
+		Over:        g.gameOver,
+		Name:        g.opts.profile,
+	})
+	if err != nil && !race.failed {
+		log.Printf("Ghost race: %v", err)
+		race.failed = true
+	}
+}
+
+// ghost is the friend's latest pose, false when they aren't racing this
+// world right now
+func (g *Game) ghost() (ghostnet.Pose, bool) {
+	if g.opts.ghost == nil || g.replayView != nil {
+		return ghostnet.Pose{}, false
+	}
+	p, ok := g.opts.ghost.link.Ghost()
+	return p, ok && p.Seed == g.seed
+}
+
+// drawGhost draws the friend translucent where they are in their run, or
+// an arrow at the screen edge toward them when they're out of view
+func (g *Game) drawGhost(screen *ebiten.Image) {
+	p, ok := g.ghost()
+	if !ok || p.Over {
+		return
+	}
+	x, y := float64(p.X), g.screenY(float64(p.Y))
+	clr := color.RGBA{180, 220, 255, 255}
+	switch {
+	case y < -PlayerHeight/2:
+		g.drawGhostArrow(screen, x, HUDMargin+20, -1, "+"+strconv.Itoa(int(p.Altitude)-g.altitude())+"m", clr)
+	case y > ScreenHeight+PlayerHeight/2:
+		g.drawGhostArrow(screen, x, ScreenHeight-HUDMargin-20, 1, strconv.Itoa(int(p.Altitude)-g.altitude())+"m", clr)
+	default:
+		op := &ebiten.DrawImageOptions{}
+		if !p.FacingRight {
+			op.GeoM.Scale(-1, 1)
+			op.GeoM.Translate(PlayerWidth, 0)
+		}
+		op.GeoM.Translate(x-PlayerWidth/2, y-PlayerHeight/2)
+		op.ColorM.Scale(0.7, 0.85, 1, GhostAlpha)
+		g.drawSprite(screen, g.sprites.Lookup(SpriteKey{Entity: EntityPlayer}), op) // Not the player's own pose: the friend's isn't sent
+		drawText(screen, p.Name, x-textWidth(p.Name, TextSmall)/2, y-PlayerHeight/2-textLineHeight, TextSmall, clr)
+	}
+}
+
+// drawGhostArrow points from the screen edge at y toward a ghost above
+// (dir -1) or below (dir 1), labeled with how far ahead or behind it is
+func (g *Game) drawGhostArrow(screen *ebiten.Image, x, y float64, dir int, label string, clr color.RGBA) {
+	const size = 5.0
+	x = max(size, min(ScreenWidth-size, x))
+	tip := y + float64(dir)*size
+	for i := 0.0; i < size; i++ {
+		w := (size - i) * 2
+		row := tip - float64(dir)*(size-i)
+		drawRect(screen, x-w/2, row, w, 1, clr)
+	}
+	drawText(screen, label, max(0, min(ScreenWidth-textWidth(label, TextSmall), x-textWidth(label, TextSmall)/2)), y-float64(dir)*textLineHeight, TextSmall, clr)
+}
+
+// ghostLines tell how the race against the ghost stands
+func ghostLines(g *Game) []hudLine {
+	if g.opts.ghost == nil || g.replayView != nil {
+		return nil
+	}
+	p, ok := g.opts.ghost.link.Ghost()
+	switch {
+	case !ok:
+		return []hudLine{{"Ghost: waiting for a friend", TextSmall}}
+	case p.Seed != g.seed:
+		return []hudLine{{"Ghost " + p.Name + " is on seed " + strconv.FormatInt(p.Seed, 10), TextSmall}}
+	case p.Over:
+		return []hudLine{{fmt.Sprintf("Ghost %s fell at %dm", p.Name, p.Altitude), TextSmall}}
+	}
+	return []hudLine{{fmt.Sprintf("Ghost %s: %dm", p.Name, p.Altitude), TextSmall}}
+}
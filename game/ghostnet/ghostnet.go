@@ -0,0 +1,179 @@
+// Package ghostnet streams a player's position to a friend racing the same
+// seed and receives theirs, over UDP. There is no state sync: each side
+// plays its own game and draws the other as a ghost, so a lost or late
+// packet only costs the ghost a frame.
+//
+// A packet is PacketSize bytes, little endian:
+//
+//	 0  magic     "GJG1"
+//	 4  seed      int64, of the sender's world
+//	12  run       uint16, counting the sender's runs
+//	14  tick      uint32, ticks into the run
+//	18  x, y      float32, world coordinates
+//	26  altitude  int32, meters
+//	30  flags     uint8: flagFacingRight, flagOver
+//	31  name      NameSize bytes, zero padded
+package ghostnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// Protocol parameters
+const (
+	NameSize   = 16
+	PacketSize = 31 + NameSize
+	StaleAfter = 3 * time.Second // A ghost not heard from for this long has left
+)
+
+// magic starts every packet, so stray datagrams are ignored
+var magic = [4]byte{'G', 'J', 'G', '1'}
+
+// Pose flags
+const (
+	flagFacingRight = 1 << iota
+	flagOver        // The run has ended
+)
+
+// Pose is where a player is in their run
+type Pose struct {
+	Seed        int64
+	Run         uint16
+	Tick        uint32
+	X, Y        float32
+	Altitude    int32
+	FacingRight bool
+	Over        bool
+	Name        string // Cut to NameSize bytes
+}
+
+// newer reports whether p comes after q from the same sender
+func (p Pose) newer(q Pose) bool {
+	if p.Run != q.Run {
+		return int16(p.Run-q.Run) > 0 // The run counter wraps
+	}
+	return p.Tick >= q.Tick
+}
+
+// MarshalBinary encodes p as a packet
+func (p Pose) MarshalBinary() ([]byte, error) {
+	b := make([]byte, PacketSize)
+	copy(b, magic[:])
+	le := binary.LittleEndian
+	le.PutUint64(b[4:], uint64(p.Seed))
+	le.PutUint16(b[12:], p.Run)
+	le.PutUint32(b[14:], p.Tick)
+	le.PutUint32(b[18:], math.Float32bits(p.X))
+	le.PutUint32(b[22:], math.Float32bits(p.Y))
+	le.PutUint32(b[26:], uint32(p.Altitude))
+	if p.FacingRight {
+		b[30] |= flagFacingRight
+	}
+	if p.Over {
+		b[30] |= flagOver
+	}
+	copy(b[31:], p.Name)
+	return b, nil
+}
+
+// errNotGhost rejects datagrams that aren't ghost packets
+var errNotGhost = errors.New("not a ghost packet")
+
+// UnmarshalBinary decodes a packet into p
+func (p *Pose) UnmarshalBinary(b []byte) error {
+	if len(b) != PacketSize || !bytes.Equal(b[:4], magic[:]) {
+		return errNotGhost
+	}
+	le := binary.LittleEndian
+	*p = Pose{
+		Seed:        int64(le.Uint64(b[4:])),
+		Run:         le.Uint16(b[12:]),
+		Tick:        le.Uint32(b[14:]),
+		X:           math.Float32frombits(le.Uint32(b[18:])),
+		Y:           math.Float32frombits(le.Uint32(b[22:])),
+		Altitude:    int32(le.Uint32(b[26:])),
+		FacingRight: b[30]&flagFacingRight != 0,
+		Over:        b[30]&flagOver != 0,
+		Name:        string(bytes.TrimRight(b[31:], "\x00")),
+	}
+	return nil
+}
+
+// Link is one end of a ghost race: it sends the local player's poses to
+// the peer and keeps the latest pose the peer sent
+type Link struct {
+	conn net.PacketConn
+	peer net.Addr
+
+	mu    sync.Mutex
+	ghost Pose
+	heard time.Time // When the ghost's pose arrived, zero before any did
+}
+
+// Dial listens on the local UDP address listen, such as ":7777", and
+// streams to the peer's address
+func Dial(listen, peer string) (*Link, error) {
+	addr, err := net.ResolveUDPAddr("udp", peer)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenPacket("udp", listen)
+	if err != nil {
+		return nil, err
+	}
+	l := &Link{conn: conn, peer: addr}
+	go l.receive()
+	return l, nil
+}
+
+// receive keeps the newest pose arriving until the link closes
+func (l *Link) receive() {
+	buf := make([]byte, PacketSize+1) // One byte over, so oversized datagrams fail to decode
+	for {
+		n, _, err := l.conn.ReadFrom(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		var p Pose
+		if err != nil || p.UnmarshalBinary(buf[:n]) != nil {
+			continue
+		}
+		l.mu.Lock()
+		if l.heard.IsZero() || p.newer(l.ghost) || time.Since(l.heard) > StaleAfter {
+			l.ghost, l.heard = p, time.Now()
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Send streams the local player's pose to the peer
+func (l *Link) Send(p Pose) error {
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = l.conn.WriteTo(b, l.peer)
+	return err
+}
+
+// Ghost returns the peer's latest pose, false when none arrived in the
+// last StaleAfter
+func (l *Link) Ghost() (Pose, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.heard.IsZero() || time.Since(l.heard) > StaleAfter {
+		return Pose{}, false
+	}
+	return l.ghost, true
+}
+
+// Close stops the link
+func (l *Link) Close() error {
+	return l.conn.Close()
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Grappling hook parameters
+const (
+	HookSpeed        = 8.0         // Throw speed
+	HookAngle        = math.Pi / 5 // Throw direction from straight up, toward the facing side
+	GrapplePullSpeed = 6.0         // Player speed while reeling in
+	GrappleCooldown  = 1.5         // Seconds between throws
+	GrappleReach     = 8.0         // Distance to the anchor that ends the pull
+)
+
+// Grapple states
+const (
+	GrappleIdle = iota
+	GrappleAiming
+	GrappleFlying
+	GrappleAnchored
+)
+
+// Grapple is the player's hook: aimed while the button is held, thrown on
+// release along a ballistic arc, and reeling the player in if it catches
+// a platform
+type Grapple struct {
+	State    int
+	X, Y     float64 // Hook position in world coordinates
+	VX, VY   float64
+	Ticks    int // Ticks the hook has been flying
+	Cooldown float64
+}
+
+// throwVelocity is the speed the hook leaves the player with
+func (g *Game) throwVelocity() (vx, vy float64) {
+	dir := 1.0
+	if !g.player.FacingRight {
+		dir = -1
+	}
+	return dir * math.Sin(HookAngle) * HookSpeed, -math.Cos(HookAngle) * HookSpeed
+}
+
+// hookHit returns the platform a hook at x, y is touching, if any
+func (g *Game) hookHit(x, y float64) *Platform {
+	for i := range g.platforms {
+		p := &g.platforms[i]
+		if !p.solid() {
+			continue
+		}
+		if x >= p.X && x <= p.X+PlatformWidth && y >= p.Y && y <= p.Y+PlatformHeight {
+			return p
+		}
+	}
+	return nil
+}
+
+// updateGrapple advances the hook; it runs before gravity so a reeling
+// player isn't pulled down
+func (g *Game) updateGrapple() {
+	h := &g.grapple
+	if h.Cooldown > 0 {
+		h.Cooldown -= g.dt
+	}
+	held := g.controller.Pressed(input.ActionGrapple)
+
+	switch h.State {
+	case GrappleIdle:
+		if held && h.Cooldown <= 0 && g.loadedCannon == nil {
+			h.State = GrappleAiming
+		}
+
+	case GrappleAiming:
+		if !held {
+			h.X, h.Y = g.player.X, g.player.Y
+			h.VX, h.VY = g.throwVelocity()
+			h.Ticks = 0
+			h.State = GrappleFlying
+		}
+
+	case GrappleFlying:
+		h.X, h.Y, h.VY = g.projectileStep(h.X, h.Y, h.VX, h.VY)
+		h.Ticks++
+		if p := g.hookHit(h.X, h.Y); p != nil {
+			h.Y = p.Y
+			h.State = GrappleAnchored
+			g.feedback(FeedbackLanding)
+		} else if h.Ticks > g.ticks(PredictTicks) || g.screenY(h.Y) > ScreenHeight {
+			h.State = GrappleIdle
+			h.Cooldown = GrappleCooldown
+		}
+
+	case GrappleAnchored:
+		dx, dy := h.X-g.player.X, h.Y-g.player.Y
+		dist := math.Hypot(dx, dy)
+		if dist <= GrappleReach || g.stuckToPlatform != nil {
+			// Pop up over the edge so the player can land on the platform
+			g.player.VelocityY = g.jumpVelocity * 0.6
+			h.State = GrappleIdle
+			h.Cooldown = GrappleCooldown
+			return
+		}
+		step := math.Min(GrapplePullSpeed*g.step, dist)
+		g.player.X += dx / dist * step
+		g.player.Y += dy / dist * step
+		g.player.VelocityY = -g.gravity * g.step // Cancels this tick's gravity
+		g.player.LaunchX = 0
+	}
+}
+
+// drawGrapple draws the aim preview, the flying hook and the rope
+func (g *Game) drawGrapple(screen *ebiten.Image) {
+	h := &g.grapple
+	py := g.screenY(g.player.Y)
+	switch h.State {
+	case GrappleAiming:
+		vx, vy := g.throwVelocity()
+		g.drawArc(screen, g.player.X, g.player.Y, vx, vy, color.RGBA{200, 255, 200, 220}, func(x, y, vy float64) bool {
+			return g.hookHit(x, y) != nil
+		})
+
+	case GrappleFlying, GrappleAnchored:
+		hy := g.screenY(h.Y)
+		drawLine(screen, g.player.X, py, h.X, hy, color.RGBA{120, 90, 60, 255})
+		drawCircle(screen, h.X, hy, 2.5, color.RGBA{180, 180, 190, 255})
+	}
+}
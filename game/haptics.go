@@ -0,0 +1,59 @@
+package game
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Haptics plays vibration feedback on whatever device supports it
+type Haptics interface {
+	// Pulse vibrates with strength in 0.0 - 1.0 for the given duration
+	Pulse(strength float64, duration time.Duration)
+}
+
+// gamepadHaptics rumbles every connected gamepad that supports vibration
+type gamepadHaptics struct {
+	ids []ebiten.GamepadID
+}
+
+func (h *gamepadHaptics) Pulse(strength float64, duration time.Duration) {
+	h.ids = ebiten.AppendGamepadIDs(h.ids[:0])
+	for _, id := range h.ids {
+		ebiten.VibrateGamepad(id, &ebiten.VibrateGamepadOptions{
+			Duration:        duration,
+			StrongMagnitude: strength,
+			WeakMagnitude:   strength * 0.5,
+		})
+	}
+}
+
+// mobileHaptics uses the phone's vibration motor (Android, iOS and browsers)
+type mobileHaptics struct{}
+
+func (mobileHaptics) Pulse(strength float64, duration time.Duration) {
+	ebiten.Vibrate(&ebiten.VibrateOptions{
+		Duration:  duration,
+		Magnitude: strength,
+	})
+}
+
+// multiHaptics fans a pulse out to several backends
+type multiHaptics []Haptics
+
+func (m multiHaptics) Pulse(strength float64, duration time.Duration) {
+	for _, h := range m {
+		h.Pulse(strength, duration)
+	}
+}
+
+// newHaptics picks the backends available on this platform
+func newHaptics() Haptics {
+	backends := multiHaptics{&gamepadHaptics{}}
+	switch runtime.GOOS {
+	case "android", "ios", "js":
+		backends = append(backends, mobileHaptics{})
+	}
+	return backends
+}
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Heatmap parameters
+const (
+	HeatColumns           = 16  // Horizontal cells across the screen
+	HeatBandMeters        = 50  // Altitude covered by one row
+	MaxHeatBands          = 200 // Rows kept; higher samples land in the top row
+	MaxDeathRecords       = 500 // Oldest deaths are dropped past this many
+	TrajectorySampleTicks = 15  // Frames between trajectory samples
+)
+
+// DeathCause records how a run ended
+type DeathCause int
+
+const (
+	DeathBird      DeathCause = iota // Hit by a bird without a shield
+	DeathFall                        // Fell off the bottom of the screen
+	DeathEdge                        // Touched a deadly screen edge
+	DeathLightning                   // Struck during a thunderstorm
+	DeathCold                        // Ran out of warmth in Hardcore
+	DeathTime                        // Ran out of time in a kiosk
+	deathCauseCount
+)
+
+var deathCauseNames = [deathCauseCount]string{
+	DeathBird:      "Birds",
+	DeathFall:      "Falls",
+	DeathEdge:      "Edges",
+	DeathLightning: "Lightning",
+	DeathCold:      "Cold",
+	DeathTime:      "Time",
+}
+
+func (c DeathCause) String() string {
+	if c < 0 || c >= deathCauseCount {
+		return "Unknown"
+	}
+	return deathCauseNames[c]
+}
+
+// deathColors tells causes apart on the heatmap
+var deathColors = [deathCauseCount]color.RGBA{
+	DeathBird:      {255, 80, 80, 255},
+	DeathFall:      {255, 180, 60, 255},
+	DeathEdge:      {200, 120, 255, 255},
+	DeathLightning: {255, 255, 120, 255},
+	DeathCold:      {120, 200, 255, 255},
+	DeathTime:      {200, 200, 200, 255},
+}
+
+// DeathRecord is where and how one run ended
+type DeathRecord struct {
+	Cause    DeathCause `json:"cause"`
+	X        float64    `json:"x"`
+	Altitude float64    `json:"altitude"` // Meters
+}
+
+// Heatmap aggregates trajectories and deaths over every run of a profile
+type Heatmap struct {
+	Visits [][HeatColumns]int `json:"visits"` // Trajectory samples by altitude band and column
+	Deaths []DeathRecord      `json:"deaths"`
+}
+
+// heatSample is a point of the current run's trajectory
+type heatSample struct {
+	x, altitude float64
+}
+
+// playerAltitude is the player's height above the start, in meters
+func (g *Game) playerAltitude() float64 {
+	return math.Max(0, (ScreenHeight-g.player.Y)/PixelsPerMeter)
+}
+
+// sampleTrajectory records the player's position every few ticks. On low
+// memory a full trace drops every other sample and the rest of the run is
+// sampled half as often, so it covers the run at less detail.
+func (g *Game) sampleTrajectory() {
+	g.traceTicks++
+	if g.traceTicks%(g.ticks(TrajectorySampleTicks)<<g.traceThinned) != 0 {
+		return
+	}
+	g.trace = append(g.trace, heatSample{g.player.X, g.playerAltitude()})
+	if g.opts.lowMemory && len(g.trace) >= LowMemoryTraceSamples {
+		for i := range len(g.trace) / 2 {
+			g.trace[i] = g.trace[2*i+1]
+		}
+		g.trace = g.trace[:len(g.trace)/2]
+		g.traceThinned++
+	}
+}
+
+// heatCell maps a position to its heatmap cell
+func heatCell(x, altitude float64) (band, col int) {
+	col = int(x / ScreenWidth * HeatColumns)
+	col = max(0, min(HeatColumns-1, col))
+	band = min(MaxHeatBands-1, int(altitude/HeatBandMeters))
+	return max(0, band), col
+}
+
+// addRun merges a finished run into the aggregate
+func (h *Heatmap) addRun(trace []heatSample, death DeathRecord) {
+	for _, s := range trace {
+		band, col := heatCell(s.x, s.altitude)
+		for len(h.Visits) <= band {
+			h.Visits = append(h.Visits, [HeatColumns]int{})
+		}
+		h.Visits[band][col]++
+	}
+	h.Deaths = append(h.Deaths, death)
+	if len(h.Deaths) > MaxDeathRecords {
+		h.Deaths = h.Deaths[len(h.Deaths)-MaxDeathRecords:]
+	}
+}
+
+// heatColor ramps from transparent blue through yellow to red
+func heatColor(t float64) color.RGBA {
+	switch {
+	case t <= 0:
+		return color.RGBA{}
+	case t < 0.5:
+		u := t * 2
+		return color.RGBA{uint8(255 * u), uint8(255 * u), uint8(255 * (1 - u)), uint8(80 + 100*u)}
+	default:
+		u := (t - 0.5) * 2
+		return color.RGBA{255, uint8(255 * (1 - u)), 0, 200}
+	}
+}
+
+// drawStats renders the heatmap viewer over the game-over screen
+func (g *Game) drawStats(screen *ebiten.Image) {
+	h := &g.save.Heatmap
+	drawRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{10, 10, 20, 230})
+
+	const top, bottom = 40.0, ScreenHeight - 70.0
+	bands := max(len(h.Visits), 1)
+	for _, d := range h.Deaths {
+		band, _ := heatCell(d.X, d.Altitude)
+		bands = max(bands, band+1)
+	}
+	cellW := float64(ScreenWidth) / HeatColumns
+	cellH := (bottom - top) / float64(bands)
+
+	// Visits use a log scale so a few busy cells don't wash out the rest
+	peak := 1
+	for _, row := range h.Visits {
+		for _, v := range row {
+			peak = max(peak, v)
+		}
+	}
+	for band, row := range h.Visits {
+		y := bottom - float64(band+1)*cellH
+		for col, v := range row {
+			if v == 0 {
+				continue
+			}
+			t := math.Log1p(float64(v)) / math.Log1p(float64(peak))
+			drawRect(screen, float64(col)*cellW, y, cellW, cellH, heatColor(t))
+		}
+	}
+
+	// Deaths as dots, colored by cause
+	var byBand = map[int]int{}
+	for _, d := range h.Deaths {
+		band, _ := heatCell(d.X, d.Altitude)
+		byBand[band]++
+		y := bottom - d.Altitude/HeatBandMeters*cellH
+		drawCircle(screen, d.X, y, 2, deathColors[d.Cause])
+	}
+
+	drawText(screen, "Where runs go and end", 5, 8, TextSmall, textColor)
+	drawText(screen, fmt.Sprintf("Top: %dm", bands*HeatBandMeters), 5, 24, TextSmall, textColor)
+	if c, ok := g.save.deadliest(); ok {
+		killer := "Top killer: " + c.String()
+		drawText(screen, killer, ScreenWidth-5-textWidth(killer, TextSmall), 24, TextSmall, textColor)
+	}
+
+	y := bottom + 8
+	for c := DeathCause(0); c < deathCauseCount; c++ {
+		drawCircle(screen, 9, y+4, 3, deathColors[c])
+		drawText(screen, fmt.Sprintf("%s: %d", c, g.save.DeathCounts[c]), 18, y, TextSmall, textColor)
+		y += textLineHeight
+	}
+
+	worst, worstCount := 0, 0
+	for band, n := range byBand {
+		if n > worstCount || (n == worstCount && band < worst) {
+			worst, worstCount = band, n
+		}
+	}
+	if worstCount > 0 {
+		drawText(screen, fmt.Sprintf("Deadliest: %d-%dm", worst*HeatBandMeters, (worst+1)*HeatBandMeters), 5, y, TextSmall, textColor)
+	}
+	drawTextCentered(screen, g.actionHint(input.ActionStats, "back"), ScreenHeight-14, TextSmall, textColor)
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"image"
+	"os"
+
+	"doodlejump/game/physics"
+)
+
+// Collision mask parameters
+const (
+	MaskAlphaThreshold = 128 // Pixels at least this opaque count as solid
+	MaskEdgeCoverage   = 0.5 // Edge rows and columns less solid than this are trimmed
+)
+
+// spriteHitbox derives a collision box from a sprite's alpha, relative to
+// its top-left corner. Starting from the opaque bounds, each edge is pulled
+// in while its row or column is mostly empty, so wing tips and the empty
+// corners around them don't count as hits. The result never exceeds the
+// w by h box the sprite is nominally drawn in.
+func spriteHitbox(img image.Image, w, h int) physics.Rect {
+	b := img.Bounds()
+	solid := func(x, y int) bool {
+		_, _, _, a := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+		return a>>8 >= MaskAlphaThreshold
+	}
+	w, h = min(w, b.Dx()), min(h, b.Dy())
+
+	// coverage is the solid fraction of a row (or column) between lo and hi
+	coverage := func(fixed, lo, hi int, row bool) float64 {
+		if hi <= lo {
+			return 0
+		}
+		n := 0
+		for i := lo; i < hi; i++ {
+			if row && solid(i, fixed) || !row && solid(fixed, i) {
+				n++
+			}
+		}
+		return float64(n) / float64(hi-lo)
+	}
+
+	x0, y0, x1, y1 := 0, 0, w, h
+	for changed := true; changed && x0 < x1 && y0 < y1; {
+		changed = false
+		if coverage(y0, x0, x1, true) < MaskEdgeCoverage {
+			y0, changed = y0+1, true
+		}
+		if y0 < y1 && coverage(y1-1, x0, x1, true) < MaskEdgeCoverage {
+			y1, changed = y1-1, true
+		}
+		if coverage(x0, y0, y1, false) < MaskEdgeCoverage {
+			x0, changed = x0+1, true
+		}
+		if x0 < x1 && coverage(x1-1, y0, y1, false) < MaskEdgeCoverage {
+			x1, changed = x1-1, true
+		}
+	}
+	if x0 >= x1 || y0 >= y1 {
+		// Nothing solid enough; keep the full box rather than making birds harmless
+		return physics.Rect{W: float64(w), H: float64(h)}
+	}
+	return physics.Rect{X: float64(x0), Y: float64(y0), W: float64(x1 - x0), H: float64(y1 - y0)}
+}
+
+// birdSprite decodes the bird sprite the level uses for name, falling back
+// to the embedded one. It runs headless too, so simulations get the same
+// hitboxes as the game.
+func (g *Game) birdSprite(name string) image.Image {
+	if g.level.ThemeData != nil {
+		if file := g.level.ThemeData.SpritePath(name); file != "" {
+			if f, err := os.Open(file); err == nil {
+				img, _, err := image.Decode(f)
+				f.Close()
+				if err == nil {
+					return img
+				}
+			}
+		}
+	}
+	path := "assets/" + name + ".png"
+	img, err := decodeAsset(path)
+	if err != nil {
+		return placeholderImage(path)
+	}
+	return img
+}
+
+// loadBirdHitboxes computes the collision boxes of the bird sprites,
+// flying and perched
+func (g *Game) loadBirdHitboxes() {
+	g.birdBoxes[0] = spriteHitbox(g.birdSprite("bird_left"), BirdWidth, BirdHeight)
+	g.birdBoxes[1] = spriteHitbox(g.birdSprite("bird_right"), BirdWidth, BirdHeight)
+	g.perchBoxes[0] = spriteHitbox(g.birdSprite("bird_perched_left"), BirdWidth, BirdHeight)
+	g.perchBoxes[1] = spriteHitbox(g.birdSprite("bird_perched_right"), BirdWidth, BirdHeight)
+}
+
+// birdRect is bird b's collision box in world space
+func (g *Game) birdRect(b *Bird) physics.Rect {
+	boxes := &g.birdBoxes
+	if b.Perched > 0 {
+		boxes = &g.perchBoxes
+	}
+	box := boxes[0]
+	if b.Direction > 0 {
+		box = boxes[1]
+	}
+	return physics.Rect{X: b.X + box.X, Y: b.Y + box.Y, W: box.W, H: box.H}
+}
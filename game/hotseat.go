@@ -0,0 +1,198 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Hot-seat size limits
+const (
+	MinHotseatPlayers = 2
+	MaxHotseatPlayers = 8
+)
+
+// HotseatPlayer is one seat of a pass-and-play session and its handicap
+type HotseatPlayer struct {
+	Name       string
+	Multiplier float64 // Applied to the score of every run, 1 = no handicap
+	Shields    int     // Bird or lightning hits each run survives
+	Runs       []int   // Handicapped scores, one per turn taken
+}
+
+// Total is the player's handicapped score over the session
+func (p *HotseatPlayer) Total() int {
+	total := 0
+	for _, s := range p.Runs {
+		total += s
+	}
+	return total
+}
+
+// Hotseat is a pass-and-play session: players take turns in order, and
+// everyone of a round plays the same seed
+type Hotseat struct {
+	Seed    int64 // Seed of the first round; round r plays Seed+r
+	Players []HotseatPlayer
+	turn    int // Runs played so far
+}
+
+// ParseHotseat reads a comma-separated list of players. Each name may be
+// followed by *multiplier and +shields, e.g. "Ann,Bob*1.5,Cid+2,Dee*1.2+1".
+func ParseHotseat(spec string, seed int64) (*Hotseat, error) {
+	var players []HotseatPlayer
+	for _, field := range strings.Split(spec, ",") {
+		p := HotseatPlayer{Multiplier: 1}
+		field = strings.TrimSpace(field)
+		if i := strings.LastIndex(field, "+"); i >= 0 {
+			n, err := strconv.Atoi(field[i+1:])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("hot-seat player %q: bad shield count", field)
+			}
+			p.Shields, field = n, field[:i]
+		}
+		if i := strings.LastIndex(field, "*"); i >= 0 {
+			m, err := strconv.ParseFloat(field[i+1:], 64)
+			if err != nil || m <= 0 {
+				return nil, fmt.Errorf("hot-seat player %q: bad score multiplier", field)
+			}
+			p.Multiplier, field = m, field[:i]
+		}
+		if p.Name = strings.TrimSpace(field); p.Name == "" {
+			return nil, errors.New("hot-seat player names must not be empty")
+		}
+		players = append(players, p)
+	}
+	if len(players) < MinHotseatPlayers || len(players) > MaxHotseatPlayers {
+		return nil, fmt.Errorf("hot-seat needs %d to %d players, got %d", MinHotseatPlayers, MaxHotseatPlayers, len(players))
+	}
+	return &Hotseat{Seed: seed, Players: players}, nil
+}
+
+// Current is the player whose turn it is
+func (h *Hotseat) Current() *HotseatPlayer {
+	return &h.Players[h.turn%len(h.Players)]
+}
+
+// Round counts the rounds started so far, from 0
+func (h *Hotseat) Round() int {
+	return h.turn / len(h.Players)
+}
+
+// RoundSeed is the seed everyone of the current round plays
+func (h *Hotseat) RoundSeed() int64 {
+	return h.Seed + int64(h.Round())
+}
+
+// Record books a run's score for the current player, handicap applied,
+// and passes the turn on
+func (h *Hotseat) Record(score int) {
+	p := h.Current()
+	p.Runs = append(p.Runs, h.handicapped(p, score))
+	h.turn++
+}
+
+// handicapped is score with p's multiplier applied
+func (h *Hotseat) handicapped(p *HotseatPlayer, score int) int {
+	return int(math.Round(float64(score) * p.Multiplier))
+}
+
+// standings orders the players by total, best first
+func (h *Hotseat) standings() []*HotseatPlayer {
+	ranked := make([]*HotseatPlayer, len(h.Players))
+	for i := range h.Players {
+		ranked[i] = &h.Players[i]
+	}
+	slices.SortStableFunc(ranked, func(a, b *HotseatPlayer) int { return b.Total() - a.Total() })
+	return ranked
+}
+
+// handicapText describes p's handicap, "" without one
+func handicapText(p *HotseatPlayer) string {
+	var parts []string
+	if p.Multiplier != 1 {
+		parts = append(parts, "x"+strconv.FormatFloat(p.Multiplier, 'f', -1, 64))
+	}
+	if p.Shields == 1 {
+		parts = append(parts, "1 shield")
+	} else if p.Shields > 1 {
+		parts = append(parts, strconv.Itoa(p.Shields)+" shields")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// useShield spends one of the run's handicap shields on a hit that would
+// otherwise end it, with a moment of grace to get clear
+func (g *Game) useShield(cause DeathCause) bool {
+	if g.shields == 0 || (cause != DeathBird && cause != DeathLightning) {
+		return false
+	}
+	g.shields--
+	g.timers.Add(TimerInvincible, ShieldGrace, nil)
+	g.feedback(FeedbackShieldHit)
+	return true
+}
+
+// hotseatLines names the player whose turn it is and their handicapped score
+func hotseatLines(g *Game) []hudLine {
+	h := g.opts.hotseat
+	if h == nil || g.gameOver {
+		return nil
+	}
+	p := h.Current()
+	lines := []hudLine{{"Turn: " + p.Name, TextSmall}}
+	if p.Multiplier != 1 {
+		lines = append(lines, hudLine{"Handicapped: " + strconv.Itoa(h.handicapped(p, g.score)), TextSmall})
+	}
+	if g.shields > 0 {
+		lines = append(lines, hudLine{"Shields: " + strconv.Itoa(g.shields), TextSmall})
+	}
+	return lines
+}
+
+// sceneScoreboard shows the session totals between hot-seat turns
+type sceneScoreboard struct{}
+
+func (sceneScoreboard) Update(g *Game) error {
+	if g.audio != nil {
+		g.audio.Update(false)
+	}
+	if g.controller.JustPressed(input.ActionRestart) && g.canStartRun() {
+		g.restart()
+	}
+	return nil
+}
+
+func (sceneScoreboard) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	h := g.opts.hotseat
+	drawTextCentered(screen, "Scoreboard", 40, TextLarge, textColor)
+	drawTextCentered(screen, fmt.Sprintf("Round %d", h.Round()+1), 60, TextSmall, textColor)
+
+	highlight := color.RGBA{255, 220, 100, 255}
+	next := h.Current()
+	y := 90.0
+	for rank, p := range h.standings() {
+		clr := textColor
+		if p == next {
+			clr = highlight
+		}
+		drawText(screen, fmt.Sprintf("%d. %s", rank+1, p.Name), 20, y, TextSmall, clr)
+		drawText(screen, fmt.Sprintf("%d (%d runs)", p.Total(), len(p.Runs)), 180, y, TextSmall, clr)
+		if hc := handicapText(p); hc != "" {
+			drawText(screen, "   "+hc, 20, y+textLineHeight, TextSmall, textColor)
+		}
+		y += 2.5 * textLineHeight
+	}
+
+	drawTextCentered(screen, "Next up: "+next.Name, ScreenHeight-60, TextSmall, highlight)
+	drawTextCentered(screen, "Pass the controls and press SPACE", ScreenHeight-40, TextSmall, textColor)
+}
@@ -0,0 +1,372 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// HUD layout constants
+const (
+	HUDMargin       = 5    // Distance of anchored panels from the screen edge
+	HintsVisibleFor = 30.0 // Seconds before control hints auto-hide
+	HUDFadeAlpha    = 0.3  // Opacity of a panel the player is behind
+	HUDFadeDistance = 10.0 // Extra margin around a panel that counts as "near"
+	HUDFadeSpeed    = 4.0  // Opacity change per second when fading
+	hudLineSpacing  = 4    // Gap between stacked lines
+	HUDBarWidth     = 60   // Length of a meter drawn after a line's label
+	hudBarGap       = 4    // Space between a label and its meter
+)
+
+// hudAnchor selects which part of the screen a panel is laid out against
+type hudAnchor int
+
+const (
+	anchorTopLeft hudAnchor = iota
+	anchorTopRight
+	anchorBottomLeft
+	anchorCenter
+	anchorTopCenter
+	anchorMiddleLeft
+	anchorMiddleRight
+	anchorBottomRight
+)
+
+// hudLine is a single line of HUD text
+type hudLine struct {
+	text string
+	size float64
+}
+
+// hudBar is a horizontal meter shown after a line's text
+type hudBar struct {
+	fill  float64 // 0 to 1
+	color color.RGBA
+}
+
+// draw draws the meter at x, y, size pixels tall
+func (b *hudBar) draw(screen *ebiten.Image, x, y, size, alpha float64) {
+	scale := func(c color.RGBA) color.RGBA {
+		return color.RGBA{uint8(float64(c.R) * alpha), uint8(float64(c.G) * alpha), uint8(float64(c.B) * alpha), uint8(float64(c.A) * alpha)}
+	}
+	drawRect(screen, x, y, HUDBarWidth, size, scale(textOutline))
+	fill := max(0, min(1, b.fill))
+	drawRect(screen, x+1, y+1, (HUDBarWidth-2)*fill, size-2, scale(b.color))
+}
+
+// hudPanel is a block of text lines anchored to the screen
+type hudPanel struct {
+	id        string
+	anchor    hudAnchor
+	hint      bool // Control hints; hidden after HintsVisibleFor when enabled
+	sensitive bool // Seed, profile name... hidden in streamer mode
+	lines     func(g *Game) []hudLine
+	fade      func(g *Game, i int) float64 // Optional per-line opacity
+	bar       func(g *Game, i int) *hudBar // Optional meter after a line
+	below     string                       // Stack under the panel with this id instead of using anchor
+
+	// Layout state, recomputed every frame
+	x, y, w, h float64
+	alpha      float64
+}
+
+// HUD lays out and draws every screen-space text panel
+type HUD struct {
+	panels    []*hudPanel
+	showTally bool // Live score breakdown, toggled by the player
+	showStats bool // Death heatmap over the game-over screen
+}
+
+// newHUD builds the default panel layout
+func newHUD() *HUD {
+	return &HUD{
+		panels: []*hudPanel{
+			{id: "status", anchor: anchorTopLeft, lines: statusLines, alpha: 1},
+			{id: "hints", anchor: anchorBottomLeft, hint: true, lines: hintLines, alpha: 1},
+			{id: "tally", anchor: anchorTopRight, lines: tallyLines, alpha: 1},
+			{id: "warmth", below: "status", lines: warmthLines, bar: warmthBar, alpha: 1},
+			{id: "tournament", below: "warmth", lines: tournamentLines, alpha: 1},
+			{id: "hotseat", below: "tournament", lines: hotseatLines, alpha: 1},
+			{id: "daily", below: "hotseat", lines: dailyLines, alpha: 1},
+			{id: "limit", below: "daily", lines: limitLines, alpha: 1},
+			{id: "session", below: "limit", lines: sessionLines, alpha: 1},
+			{id: "ghost", below: "session", lines: ghostLines, alpha: 1},
+			{id: "combo", below: "ghost", lines: comboLines, alpha: 1},
+			{id: "feed", anchor: anchorBottomRight, lines: feedLines, fade: feedFade, alpha: 1},
+			{id: "gameover", anchor: anchorCenter, lines: gameOverLines, alpha: 1},
+			{id: "seed", anchor: anchorCenter, below: "gameover", sensitive: true, lines: seedLines, alpha: 1},
+			{id: "announcer", anchor: anchorTopCenter, lines: announcerLines, alpha: 1},
+			{id: "captions_left", anchor: anchorMiddleLeft, lines: func(g *Game) []hudLine { return captionLines(g.captions.left) }, alpha: 1},
+			{id: "captions_right", anchor: anchorMiddleRight, lines: func(g *Game) []hudLine { return captionLines(g.captions.right) }, alpha: 1},
+		},
+	}
+}
+
+// panel returns the panel registered under id, or nil
+func (h *HUD) panel(id string) *hudPanel {
+	for _, p := range h.panels {
+		if p.id == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// lineWidth is the width of line i of p including its meter
+func (p *hudPanel) lineWidth(g *Game, i int, l hudLine) float64 {
+	w := textWidth(l.text, l.size)
+	if p.bar != nil && p.bar(g, i) != nil {
+		w += hudBarGap + HUDBarWidth
+	}
+	return w
+}
+
+// layout measures every panel and positions it against its anchor
+func (h *HUD) layout(g *Game) [][]hudLine {
+	all := make([][]hudLine, len(h.panels))
+	for i, p := range h.panels {
+		lines := p.lines(g)
+		all[i] = lines
+
+		p.w, p.h = 0, 0
+		for j, l := range lines {
+			if w := p.lineWidth(g, j, l); w > p.w {
+				p.w = w
+			}
+			p.h += l.size + hudLineSpacing
+		}
+
+		switch p.anchor {
+		case anchorTopLeft:
+			p.x, p.y = HUDMargin, HUDMargin
+		case anchorTopRight:
+			p.x, p.y = ScreenWidth-HUDMargin-p.w, HUDMargin
+		case anchorBottomLeft:
+			p.x, p.y = HUDMargin, ScreenHeight-HUDMargin-p.h
+		case anchorCenter:
+			p.x, p.y = (ScreenWidth-p.w)/2, (ScreenHeight-p.h)/2
+		case anchorTopCenter:
+			p.x, p.y = (ScreenWidth-p.w)/2, ScreenHeight/4
+		case anchorMiddleLeft:
+			p.x, p.y = HUDMargin, ScreenHeight*0.6
+		case anchorMiddleRight:
+			p.x, p.y = ScreenWidth-HUDMargin-p.w, ScreenHeight*0.6
+		case anchorBottomRight:
+			p.x, p.y = ScreenWidth-HUDMargin-p.w, ScreenHeight-HUDMargin-p.h
+		}
+		if p.below != "" {
+			if o := h.panel(p.below); o != nil {
+				p.x, p.y = o.x, o.y+o.h
+			}
+		}
+	}
+	return all
+}
+
+// targetAlpha decides how visible a panel should be this frame
+func (h *HUD) targetAlpha(g *Game, p *hudPanel) float64 {
+	settings := g.save.Settings
+	if p.sensitive && settings.StreamerMode {
+		return 0
+	}
+	if p.hint && settings.AutoHideHints && g.gameTime > HintsVisibleFor {
+		return 0
+	}
+	if settings.FadeHUDNearPlay && p.anchor != anchorCenter {
+		px, py := g.player.X-PlayerWidth/2, g.screenY(g.player.Y)-PlayerHeight/2
+		if px+PlayerWidth >= p.x-HUDFadeDistance && px <= p.x+p.w+HUDFadeDistance &&
+			py+PlayerHeight >= p.y-HUDFadeDistance && py <= p.y+p.h+HUDFadeDistance {
+			return HUDFadeAlpha
+		}
+	}
+	return 1
+}
+
+// Update eases every panel toward its target opacity
+func (h *HUD) Update(g *Game) {
+	h.layout(g)
+	step := HUDFadeSpeed * g.dt
+	for _, p := range h.panels {
+		target := h.targetAlpha(g, p)
+		if p.alpha < target {
+			p.alpha = min(target, p.alpha+step)
+		} else if p.alpha > target {
+			p.alpha = max(target, p.alpha-step)
+		}
+	}
+}
+
+// Draw renders all visible panels
+func (h *HUD) Draw(screen *ebiten.Image, g *Game) {
+	all := h.layout(g)
+	for i, p := range h.panels {
+		// Streamer mode hides instantly, never through a fade
+		if p.sensitive && g.save.Settings.StreamerMode {
+			continue
+		}
+		y := p.y
+		for j, l := range all[i] {
+			alpha := p.alpha
+			if p.fade != nil {
+				alpha *= p.fade(g, j)
+			}
+			x := p.x
+			switch p.anchor {
+			case anchorCenter, anchorTopCenter:
+				x = (ScreenWidth - p.lineWidth(g, j, l)) / 2
+			case anchorTopRight, anchorMiddleRight:
+				x = p.x + p.w - p.lineWidth(g, j, l) // Right-align
+			}
+			drawTextAlpha(screen, l.text, x, y, l.size, textColor, alpha)
+			if p.bar != nil {
+				if b := p.bar(g, j); b != nil {
+					b.draw(screen, x+textWidth(l.text, l.size)+hudBarGap, y, l.size, alpha)
+				}
+			}
+			y += l.size + hudLineSpacing
+		}
+	}
+}
+
+// statusLines shows score, time/weather, boosts and difficulty
+func statusLines(g *Game) []hudLine {
+	lines := []hudLine{{g.scoreLine(), TextLarge}}
+
+	// Display current weather
+	var weatherText string
+	switch g.weather {
+	case WeatherClear:
+		weatherText = "Clear"
+	case WeatherRain:
+		weatherText = "Rainy"
+	case WeatherSnow:
+		weatherText = "Snowy"
+	case WeatherStorm:
+		weatherText = "Stormy"
+	}
+
+	// Display time mode
+	timeText := "Day"
+	if g.nightMode {
+		timeText = "Night"
+	}
+	lines = append(lines, hudLine{timeText + " / " + weatherText, TextSmall})
+
+	// Display active boost
+	var boostText string
+	switch g.player.BoostType {
+	case BoostNone:
+		boostText = "No Boost"
+	case BoostSpeed:
+		boostText = fmt.Sprintf("Speed Boost: %.1f", g.timers.Remaining(TimerBoost))
+	case BoostJump:
+		boostText = fmt.Sprintf("Jump Boost: %.1f", g.timers.Remaining(TimerBoost))
+	case BoostShield:
+		boostText = fmt.Sprintf("Shield Boost: %.1f", g.timers.Remaining(TimerBoost))
+	case BoostMultiplier:
+		boostText = fmt.Sprintf("Score x%d: %.1f", MultiplierBoost, g.timers.Remaining(TimerBoost))
+	}
+	lines = append(lines, hudLine{boostText, TextSmall})
+
+	// Display if flying is active
+	if g.canFly() {
+		lines = append(lines, hudLine{fmt.Sprintf("Flying: %.1f", g.timers.Remaining(TimerFly)), TextSmall})
+	}
+
+	lines = append(lines, hudLine{fmt.Sprintf("Difficulty: %d (Birds: %d)", g.difficulty, len(g.birds)), TextSmall})
+	return lines
+}
+
+// hintLines lists the controls at the bottom of the screen
+func hintLines(g *Game) []hudLine {
+	if g.opts.race != nil {
+		return []hudLine{{raceSeats[g.opts.seat].hint, TextSmall}}
+	}
+	move := g.moveHint("Move")
+	if g.hintBindings().MouseSteer {
+		move = "Mouse: Move"
+	}
+	return []hudLine{
+		{joinHints("/", g.actionKey(input.ActionJump), g.actionKey(input.ActionShoot)) + ": leave sticky", TextSmall},
+		{joinHints(" ", move, g.actionHint(input.ActionFly, "Fly"), g.actionHint(input.ActionShoot, "Shoot")), TextSmall},
+		{joinHints(" ", g.actionHint(input.ActionWeather, "Weather"), g.actionHint(input.ActionGrapple, "Grapple"), g.actionHint(input.ActionTally, "Tally")), TextSmall},
+	}
+}
+
+// bookmarkLine offers to bookmark the run's seed, or confirms it was
+func bookmarkLine(g *Game) hudLine {
+	if g.save.hasBookmark(g.seed) {
+		return hudLine{"Seed bookmarked", TextSmall}
+	}
+	return hudLine{g.actionHint(input.ActionBookmarks, "Bookmark seed"), TextSmall}
+}
+
+// seedLines show a finished run's seed and how to race a friend on it
+func seedLines(g *Game) []hudLine {
+	if !g.gameOver || g.replayView != nil {
+		return nil
+	}
+	seed := strconv.FormatInt(g.seed, 10)
+	return []hudLine{
+		{"Seed: " + seed, TextSmall},
+		{"Race it: godlejump -seed " + seed, TextSmall},
+	}
+}
+
+// limitLines count down the time left in a timed run
+func limitLines(g *Game) []hudLine {
+	t := g.timers.Get(TimerLimit)
+	if t == nil || g.opts.idle || g.gameOver {
+		return nil
+	}
+	left := int(math.Ceil(t.Remaining))
+	return []hudLine{{fmt.Sprintf("Time: %d:%02d", left/60, left%60), TextSmall}}
+}
+
+// gameOverLines shows the end-of-run message
+func gameOverLines(g *Game) []hudLine {
+	if !g.gameOver || g.opts.race != nil {
+		return nil // The race announces its own result
+	}
+	lines := []hudLine{
+		{g.deathCause.title(), TextLarge},
+		{g.scoreLine(), TextSmall},
+	}
+	if g.replayView != nil {
+		return lines // The replay scene has its own controls
+	}
+	lines = append(lines, breakdownLines(g)...)
+	lines = append(lines, coinLines(g)...)
+	for _, line := range g.tips.lines() {
+		lines = append(lines, hudLine{line, TextSmall})
+	}
+	if g.opts.kiosk != nil {
+		return append(lines, kioskOverLines(g)...) // Only credits get another run
+	}
+	press := "Press " + g.actionKey(input.ActionRestart)
+	restart := press + " to restart"
+	switch g.turnScene() {
+	case standingsScene:
+		restart = press + " for the standings"
+	case scoreboardScene:
+		restart = press + " for the scoreboard"
+	}
+	if !g.canStartRun() {
+		restart = "Play time is up. See you next time!" // Nothing past the run answers then
+	}
+	lines = append(lines,
+		hudLine{"Best: " + strconv.Itoa(g.save.best(g.mode)), TextSmall},
+		hudLine{restart, TextSmall},
+		hudLine{g.actionHint(input.ActionStats, "Heatmap"), TextSmall},
+		bookmarkLine(g),
+	)
+	if g.lastReplay != nil {
+		lines = append(lines, hudLine{g.actionHint(input.ActionReplay, "Watch replay"), TextSmall})
+	}
+	return append(lines, prestigeLines(g)...)
+}
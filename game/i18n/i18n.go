@@ -0,0 +1,85 @@
+// Package i18n looks up player-facing strings in embedded per-language
+// catalogs.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLanguage is used for keys missing from the active catalog
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	catalogs = map[string]map[string]string{}
+	active   = DefaultLanguage
+)
+
+func init() {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		log.Printf("Failed to list locales: %v", err)
+		return
+	}
+	for _, e := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			log.Printf("Failed to read locale %s: %v", e.Name(), err)
+			continue
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			log.Printf("Failed to parse locale %s: %v", e.Name(), err)
+			continue
+		}
+		catalogs[strings.TrimSuffix(e.Name(), ".json")] = catalog
+	}
+}
+
+// Languages lists the available language codes
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// SetLanguage switches the active catalog; unknown codes fall back to
+// DefaultLanguage
+func SetLanguage(lang string) {
+	if _, ok := catalogs[lang]; !ok {
+		lang = DefaultLanguage
+	}
+	active = lang
+}
+
+// Language reports the active language code
+func Language() string {
+	return active
+}
+
+// T returns the translation of key formatted with args. Missing keys fall
+// back to the default language and finally to the key itself.
+func T(key string, args ...any) string {
+	msg, ok := catalogs[active][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Idle mode parameters
+const (
+	IdleMusicScale   = 0.3 // Music volume relative to the player's setting
+	IdleDrawEvery    = 3   // Only every Nth frame is drawn, to save battery
+	IdleRestartDelay = 2.0 // Seconds the game-over screen shows before the bot goes again
+)
+
+// updateIdle runs the screensaver around the bot's runs: any key or click
+// quits, and a finished run restarts after a short pause
+func (g *Game) updateIdle() error {
+	if len(inpututil.AppendJustPressedKeys(nil)) > 0 || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return ebiten.Termination
+	}
+	if !g.gameOver {
+		g.idleOver = 0
+		return nil
+	}
+	if g.idleOver += g.dt; g.idleOver >= IdleRestartDelay {
+		g.restart()
+	}
+	return nil
+}
+
+// idleSkipDraw reports whether this frame is skipped in idle mode. The
+// launcher stops ebiten clearing the screen, so the last drawn frame stays up.
+func (g *Game) idleSkipDraw() bool {
+	if !g.opts.idle || g.opts.attract != nil {
+		return false // The attract demo draws every frame, as the screen is cleared
+	}
+	g.idleFrame++
+	return g.idleFrame%IdleDrawEvery != 0
+}
@@ -0,0 +1,225 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Contexts an action is read in; two actions only clash when they share one
+const (
+	contextPlay = 1 << iota // During a run
+	contextMenu             // On the title, game-over and seeds screens
+)
+
+// actionContexts say where each action is read, so Space can shoot in a
+// run and restart after it without counting as a conflict
+var actionContexts = [actionCount]int{
+	ActionLeft:      contextPlay | contextMenu,
+	ActionRight:     contextPlay | contextMenu,
+	ActionJump:      contextPlay,
+	ActionShoot:     contextPlay,
+	ActionFly:       contextPlay,
+	ActionWeather:   contextPlay,
+	ActionRestart:   contextMenu,
+	ActionGrapple:   contextPlay,
+	ActionTally:     contextPlay,
+	ActionStats:     contextMenu,
+	ActionPause:     contextPlay | contextMenu,
+	ActionBookmarks: contextMenu,
+	ActionPrestige:  contextMenu,
+	ActionControls:  contextMenu,
+	ActionSettings:  contextMenu,
+	ActionGallery:   contextMenu,
+	ActionCodex:     contextMenu,
+	ActionContinue:  contextMenu,
+	ActionProfiles:  contextMenu,
+	ActionReplay:    contextMenu,
+	ActionDaily:     contextMenu,
+	ActionCoin:      contextPlay | contextMenu,
+	ActionShop:      contextMenu,
+}
+
+// mouseButtonNames are the config file names of mouse buttons
+var mouseButtonNames = map[ebiten.MouseButton]string{
+	ebiten.MouseButtonLeft:   "left",
+	ebiten.MouseButtonRight:  "right",
+	ebiten.MouseButtonMiddle: "middle",
+	ebiten.MouseButton3:      "back",
+	ebiten.MouseButton4:      "forward",
+}
+
+// gamepadButtonNames are the config file names of standard-layout buttons
+var gamepadButtonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "a",
+	ebiten.StandardGamepadButtonRightRight:       "b",
+	ebiten.StandardGamepadButtonRightLeft:        "x",
+	ebiten.StandardGamepadButtonRightTop:         "y",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "lb",
+	ebiten.StandardGamepadButtonFrontTopRight:    "rb",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "lt",
+	ebiten.StandardGamepadButtonFrontBottomRight: "rt",
+	ebiten.StandardGamepadButtonCenterLeft:       "back",
+	ebiten.StandardGamepadButtonCenterRight:      "start",
+	ebiten.StandardGamepadButtonCenterCenter:     "home",
+	ebiten.StandardGamepadButtonLeftStick:        "lstick",
+	ebiten.StandardGamepadButtonRightStick:       "rstick",
+	ebiten.StandardGamepadButtonLeftTop:          "up",
+	ebiten.StandardGamepadButtonLeftBottom:       "down",
+	ebiten.StandardGamepadButtonLeftLeft:         "left",
+	ebiten.StandardGamepadButtonLeftRight:        "right",
+}
+
+// GamepadButtonName is the config file name of standard-layout button b
+func GamepadButtonName(b ebiten.StandardGamepadButton) string {
+	return gamepadButtonNames[b]
+}
+
+// lookupName finds the input called name in names
+func lookupName[T comparable](names map[T]string, name string) (T, bool) {
+	for v, n := range names {
+		if n == strings.ToLower(name) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Equal reports whether b and o are triggered by exactly the same inputs
+func (b Binding) Equal(o Binding) bool {
+	return slices.Equal(b.Keys, o.Keys) &&
+		slices.Equal(b.MouseButtons, o.MouseButtons) &&
+		slices.Equal(b.GamepadButtons, o.GamepadButtons)
+}
+
+// String lists the binding's inputs for menus, e.g. "ArrowUp, W, Pad a"
+func (b Binding) String() string {
+	var parts []string
+	for _, k := range b.Keys {
+		parts = append(parts, k.String())
+	}
+	for _, m := range b.MouseButtons {
+		parts = append(parts, "Mouse "+mouseButtonNames[m])
+	}
+	for _, gb := range b.GamepadButtons {
+		parts = append(parts, "Pad "+gamepadButtonNames[gb])
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// overlaps reports whether b and o share any input
+func (b Binding) overlaps(o Binding) bool {
+	return slices.ContainsFunc(b.Keys, func(k ebiten.Key) bool { return slices.Contains(o.Keys, k) }) ||
+		slices.ContainsFunc(b.MouseButtons, func(m ebiten.MouseButton) bool { return slices.Contains(o.MouseButtons, m) }) ||
+		slices.ContainsFunc(b.GamepadButtons, func(gb ebiten.StandardGamepadButton) bool { return slices.Contains(o.GamepadButtons, gb) })
+}
+
+// Conflicts lists the other actions that share an input with a and are
+// read on the same screens, so one press would trigger both
+func (b Bindings) Conflicts(a Action) []Action {
+	var clashes []Action
+	for o := range actionCount {
+		if o != a && actionContexts[a]&actionContexts[o] != 0 && b.Actions[a].overlaps(b.Actions[o]) {
+			clashes = append(clashes, o)
+		}
+	}
+	return clashes
+}
+
+// bindingEntry is one action in a bindings file
+type bindingEntry struct {
+	Keys    []ebiten.Key `json:"keys,omitempty"`
+	Mouse   []string     `json:"mouse,omitempty"`
+	Gamepad []string     `json:"gamepad,omitempty"`
+}
+
+// LoadBindings reads the bindings file at path and applies it on top of
+// base. Actions missing from the file keep their base binding, and a
+// missing file is not an error.
+func LoadBindings(path string, base Bindings) (Bindings, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return base, nil
+	}
+	if err != nil {
+		return base, err
+	}
+
+	var file map[string]bindingEntry
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return base, fmt.Errorf("parse bindings %s: %w", path, err)
+	}
+
+	b := base
+	for name, e := range file {
+		a, ok := lookupName(actionNamesByAction(), name)
+		if !ok {
+			return base, fmt.Errorf("bindings %s: unknown action %q", path, name)
+		}
+		bind := Binding{Keys: e.Keys}
+		for _, n := range e.Mouse {
+			m, ok := lookupName(mouseButtonNames, n)
+			if !ok {
+				return base, fmt.Errorf("bindings %s: %s: unknown mouse button %q", path, name, n)
+			}
+			bind.MouseButtons = append(bind.MouseButtons, m)
+		}
+		for _, n := range e.Gamepad {
+			gb, ok := lookupName(gamepadButtonNames, n)
+			if !ok {
+				return base, fmt.Errorf("bindings %s: %s: unknown gamepad button %q", path, name, n)
+			}
+			bind.GamepadButtons = append(bind.GamepadButtons, gb)
+		}
+		b.Actions[a] = bind
+	}
+	return b, nil
+}
+
+// WriteBindings saves the actions of b that differ from base to path, so
+// everything left alone keeps following the preset
+func WriteBindings(path string, b, base Bindings) error {
+	file := map[string]bindingEntry{}
+	for a := range actionCount {
+		bind := b.Actions[a]
+		if bind.Equal(base.Actions[a]) {
+			continue
+		}
+		e := bindingEntry{Keys: bind.Keys}
+		for _, m := range bind.MouseButtons {
+			e.Mouse = append(e.Mouse, mouseButtonNames[m])
+		}
+		for _, gb := range bind.GamepadButtons {
+			e.Gamepad = append(e.Gamepad, gamepadButtonNames[gb])
+		}
+		file[a.String()] = e
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// actionNamesByAction indexes actionNames for lookupName
+func actionNamesByAction() map[Action]string {
+	names := make(map[Action]string, actionCount)
+	for a, n := range actionNames {
+		names[Action(a)] = n
+	}
+	return names
+}
@@ -0,0 +1,192 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// MouseSteerDeadzone is how close (in pixels) the cursor must be to the
+// player before mouse steering stops
+const MouseSteerDeadzone = 6.0
+
+// StickDeadzone is how far the left stick must tilt, out of 1.0, before it moves the player
+const StickDeadzone = 0.25
+
+// Controller answers "is this action active" questions for the game
+type Controller struct {
+	Bindings Bindings
+
+	// AutoMove latches direction: tap left/right to keep moving that way,
+	// tap the same direction again (or the other one) to stop or turn
+	AutoMove bool
+
+	// Shared leaves the mouse and every gamepad but the first to a co-op
+	// Gunner, so they no longer drive this controller
+	Shared bool
+
+	// Seat, from 1, gives the controller only the Seat-th gamepad, so
+	// split-screen players each drive with their own; 0 takes them all
+	Seat int
+
+	latched float64 // Latched auto-move direction: -1, 0 or 1
+	pads    []ebiten.GamepadID
+}
+
+// standardGamepads refills buf with the connected gamepads with the standard layout
+func standardGamepads(buf []ebiten.GamepadID) []ebiten.GamepadID {
+	buf = ebiten.AppendGamepadIDs(buf[:0])
+	standard := buf[:0]
+	for _, id := range buf {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			standard = append(standard, id)
+		}
+	}
+	return standard
+}
+
+// gamepads returns the gamepads driving the controller
+func (c *Controller) gamepads() []ebiten.GamepadID {
+	c.pads = standardGamepads(c.pads)
+	switch {
+	case c.Seat > len(c.pads):
+		return nil
+	case c.Seat > 0:
+		return c.pads[c.Seat-1 : c.Seat]
+	case c.Shared && len(c.pads) > 1:
+		return c.pads[:1]
+	}
+	return c.pads
+}
+
+// stick returns the left stick's horizontal tilt of the first gamepad
+// pushed past StickDeadzone, rescaled so movement starts from 0 at the
+// deadzone's edge
+func (c *Controller) stick() float64 {
+	for _, id := range c.gamepads() {
+		v := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		if math.Abs(v) > StickDeadzone {
+			return math.Copysign((math.Abs(v)-StickDeadzone)/(1-StickDeadzone), v)
+		}
+	}
+	return 0
+}
+
+// NewController creates a controller for bindings
+func NewController(b Bindings) *Controller {
+	return &Controller{Bindings: b}
+}
+
+// Pressed reports whether any input bound to a is held
+func (c *Controller) Pressed(a Action) bool {
+	b := c.Bindings.Actions[a]
+	for _, k := range b.Keys {
+		if ebiten.IsKeyPressed(k) {
+			return true
+		}
+	}
+	for _, m := range b.MouseButtons {
+		if !c.Shared && ebiten.IsMouseButtonPressed(m) {
+			return true
+		}
+	}
+	for _, id := range c.gamepads() {
+		for _, gb := range b.GamepadButtons {
+			if ebiten.IsStandardGamepadButtonPressed(id, gb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JustPressed reports whether any input bound to a was pressed this tick
+func (c *Controller) JustPressed(a Action) bool {
+	b := c.Bindings.Actions[a]
+	for _, k := range b.Keys {
+		if inpututil.IsKeyJustPressed(k) {
+			return true
+		}
+	}
+	for _, m := range b.MouseButtons {
+		if !c.Shared && inpututil.IsMouseButtonJustPressed(m) {
+			return true
+		}
+	}
+	for _, id := range c.gamepads() {
+		for _, gb := range b.GamepadButtons {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, gb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Update advances per-tick state such as the auto-move latch; call once per tick
+func (c *Controller) Update() {
+	if !c.AutoMove {
+		c.latched = 0
+		return
+	}
+	if c.JustPressed(ActionLeft) {
+		if c.latched < 0 {
+			c.latched = 0
+		} else {
+			c.latched = -1
+		}
+	}
+	if c.JustPressed(ActionRight) {
+		if c.latched > 0 {
+			c.latched = 0
+		} else {
+			c.latched = 1
+		}
+	}
+}
+
+// Horizontal returns the movement direction in -1.0 - 1.0. A tilted stick
+// wins over everything else; playerX is used in screen space to steer
+// toward the cursor when mouse steering is on.
+func (c *Controller) Horizontal(playerX float64) float64 {
+	if v := c.stick(); v != 0 {
+		return v
+	}
+
+	if c.Bindings.MouseSteer && !c.Shared {
+		cx, _ := ebiten.CursorPosition()
+		dx := float64(cx) - playerX
+		if dx < -MouseSteerDeadzone {
+			return -1
+		}
+		if dx > MouseSteerDeadzone {
+			return 1
+		}
+		return 0
+	}
+
+	if c.AutoMove {
+		return c.latched
+	}
+
+	dir := 0.0
+	if c.Pressed(ActionLeft) {
+		dir--
+	}
+	if c.Pressed(ActionRight) {
+		dir++
+	}
+	return dir
+}
+
+// Source is anything that can drive the game: the keyboard/mouse
+// Controller, a bot, a replay or a network peer
+type Source interface {
+	Pressed(a Action) bool
+	JustPressed(a Action) bool
+	Horizontal(playerX float64) float64
+	Update()
+}
+
+var _ Source = (*Controller)(nil)
@@ -0,0 +1,125 @@
+package input
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ReticleSpeed is how far (in pixels per tick) a fully tilted right stick
+// moves the co-op reticle
+const ReticleSpeed = 6.0
+
+// Gunner is player two of a co-op run: an aiming reticle steered by the
+// mouse or the second gamepad's right stick, and a trigger that fires at it
+type Gunner struct {
+	X, Y float64 // Reticle position in screen space
+
+	width, height float64 // Screen bounds the reticle stays inside
+	cursorX       int     // Last cursor position, so only a moved mouse takes over
+	cursorY       int
+	pads          []ebiten.GamepadID
+}
+
+// NewGunner creates a gunner whose reticle starts in the middle of a
+// width x height screen
+func NewGunner(width, height float64) *Gunner {
+	cx, cy := ebiten.CursorPosition()
+	return &Gunner{X: width / 2, Y: height / 2, width: width, height: height, cursorX: cx, cursorY: cy}
+}
+
+// pad returns the second standard gamepad, the gunner's own
+func (gn *Gunner) pad() (ebiten.GamepadID, bool) {
+	gn.pads = standardGamepads(gn.pads)
+	if len(gn.pads) < 2 {
+		return 0, false
+	}
+	return gn.pads[1], true
+}
+
+// Update moves the reticle; call once per tick
+func (gn *Gunner) Update() {
+	if cx, cy := ebiten.CursorPosition(); cx != gn.cursorX || cy != gn.cursorY {
+		gn.cursorX, gn.cursorY = cx, cy
+		gn.X, gn.Y = float64(cx), float64(cy)
+	}
+	if id, ok := gn.pad(); ok {
+		for _, axis := range [2]struct {
+			a   ebiten.StandardGamepadAxis
+			pos *float64
+		}{
+			{ebiten.StandardGamepadAxisRightStickHorizontal, &gn.X},
+			{ebiten.StandardGamepadAxisRightStickVertical, &gn.Y},
+		} {
+			if v := ebiten.StandardGamepadAxisValue(id, axis.a); math.Abs(v) > StickDeadzone {
+				*axis.pos += v * ReticleSpeed
+			}
+		}
+	}
+	gn.X = math.Max(0, math.Min(gn.width, gn.X))
+	gn.Y = math.Max(0, math.Min(gn.height, gn.Y))
+}
+
+// Firing reports whether the trigger is held
+func (gn *Gunner) Firing() bool {
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		return true
+	}
+	id, ok := gn.pad()
+	return ok && (ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonFrontBottomRight) ||
+		ebiten.IsStandardGamepadButtonPressed(id, ebiten.StandardGamepadButtonRightBottom))
+}
+
+// Fired reports whether the trigger was pulled this tick
+func (gn *Gunner) Fired() bool {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return true
+	}
+	id, ok := gn.pad()
+	return ok && (inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonFrontBottomRight) ||
+		inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightBottom))
+}
+
+// Coop splits one avatar between two players: the Mover flies it with
+// every action but shooting, which belongs to the Gunner
+type Coop struct {
+	Mover  *Controller
+	Gunner *Gunner
+}
+
+// NewCoop pairs mover, who keeps the keyboard and first gamepad, with a
+// gunner on the mouse or second gamepad
+func NewCoop(mover *Controller, gunner *Gunner) *Coop {
+	mover.Shared = true
+	return &Coop{Mover: mover, Gunner: gunner}
+}
+
+// Pressed reports whether a is held by the player it belongs to
+func (c *Coop) Pressed(a Action) bool {
+	if a == ActionShoot {
+		return c.Gunner.Firing()
+	}
+	return c.Mover.Pressed(a)
+}
+
+// JustPressed reports whether a was pressed this tick by the player it belongs to
+func (c *Coop) JustPressed(a Action) bool {
+	if a == ActionShoot {
+		return c.Gunner.Fired()
+	}
+	return c.Mover.JustPressed(a)
+}
+
+// Horizontal is the mover's steering
+func (c *Coop) Horizontal(playerX float64) float64 {
+	return c.Mover.Horizontal(playerX)
+}
+
+// Update advances both players' per-tick state
+func (c *Coop) Update() {
+	c.Mover.Update()
+	c.Gunner.Update()
+}
+
+var _ Source = (*Coop)(nil)
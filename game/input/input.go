@@ -0,0 +1,284 @@
+// Package input maps physical keys and buttons to game actions so controls
+// can be remapped and swapped between presets.
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is something the player can do
+type Action int
+
+const (
+	ActionLeft Action = iota
+	ActionRight
+	ActionJump // Release from sticky platforms, fly upward
+	ActionShoot
+	ActionFly
+	ActionWeather
+	ActionRestart
+	ActionGrapple   // Hold to aim the grappling hook, release to throw
+	ActionTally     // Toggle the live score breakdown
+	ActionStats     // Toggle the death heatmap on the game-over screen
+	ActionPause     // Pause and resume a run
+	ActionBookmarks // Bookmark a finished run's seed; open the seeds screen from the title
+	ActionPrestige  // Trade a high-scoring run in for a prestige level
+	ActionControls  // Open the rebinding screen from the title
+	ActionSettings  // Open the settings screen from the title
+	ActionGallery   // Open the postcard gallery from the title
+	ActionCodex     // Open the codex of entities met from the title
+	ActionContinue  // Continue the run left unfinished when the window closed
+	ActionProfiles  // Open the profile picker from the title
+	ActionReplay    // Watch the replay of the run that just ended
+	ActionDaily     // Start the daily challenge from the title, leave it after a run
+	ActionCoin      // Insert a coin in a kiosk
+	ActionShop      // Open the shop from the title
+	actionCount
+)
+
+// actionNames are stable identifiers used in config files and menus
+var actionNames = [actionCount]string{
+	ActionLeft:      "left",
+	ActionRight:     "right",
+	ActionJump:      "jump",
+	ActionShoot:     "shoot",
+	ActionFly:       "fly",
+	ActionWeather:   "weather",
+	ActionRestart:   "restart",
+	ActionGrapple:   "grapple",
+	ActionTally:     "tally",
+	ActionStats:     "stats",
+	ActionPause:     "pause",
+	ActionBookmarks: "bookmarks",
+	ActionPrestige:  "prestige",
+	ActionControls:  "controls",
+	ActionSettings:  "settings",
+	ActionGallery:   "gallery",
+	ActionCodex:     "codex",
+	ActionContinue:  "continue",
+	ActionProfiles:  "profiles",
+	ActionReplay:    "replay",
+	ActionDaily:     "daily",
+	ActionCoin:      "coin",
+	ActionShop:      "shop",
+}
+
+// String returns the action's stable identifier
+func (a Action) String() string {
+	if a < 0 || a >= actionCount {
+		return "unknown"
+	}
+	return actionNames[a]
+}
+
+// Actions lists every action in menu order
+func Actions() []Action {
+	actions := make([]Action, actionCount)
+	for i := range actions {
+		actions[i] = Action(i)
+	}
+	return actions
+}
+
+// Binding is the set of inputs that trigger one action
+type Binding struct {
+	Keys           []ebiten.Key
+	MouseButtons   []ebiten.MouseButton
+	GamepadButtons []ebiten.StandardGamepadButton // On any gamepad with the standard layout
+}
+
+// Bindings maps every action to its inputs
+type Bindings struct {
+	Actions [actionCount]Binding
+
+	// MouseSteer moves the player toward the cursor instead of using
+	// left/right bindings alone
+	MouseSteer bool
+}
+
+// Preset is a named, documented set of bindings
+type Preset struct {
+	ID          string
+	Name        string
+	Description string // Shown next to the preset in the settings screen
+	Bindings    Bindings
+}
+
+// keys is shorthand for a key-only binding
+func keys(k ...ebiten.Key) Binding {
+	return Binding{Keys: k}
+}
+
+// Presets lists the built-in control schemes
+var Presets = []Preset{
+	{
+		ID:          "default",
+		Name:        "Default",
+		Description: "Arrows/A/D move, Up/W jump and fly, Space shoots, F flies, G grapples, Q changes weather",
+		Bindings: Bindings{Actions: [actionCount]Binding{
+			ActionLeft:      keys(ebiten.KeyLeft, ebiten.KeyA),
+			ActionRight:     keys(ebiten.KeyRight, ebiten.KeyD),
+			ActionJump:      keys(ebiten.KeyUp, ebiten.KeyW),
+			ActionShoot:     keys(ebiten.KeySpace),
+			ActionFly:       keys(ebiten.KeyF),
+			ActionWeather:   keys(ebiten.KeyQ),
+			ActionRestart:   keys(ebiten.KeySpace),
+			ActionGrapple:   keys(ebiten.KeyG),
+			ActionTally:     keys(ebiten.KeyTab),
+			ActionStats:     keys(ebiten.KeyH),
+			ActionPause:     keys(ebiten.KeyEscape, ebiten.KeyP),
+			ActionBookmarks: keys(ebiten.KeyB),
+			ActionPrestige:  keys(ebiten.KeyV),
+			ActionControls:  keys(ebiten.KeyK),
+			ActionSettings:  keys(ebiten.KeyO),
+			ActionGallery:   keys(ebiten.KeyL),
+			ActionCodex:     keys(ebiten.KeyJ),
+			ActionContinue:  keys(ebiten.KeyC),
+			ActionProfiles:  keys(ebiten.KeyU),
+			ActionReplay:    keys(ebiten.KeyR),
+			ActionDaily:     keys(ebiten.KeyT),
+			ActionCoin:      keys(ebiten.KeyDigit5),
+			ActionShop:      keys(ebiten.KeyS),
+		}},
+	},
+	{
+		ID:          "one_handed_left",
+		Name:        "One-handed (left)",
+		Description: "Everything on the left hand: A/D move, W jump, S shoot, E fly, R grapple, Q weather",
+		Bindings: Bindings{Actions: [actionCount]Binding{
+			ActionLeft:      keys(ebiten.KeyA),
+			ActionRight:     keys(ebiten.KeyD),
+			ActionJump:      keys(ebiten.KeyW),
+			ActionShoot:     keys(ebiten.KeyS),
+			ActionFly:       keys(ebiten.KeyE),
+			ActionWeather:   keys(ebiten.KeyQ),
+			ActionRestart:   keys(ebiten.KeyS, ebiten.KeySpace),
+			ActionGrapple:   keys(ebiten.KeyR),
+			ActionTally:     keys(ebiten.KeyTab),
+			ActionStats:     keys(ebiten.KeyF),
+			ActionPause:     keys(ebiten.KeyEscape),
+			ActionBookmarks: keys(ebiten.KeyB),
+			ActionPrestige:  keys(ebiten.KeyV),
+			ActionControls:  keys(ebiten.KeyC),
+			ActionSettings:  keys(ebiten.KeyX),
+			ActionGallery:   keys(ebiten.KeyZ),
+			ActionCodex:     keys(ebiten.KeyT),
+			ActionContinue:  keys(ebiten.KeyG),
+			ActionProfiles:  keys(ebiten.KeyDigit1),
+			ActionReplay:    keys(ebiten.KeyDigit2),
+			ActionDaily:     keys(ebiten.KeyDigit3),
+			ActionCoin:      keys(ebiten.KeyDigit5),
+			ActionShop:      keys(ebiten.KeyDigit4),
+		}},
+	},
+	{
+		ID:          "one_handed_right",
+		Name:        "One-handed (right)",
+		Description: "Everything on the arrow cluster: Left/Right move, Up jump, Down shoot, Right Shift fly, Right Ctrl grapple, Enter weather",
+		Bindings: Bindings{Actions: [actionCount]Binding{
+			ActionLeft:      keys(ebiten.KeyLeft),
+			ActionRight:     keys(ebiten.KeyRight),
+			ActionJump:      keys(ebiten.KeyUp),
+			ActionShoot:     keys(ebiten.KeyDown),
+			ActionFly:       keys(ebiten.KeyShiftRight),
+			ActionWeather:   keys(ebiten.KeyEnter),
+			ActionRestart:   keys(ebiten.KeyDown, ebiten.KeyEnter),
+			ActionGrapple:   keys(ebiten.KeyControlRight),
+			ActionTally:     keys(ebiten.KeyBackslash),
+			ActionStats:     keys(ebiten.KeyEnd),
+			ActionPause:     keys(ebiten.KeyPause, ebiten.KeyBackspace),
+			ActionBookmarks: keys(ebiten.KeyPageDown),
+			ActionPrestige:  keys(ebiten.KeyPageUp),
+			ActionControls:  keys(ebiten.KeyHome),
+			ActionSettings:  keys(ebiten.KeyInsert),
+			ActionGallery:   keys(ebiten.KeyDelete),
+			ActionCodex:     keys(ebiten.KeySlash),
+			ActionContinue:  keys(ebiten.KeyEqual),
+			ActionProfiles:  keys(ebiten.KeyMinus),
+			ActionReplay:    keys(ebiten.KeyPeriod),
+			ActionDaily:     keys(ebiten.KeySemicolon),
+			ActionCoin:      keys(ebiten.KeyDigit5),
+			ActionShop:      keys(ebiten.KeyQuote),
+		}},
+	},
+	{
+		ID:          "mouse",
+		Name:        "Mouse only",
+		Description: "The player follows the cursor; left click shoots, right click jumps and flies, middle click activates flight, back button grapples, forward button pauses",
+		Bindings: Bindings{
+			// Menus past the title can't be clicked through, so the
+			// title's keys stay on the default ones
+			Actions: [actionCount]Binding{
+				ActionJump:      {MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonRight}},
+				ActionShoot:     {MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonLeft}},
+				ActionFly:       {MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonMiddle}},
+				ActionRestart:   {MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonLeft}},
+				ActionGrapple:   {MouseButtons: []ebiten.MouseButton{ebiten.MouseButton3}},
+				ActionStats:     {MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonRight}},
+				ActionPause:     {Keys: []ebiten.Key{ebiten.KeyEscape, ebiten.KeyP}, MouseButtons: []ebiten.MouseButton{ebiten.MouseButton4}},
+				ActionBookmarks: keys(ebiten.KeyB),
+				ActionPrestige:  keys(ebiten.KeyV),
+				ActionControls:  keys(ebiten.KeyK),
+				ActionSettings:  keys(ebiten.KeyO),
+				ActionGallery:   keys(ebiten.KeyL),
+				ActionCodex:     keys(ebiten.KeyJ),
+				ActionContinue:  keys(ebiten.KeyC),
+				ActionProfiles:  keys(ebiten.KeyU),
+				ActionReplay:    keys(ebiten.KeyR),
+				ActionDaily:     keys(ebiten.KeyT),
+				ActionCoin:      keys(ebiten.KeyDigit5),
+				ActionShop:      keys(ebiten.KeyS),
+			},
+			MouseSteer: true,
+		},
+	},
+}
+
+// DefaultGamepad is the standard-layout gamepad mapping every preset shares.
+// The left stick and d-pad move; A jumps and restarts, X shoots, Y flies.
+var DefaultGamepad = [actionCount][]ebiten.StandardGamepadButton{
+	ActionLeft:      {ebiten.StandardGamepadButtonLeftLeft},
+	ActionRight:     {ebiten.StandardGamepadButtonLeftRight},
+	ActionJump:      {ebiten.StandardGamepadButtonRightBottom, ebiten.StandardGamepadButtonLeftTop},
+	ActionShoot:     {ebiten.StandardGamepadButtonRightLeft, ebiten.StandardGamepadButtonFrontBottomRight},
+	ActionFly:       {ebiten.StandardGamepadButtonRightTop},
+	ActionWeather:   {ebiten.StandardGamepadButtonFrontTopLeft},
+	ActionRestart:   {ebiten.StandardGamepadButtonRightBottom},
+	ActionGrapple:   {ebiten.StandardGamepadButtonFrontTopRight},
+	ActionTally:     {ebiten.StandardGamepadButtonCenterLeft},
+	ActionStats:     {ebiten.StandardGamepadButtonRightTop},
+	ActionPause:     {ebiten.StandardGamepadButtonCenterRight},
+	ActionBookmarks: {ebiten.StandardGamepadButtonLeftBottom},
+	ActionPrestige:  {ebiten.StandardGamepadButtonLeftStick},
+	ActionControls:  {ebiten.StandardGamepadButtonRightStick},
+	ActionSettings:  {ebiten.StandardGamepadButtonCenterCenter},
+	ActionGallery:   {ebiten.StandardGamepadButtonFrontBottomLeft},
+	ActionCodex:     {ebiten.StandardGamepadButtonRightRight},
+	ActionContinue:  {ebiten.StandardGamepadButtonFrontTopRight}, // Grapple's button, free on the title
+	ActionProfiles:  {ebiten.StandardGamepadButtonLeftTop},       // Jump's d-pad up, free on the title
+	ActionReplay:    {ebiten.StandardGamepadButtonCenterLeft},    // Tally's button, free after a run
+	ActionDaily:     {ebiten.StandardGamepadButtonRightLeft},     // Shoot's X, free outside a run
+	ActionCoin:      nil,                                         // Coin switches are wired as keys
+	ActionShop:      {ebiten.StandardGamepadButtonFrontTopLeft},  // Weather's LB, free outside a run
+}
+
+func init() {
+	for i := range Presets {
+		for a := range Presets[i].Bindings.Actions {
+			b := &Presets[i].Bindings.Actions[a]
+			if b.GamepadButtons == nil {
+				b.GamepadButtons = DefaultGamepad[a]
+			}
+		}
+	}
+}
+
+// PresetByID returns the preset with id, or the default preset
+func PresetByID(id string) Preset {
+	for _, p := range Presets {
+		if p.ID == id {
+			return p
+		}
+	}
+	return Presets[0]
+}
@@ -0,0 +1,234 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Kiosk parameters
+const (
+	KioskRunTime      = 3 * time.Minute // Default time limit of a run
+	KioskAttractDelay = 20.0            // Seconds the coin screen waits before the attract demo
+	KioskOverDelay    = 10.0            // Seconds the game-over screen waits for another credit
+	KioskTableSize    = 10              // High scores kept
+	KioskInitials     = 3               // Letters of a high-score name
+)
+
+// initialLetters are the arcade keyboard's characters, in cycling order
+const initialLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// KioskScore is one entry of the cabinet's high-score table
+type KioskScore struct {
+	Initials string `json:"initials"`
+	Score    int    `json:"score"`
+}
+
+// Kiosk is a cabinet or event booth session: runs cost a credit, last a
+// fixed time, and the game can't be quit or reconfigured. It outlives
+// the games rebuilt for every run.
+type Kiosk struct {
+	RunLimit time.Duration
+	credits  int
+	waited   float64 // Seconds the current screen has gone without play
+}
+
+// NewKiosk starts a session whose runs last runLimit, or KioskRunTime
+// when runLimit isn't positive
+func NewKiosk(runLimit time.Duration) *Kiosk {
+	if runLimit <= 0 {
+		runLimit = KioskRunTime
+	}
+	return &Kiosk{RunLimit: runLimit}
+}
+
+// WithKiosk runs the game as part of kiosk session k
+func WithKiosk(k *Kiosk) Option {
+	return func(o *gameOptions) {
+		o.kiosk = k
+	}
+}
+
+// updateKiosk counts the coins inserted this frame, whatever is on screen
+func (g *Game) updateKiosk() {
+	if g.opts.idle || !g.controller.JustPressed(input.ActionCoin) {
+		return
+	}
+	g.opts.kiosk.credits++
+	g.opts.kiosk.waited = 0
+	g.feedback(FeedbackBoostPickup)
+}
+
+// startKioskRun spends a credit on a run
+func (g *Game) startKioskRun() {
+	g.opts.kiosk.credits--
+	g.opts.kiosk.waited = 0
+	g.restartWith()
+	g.feedback(FeedbackUIClick)
+}
+
+// qualifies reports whether score makes the high-score table
+func (g *Game) qualifies(score int) bool {
+	table := g.save.Kiosk
+	return score > 0 && (len(table) < KioskTableSize || score > table[len(table)-1].Score)
+}
+
+// addKioskScore enters initials into the table in score order and keeps
+// the table's top KioskTableSize
+func (g *Game) addKioskScore(initials string) {
+	i := 0
+	for i < len(g.save.Kiosk) && g.save.Kiosk[i].Score >= g.score {
+		i++ // Ties go after the older entry
+	}
+	g.save.Kiosk = slices.Insert(g.save.Kiosk, i, KioskScore{Initials: initials, Score: g.score})
+	if len(g.save.Kiosk) > KioskTableSize {
+		g.save.Kiosk = g.save.Kiosk[:KioskTableSize]
+	}
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+}
+
+// kioskRunEnded sends a high score to the initials entry
+func (g *Game) kioskRunEnded() {
+	g.opts.kiosk.waited = 0
+	if g.qualifies(g.score) {
+		g.initials = []byte{initialLetters[0]}
+		g.scenes.Switch(initialsScene)
+	}
+}
+
+// drawKioskTable draws the high-score table from y down
+func (g *Game) drawKioskTable(screen *ebiten.Image, y float64) {
+	drawTextCentered(screen, "High Scores", y, TextSmall, color.RGBA{255, 220, 100, 255})
+	for i, e := range g.save.Kiosk {
+		drawTextCentered(screen, fmt.Sprintf("%2d. %s  %6d", i+1, e.Initials, e.Score), y+float64(i+1)*textLineHeight, TextSmall, textColor)
+	}
+}
+
+// creditsLine tells the player what starting takes
+func (g *Game) creditsLine() string {
+	if c := g.opts.kiosk.credits; c > 0 {
+		return "Credits: " + strconv.Itoa(c) + "  Press " + g.actionKey(input.ActionRestart) + " to play"
+	}
+	return "Insert coin"
+}
+
+// kioskOverLines replace the game-over hints in a kiosk
+func kioskOverLines(g *Game) []hudLine {
+	lines := []hudLine{{g.creditsLine(), TextSmall}}
+	if g.opts.kiosk.credits > 0 {
+		lines = append(lines, hudLine{"Continue? " + strconv.Itoa(int(KioskOverDelay-g.opts.kiosk.waited+0.999)), TextSmall})
+	}
+	return lines
+}
+
+// updateKioskOver waits on the game-over screen for another credit, then
+// goes back to the coin screen
+func updateKioskOver(g *Game) {
+	k := g.opts.kiosk
+	switch {
+	case k.credits > 0 && g.controller.JustPressed(input.ActionRestart):
+		g.startKioskRun()
+	case k.waited >= KioskOverDelay:
+		g.restartWith()
+		g.scenes.Switch(coinScene)
+		k.waited = 0
+	default:
+		k.waited += g.dt
+	}
+}
+
+// sceneCoin is a kiosk's title: the high scores and the credits, until
+// the attract demo takes over
+type sceneCoin struct{}
+
+func (sceneCoin) Update(g *Game) error {
+	if g.audio != nil {
+		g.audio.Update(true)
+	}
+	k := g.opts.kiosk
+	switch {
+	case k.credits > 0 && g.controller.JustPressed(input.ActionRestart):
+		g.startKioskRun()
+	case k.credits == 0 && k.waited >= KioskAttractDelay:
+		g.startAttract()
+	default:
+		k.waited += g.dt
+	}
+	return nil
+}
+
+func (sceneCoin) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "GodleJump", ScreenHeight/4, TextLarge, textColor)
+	d := g.opts.kiosk.RunLimit
+	drawTextCentered(screen, fmt.Sprintf("%d:%02d per credit", int(d.Minutes()), int(d.Seconds())%60), ScreenHeight/4+30, TextSmall, textColor)
+	g.drawKioskTable(screen, ScreenHeight/4+30+2*textLineHeight)
+	// Blink like a cabinet's coin prompt
+	if g.opts.kiosk.credits > 0 || int(g.opts.kiosk.waited*2)%2 == 0 {
+		drawTextCentered(screen, g.creditsLine(), ScreenHeight-60, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+}
+
+// sceneInitials enters a high score's initials on an arcade keyboard:
+// left and right pick a letter, SPACE takes it and ESC takes one back
+type sceneInitials struct{}
+
+func (sceneInitials) Update(g *Game) error {
+	g.updateToasts()
+	last := len(g.initials) - 1
+	letter := strings.IndexByte(initialLetters, g.initials[last])
+	n := len(initialLetters)
+	switch {
+	case g.controller.JustPressed(input.ActionLeft):
+		g.initials[last] = initialLetters[(letter+n-1)%n]
+	case g.controller.JustPressed(input.ActionRight):
+		g.initials[last] = initialLetters[(letter+1)%n]
+	case g.controller.JustPressed(input.ActionPause) && last > 0:
+		g.initials = g.initials[:last]
+	case g.controller.JustPressed(input.ActionRestart) && last < KioskInitials-1:
+		g.initials = append(g.initials, initialLetters[0])
+	case g.controller.JustPressed(input.ActionRestart):
+		g.addKioskScore(string(g.initials))
+		g.scenes.Switch(gameOverScene)
+	default:
+		return nil
+	}
+	g.feedback(FeedbackUIClick)
+	return nil
+}
+
+func (sceneInitials) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 120})
+	drawTextCentered(screen, "New high score!", ScreenHeight/4, TextLarge, color.RGBA{255, 220, 100, 255})
+	drawTextCentered(screen, strconv.Itoa(g.score), ScreenHeight/4+30, TextLarge, textColor)
+	drawTextCentered(screen, "Enter your initials", ScreenHeight/4+60, TextSmall, textColor)
+
+	const slot = 30.0
+	x := (ScreenWidth - slot*KioskInitials) / 2
+	y := ScreenHeight/2.0 - 10
+	for i := 0; i < KioskInitials; i++ {
+		clr := color.RGBA{120, 120, 140, 255}
+		s := "_"
+		if i < len(g.initials) {
+			s = string(g.initials[i])
+			clr = textColor
+		}
+		if i == len(g.initials)-1 {
+			clr = color.RGBA{255, 220, 100, 255}
+			drawRect(screen, x+float64(i)*slot+4, y+TextLarge+6, slot-8, 2, clr)
+		}
+		drawText(screen, s, x+float64(i)*slot+(slot-textWidth(s, TextLarge))/2, y, TextLarge, clr)
+	}
+	drawTextCentered(screen, joinHints("  ", g.moveHint("letter"), g.actionHint(input.ActionRestart, "next"), g.actionHint(input.ActionPause, "back")), ScreenHeight-40, TextSmall, textColor)
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	_ "embed"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Lake parameters
+const (
+	LakeHeight = 40 // Depth of the lake at the bottom of the first screen
+)
+
+//go:embed shaders/lake.kage
+var lakeShaderSrc []byte
+
+// Lake mirrors the scenery above it at the bottom of the starting screen
+type Lake struct {
+	shader      *ebiten.Shader
+	reflections map[float64]*ebiten.Image // Flipped copy of the scene above the water line, per draw scale
+	failed      bool                      // Shader didn't compile; the lake is skipped
+}
+
+// lakeTop is the world Y of the water line
+func lakeTop() float64 {
+	return ScreenHeight - LakeHeight
+}
+
+// draw renders the lake if it's still on screen. It must run after the sky,
+// mountains and clouds so they show up in the reflection.
+func (l *Lake) draw(screen *ebiten.Image, g *Game) {
+	surface := int(g.screenY(lakeTop()))
+	if surface >= ScreenHeight || l.failed {
+		return
+	}
+	if l.shader == nil {
+		s, err := ebiten.NewShader(lakeShaderSrc)
+		if err != nil {
+			log.Printf("Lake shader failed to compile, skipping the lake: %v", err)
+			l.failed = true
+			return
+		}
+		l.shader = s
+		l.reflections = map[float64]*ebiten.Image{}
+	}
+
+	// The reflection is copied from the pixels drawn, so it works in them too
+	scale := drawScale
+	w, h := int(ScreenWidth*scale), int(LakeHeight*scale)
+	reflection := l.reflections[scale]
+	if reflection == nil {
+		reflection = ebiten.NewImage(w, h)
+		l.reflections[scale] = reflection
+	}
+
+	// Mirror the strip just above the water line into the reflection buffer
+	reflection.Clear()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(0, -float64(surface)*scale)
+	op.GeoM.Scale(1, -1)
+	reflection.DrawImage(screen, op)
+
+	colorSet := getColorSetForTime(g.timeOfDay())
+	water := colorSet.mountainTints[len(colorSet.mountainTints)-1]
+	sop := &ebiten.DrawRectShaderOptions{}
+	sop.GeoM.Translate(0, float64(surface)*scale)
+	sop.Images[0] = reflection
+	sop.Uniforms = map[string]any{
+		"Time":  g.gameTime,
+		"Depth": float32(LakeHeight),
+		"Scale": float32(scale),
+		"Water": []float32{
+			float32(water.R) / 255 * 0.6,
+			float32(water.G) / 255 * 0.8,
+			float32(water.B) / 255,
+			1,
+		},
+	}
+	screen.DrawRectShader(w, h, l.shader, sop)
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"image/color"
+
+	"doodlejump/game/spritegen"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Landing particle parameters
+const (
+	MaxLandingBits = 64  // Particles alive at once; older ones make room
+	LandingBitLife = 0.5 // Seconds a particle lasts
+)
+
+// landingFeel is the sound and the burst of particles of landing on a
+// kind of platform
+type landingFeel struct {
+	sound  FeedbackEvent
+	count  int // Particles thrown
+	color  color.RGBA
+	size   float64 // Particle radius
+	spread float64 // Horizontal speed, either way
+	lift   float64 // Upward speed
+	weight float64 // Pull on the particles per frame; negative floats them up
+}
+
+// Landing feels of plain platforms, by how they are drawn
+var (
+	thumpFeel = landingFeel{sound: FeedbackLanding, count: 4, color: color.RGBA{150, 120, 80, 200}, size: 1.5, spread: 1, lift: 1, weight: 0.15}
+	poofFeel  = landingFeel{sound: FeedbackPoof, count: 8, color: color.RGBA{255, 255, 255, 190}, size: 3, spread: 1.2, lift: 0.4, weight: -0.01}
+)
+
+// landingFeels maps platform types to how landing on them feels; types
+// not listed feel like plain platforms
+var landingFeels = map[int]landingFeel{
+	PlatformSticky:       {sound: FeedbackSquelch, count: 6, color: color.RGBA{255, 220, 100, 220}, size: 2, spread: 0.8, lift: 1.5, weight: 0.2},
+	PlatformDisappearing: {sound: FeedbackPlatformCrack, count: 7, color: color.RGBA{120, 110, 100, 255}, size: 1.5, spread: 1.5, lift: 1, weight: 0.25},
+	PlatformSpring:       {sound: FeedbackClang, count: 5, color: color.RGBA{230, 230, 240, 255}, size: 1, spread: 2, lift: 2.5, weight: 0.2},
+}
+
+// feelOf is how landing on p feels: its type's feel, else a poof on a
+// cloud and a thump on anything else
+func (g *Game) feelOf(p *Platform) landingFeel {
+	if f, ok := landingFeels[p.Type]; ok {
+		return f
+	}
+	if g.platformSkin(p) == spritegen.SkinCloud {
+		return poofFeel
+	}
+	return thumpFeel
+}
+
+// landingBit is one particle thrown up by a landing, in world coordinates
+type landingBit struct {
+	X, Y   float64
+	VX, VY float64
+	Life   float64 // Seconds left
+	feel   *landingFeel
+}
+
+// landingFeedback plays the sound and throws the particles of landing on p
+func (g *Game) landingFeedback(p *Platform) {
+	f := g.feelOf(p)
+	g.feedbackAt(f.sound, g.player.X, p.Y)
+	if g.seeking {
+		return
+	}
+	for range f.count {
+		if len(g.landingBits) == MaxLandingBits {
+			g.landingBits = g.landingBits[1:]
+		}
+		g.landingBits = append(g.landingBits, landingBit{
+			X:    g.player.X + (fxFloat64()*2-1)*PlayerWidth/3,
+			Y:    p.Y,
+			VX:   (fxFloat64()*2 - 1) * f.spread,
+			VY:   -f.lift * (0.5 + fxFloat64()/2),
+			Life: LandingBitLife * (0.6 + fxFloat64()*0.4),
+			feel: &f,
+		})
+	}
+}
+
+// updateLandingBits moves landing particles and drops spent ones
+func (g *Game) updateLandingBits() {
+	bits := g.landingBits[:0]
+	for _, b := range g.landingBits {
+		if b.Life -= g.dt; b.Life <= 0 {
+			continue
+		}
+		b.X += b.VX * g.step
+		b.Y += b.VY * g.step
+		b.VX *= g.damp(0.95)
+		b.VY += b.feel.weight * g.step
+		bits = append(bits, b)
+	}
+	g.landingBits = bits
+}
+
+// drawLandingBits draws landing particles fading out
+func (g *Game) drawLandingBits(screen *ebiten.Image) {
+	for _, b := range g.landingBits {
+		a := min(1, b.Life/LandingBitLife*2)
+		c := b.feel.color
+		clr := color.RGBA{uint8(float64(c.R) * a), uint8(float64(c.G) * a), uint8(float64(c.B) * a), uint8(float64(c.A) * a)}
+		drawCircle(screen, b.X, g.screenY(b.Y), b.feel.size, clr)
+	}
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Landmark parameters
+const (
+	LandmarkChance = 0.35 // Chance a chunk above the first holds a balloon
+	BalloonRadius  = 14
+)
+
+// balloonColors are the envelope colors a balloon is picked from
+var balloonColors = []color.RGBA{
+	{230, 80, 70, 255},
+	{240, 180, 60, 255},
+	{90, 170, 230, 255},
+	{150, 100, 200, 255},
+}
+
+// Landmark is a hot-air balloon hanging in the background at a fixed
+// altitude; the same seed always puts the same balloons in the same places
+type Landmark struct {
+	X, Y  float64
+	Color color.RGBA
+	Phase float64 // Bobbing phase, so balloons don't move in step
+}
+
+// populateLandmarks is the ChunkPopulator for balloons
+func populateLandmarks(c *Chunk, rng *rand.Rand) {
+	if c.Index == 0 || rng.Float64() >= LandmarkChance {
+		return
+	}
+	c.Landmarks = append(c.Landmarks, Landmark{
+		X:     BalloonRadius + rng.Float64()*(ScreenWidth-2*BalloonRadius),
+		Y:     c.Top + rng.Float64()*ChunkHeight,
+		Color: balloonColors[rng.Intn(len(balloonColors))],
+		Phase: rng.Float64() * 2 * math.Pi,
+	})
+}
+
+// drawLandmarks draws the balloons of every loaded chunk
+func (g *Game) drawLandmarks(screen *ebiten.Image) {
+	dim := 1.0
+	if g.nightMode {
+		dim = 0.6
+	}
+	scale := func(c color.RGBA) color.RGBA {
+		return color.RGBA{uint8(float64(c.R) * dim), uint8(float64(c.G) * dim), uint8(float64(c.B) * dim), c.A}
+	}
+	rope := scale(color.RGBA{90, 70, 50, 255})
+
+	for _, c := range g.stream.Chunks() {
+		for _, l := range c.Landmarks {
+			y := g.screenY(l.Y) + math.Sin(g.gameTime*0.8+l.Phase)*3
+			if y < -3*BalloonRadius || y > ScreenHeight+BalloonRadius {
+				continue
+			}
+			basketY := y + BalloonRadius*1.8
+			drawLine(screen, l.X-BalloonRadius*0.7, y+BalloonRadius*0.6, l.X-4, basketY, rope)
+			drawLine(screen, l.X+BalloonRadius*0.7, y+BalloonRadius*0.6, l.X+4, basketY, rope)
+			drawCircle(screen, l.X, y, BalloonRadius, scale(l.Color))
+			drawRect(screen, l.X-5, basketY, 10, 6, scale(color.RGBA{140, 100, 60, 255}))
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Package leaderboard talks to an online high-score service. It submits
+// finished runs and fetches the global top list. Scores that can't be sent
+// wait in a queue file and go out with the next submission.
+//
+// The service is expected to answer two requests under its endpoint:
+//
+//	POST {endpoint}/scores                   body: a Score as JSON
+//	GET  {endpoint}/scores?limit=N[&mode=M]  response: a JSON array of Entry, best first
+//
+// Each game mode has a top list of its own; scores and queries without a
+// mode are the classic game's.
+package leaderboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client limits
+const (
+	TopSize     = 100                    // Entries Top asks for
+	MaxAttempts = 4                      // Tries per request before giving up
+	BaseBackoff = 500 * time.Millisecond // Wait after the first failure, doubled after each further one
+	MaxQueued   = 100                    // Oldest queued scores are dropped past this
+	Timeout     = 10 * time.Second       // Per attempt
+)
+
+// QueueFile holds the scores not sent yet, next to the profile's save.json
+const QueueFile = "leaderboard_queue.json"
+
+// Score is one finished run as submitted to the service
+type Score struct {
+	Name     string  `json:"name"`
+	Score    int     `json:"score"`
+	Seed     int64   `json:"seed"`
+	Duration float64 `json:"duration"`       // Seconds the run lasted
+	Mode     string  `json:"mode,omitempty"` // Game mode, "" for classic
+}
+
+// Entry is one line of the global top list
+type Entry struct {
+	Rank  int    `json:"rank"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	Seed  int64  `json:"seed"`
+}
+
+// errPermanent marks a response retrying won't fix, such as a rejected score
+var errPermanent = errors.New("rejected by the leaderboard")
+
+// Client submits scores to and reads the top list from one service
+type Client struct {
+	Endpoint  string       // Base URL of the service
+	QueuePath string       // Where unsent scores wait; "" keeps them in memory only
+	HTTP      *http.Client // Defaults to one with Timeout
+
+	mu     sync.Mutex // Serializes queue access between submissions
+	queued []Score    // The queue when QueuePath is ""
+}
+
+// New creates a client for the service at endpoint, queueing unsent
+// scores in queuePath
+func New(endpoint, queuePath string) *Client {
+	return &Client{
+		Endpoint:  strings.TrimSuffix(endpoint, "/"),
+		QueuePath: queuePath,
+		HTTP:      &http.Client{Timeout: Timeout},
+	}
+}
+
+// Submit queues s and sends everything queued, oldest first. Scores that
+// still can't be sent stay queued for the next call; the error says why.
+// An unreadable queue file is replaced rather than blocking new scores.
+func (c *Client) Submit(ctx context.Context, s Score) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	queue, err := c.loadQueue()
+	return errors.Join(err, c.flush(ctx, append(queue, s)))
+}
+
+// Flush sends the scores left queued by earlier submissions
+func (c *Client) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	queue, err := c.loadQueue()
+	if err != nil || len(queue) == 0 {
+		return err
+	}
+	return c.flush(ctx, queue)
+}
+
+// flush sends queue in order and stores whatever is left. A score the
+// service rejects outright is dropped rather than retried forever.
+func (c *Client) flush(ctx context.Context, queue []Score) error {
+	var sendErr error
+	for len(queue) > 0 {
+		err := c.retry(ctx, func() error { return c.post(ctx, queue[0]) })
+		if err != nil && !errors.Is(err, errPermanent) {
+			sendErr = err
+			break // The service is unreachable; keep the rest for later
+		}
+		if err != nil {
+			sendErr = err
+		}
+		queue = queue[1:]
+	}
+	if len(queue) > MaxQueued {
+		queue = queue[len(queue)-MaxQueued:]
+	}
+	if err := c.storeQueue(queue); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// Top fetches the best TopSize runs of mode, best first
+func (c *Client) Top(ctx context.Context, mode string) ([]Entry, error) {
+	query := url.Values{"limit": {fmt.Sprint(TopSize)}}
+	if mode != "" {
+		query.Set("mode", mode)
+	}
+	var entries []Entry
+	err := c.retry(ctx, func() error {
+		u := c.Endpoint + "/scores?" + query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errPermanent, err)
+		}
+		body, err := c.do(req)
+		if err != nil {
+			return err
+		}
+		entries = nil
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return fmt.Errorf("%w: parse top list: %v", errPermanent, err)
+		}
+		return nil
+	})
+	if len(entries) > TopSize {
+		entries = entries[:TopSize]
+	}
+	return entries, err
+}
+
+// post sends one score
+func (c *Client) post(ctx context.Context, s Score) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint+"/scores", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = c.do(req)
+	return err
+}
+
+// do runs req and returns the body of a successful response. Server errors
+// and throttling are worth retrying; other failed statuses are permanent.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return body, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, resp.Status)
+	}
+	return nil, fmt.Errorf("%w: %s %s: %s", errPermanent, req.Method, req.URL.Path, resp.Status)
+}
+
+// retry calls try up to MaxAttempts times, backing off exponentially
+// between attempts, until it succeeds, fails permanently or ctx ends
+func (c *Client) retry(ctx context.Context, try func() error) error {
+	wait := BaseBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = try(); err == nil || errors.Is(err, errPermanent) || attempt == MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// loadQueue reads the unsent scores; a missing queue file is an empty queue
+func (c *Client) loadQueue() ([]Score, error) {
+	if c.QueuePath == "" {
+		return c.queued, nil
+	}
+	raw, err := os.ReadFile(c.QueuePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var queue []Score
+	if err := json.Unmarshal(raw, &queue); err != nil {
+		return nil, fmt.Errorf("parse leaderboard queue %s: %w", c.QueuePath, err)
+	}
+	return queue, nil
+}
+
+// storeQueue replaces the unsent scores with queue, removing the file once
+// everything is sent
+func (c *Client) storeQueue(queue []Score) error {
+	if c.QueuePath == "" {
+		c.queued = queue
+		return nil
+	}
+	if len(queue) == 0 {
+		if err := os.Remove(c.QueuePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.QueuePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.QueuePath, data, 0o644)
+}
@@ -0,0 +1,250 @@
+// Package level loads and validates custom level, theme and spawn-table
+// files. The game and cmd/levellint share this code, so a file that lints
+// clean is guaranteed to load.
+package level
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// File kinds, stored in the "kind" field of every file
+const (
+	KindLevel  = "level"
+	KindTheme  = "theme"
+	KindSpawns = "spawns"
+)
+
+// Level is a playable set of rules: which theme to draw it with, what to
+// spawn and how the player moves
+type Level struct {
+	Kind    string  `json:"kind"`
+	Name    string  `json:"name"`
+	Edges   string  `json:"edges,omitempty"`   // wrap, bounce or death; empty keeps the default
+	Theme   string  `json:"theme,omitempty"`   // Theme file, relative to the level file
+	Spawns  string  `json:"spawns,omitempty"`  // Spawn-table file, relative to the level file
+	Physics Physics `json:"physics,omitempty"` // Zero values keep the built-in physics
+
+	// Resolved references; nil when the file didn't name one
+	ThemeData  *Theme      `json:"-"`
+	SpawnTable *SpawnTable `json:"-"`
+}
+
+// Physics overrides the player's movement
+type Physics struct {
+	Gravity      float64 `json:"gravity,omitempty"`       // Added to vertical velocity every tick
+	JumpVelocity float64 `json:"jump_velocity,omitempty"` // Vertical velocity after a bounce, negative is up
+}
+
+// Theme swaps the sprites a level is drawn with
+type Theme struct {
+	Kind    string            `json:"kind"`
+	Name    string            `json:"name"`
+	Sprites map[string]string `json:"sprites"`        // Sprite name to PNG file, relative to the theme file
+	Skin    string            `json:"skin,omitempty"` // Platform skin for every biome; empty follows the biome
+
+	dir string // Directory of the theme file, for resolving sprites
+}
+
+// SpriteNames are the sprites a theme may replace
+var SpriteNames = []string{
+	"player", "platform", "bird_left", "bird_right", "cloud",
+	"bird_perched_left", "bird_perched_right",
+	"platform_wooden", "platform_icy", "platform_metallic", "platform_cloud",
+}
+
+// SpritePath returns the resolved file for sprite name, or "" if the theme keeps the default
+func (t *Theme) SpritePath(name string) string {
+	p, ok := t.Sprites[name]
+	if !ok || p == "" {
+		return ""
+	}
+	return resolve(t.dir, p)
+}
+
+// SpawnTable controls what appears as the player climbs
+type SpawnTable struct {
+	Kind           string         `json:"kind"`
+	Platforms      map[string]int `json:"platforms"`        // Relative weights by platform type
+	BoostChance    float64        `json:"boost_chance"`     // Chance a recycled platform carries a boost
+	Boosts         map[string]int `json:"boosts"`           // Relative weights by boost type
+	MaxBirds       int            `json:"max_birds"`        // Bird cap at the highest difficulty
+	BirdSpeedScale float64        `json:"bird_speed_scale"` // Multiplier on every bird speed
+}
+
+// Platform and boost type names used as spawn-table keys
+var (
+	PlatformTypes = []string{"normal", "sticky", "disappearing", "spring", "gamble", "moving", "phase"}
+	BoostTypes    = []string{"speed", "jump", "shield", "multiplier"}
+)
+
+// Rules are the limits of the engine a file is validated against
+type Rules struct {
+	PlatformSpacing     float64 // Vertical distance between platforms
+	MaxBirds            int     // Hard bird cap
+	DefaultGravity      float64
+	DefaultJumpVelocity float64
+}
+
+// Issue is one problem found in a file
+type Issue struct {
+	File    string
+	Field   string // JSON path of the offending value, empty for whole-file problems
+	Message string
+}
+
+func (i Issue) Error() string {
+	if i.Field == "" {
+		return fmt.Sprintf("%s: %s", i.File, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.File, i.Field, i.Message)
+}
+
+// Issues is the error returned when a file fails validation
+type Issues []Issue
+
+func (is Issues) Error() string {
+	msgs := make([]string, len(is))
+	for i, issue := range is {
+		msgs[i] = issue.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Classic returns the built-in endless level
+func Classic() *Level {
+	return &Level{
+		Kind:       KindLevel,
+		Name:       "Classic",
+		SpawnTable: ClassicSpawns(),
+	}
+}
+
+// ClassicSpawns returns the spawn table of the original game
+func ClassicSpawns() *SpawnTable {
+	return &SpawnTable{
+		Kind:           KindSpawns,
+		Platforms:      map[string]int{"normal": 48, "sticky": 20, "disappearing": 15, "spring": 5, "gamble": 2, "moving": 6, "phase": 4},
+		BoostChance:    0.15,
+		Boosts:         map[string]int{"speed": 1, "jump": 1, "shield": 1, "multiplier": 1},
+		MaxBirds:       8,
+		BirdSpeedScale: 1,
+	}
+}
+
+// Load reads a level file with its theme and spawn table and validates all
+// of them. The returned error is an Issues listing every problem found.
+func Load(path string, rules Rules) (*Level, error) {
+	var issues Issues
+	lvl := loadLevel(path, rules, &issues)
+	if len(issues) > 0 {
+		return nil, issues
+	}
+	return lvl, nil
+}
+
+// Lint validates any level, theme or spawn-table file, following references
+func Lint(path string, rules Rules) Issues {
+	var issues Issues
+	kind, err := peekKind(path)
+	if err != nil {
+		return Issues{{File: path, Message: err.Error()}}
+	}
+	switch kind {
+	case KindLevel:
+		loadLevel(path, rules, &issues)
+	case KindTheme:
+		loadTheme(path, &issues)
+	case KindSpawns:
+		loadSpawns(path, rules, &issues)
+	default:
+		issues = append(issues, Issue{File: path, Field: "kind",
+			Message: fmt.Sprintf("unknown kind %q; want %q, %q or %q", kind, KindLevel, KindTheme, KindSpawns)})
+	}
+	return issues
+}
+
+// peekKind reads only the kind field of a file
+func peekKind(path string) (string, error) {
+	var head struct {
+		Kind string `json:"kind"`
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	if head.Kind == "" {
+		return "", fmt.Errorf(`missing "kind"; add "kind": %q, %q or %q`, KindLevel, KindTheme, KindSpawns)
+	}
+	return head.Kind, nil
+}
+
+// readJSON decodes path strictly so misspelled fields are reported
+func readJSON(path string, v any) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// resolve interprets p relative to the directory of the file that named it
+func resolve(dir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(dir, p)
+}
+
+func loadLevel(path string, rules Rules, issues *Issues) *Level {
+	lvl := &Level{}
+	if err := readJSON(path, lvl); err != nil {
+		*issues = append(*issues, Issue{File: path, Message: err.Error()})
+		return nil
+	}
+	validateLevel(path, lvl, rules, issues)
+
+	dir := filepath.Dir(path)
+	if lvl.Theme != "" {
+		lvl.ThemeData = loadTheme(resolve(dir, lvl.Theme), issues)
+	}
+	if lvl.Spawns != "" {
+		lvl.SpawnTable = loadSpawns(resolve(dir, lvl.Spawns), rules, issues)
+	} else {
+		lvl.SpawnTable = ClassicSpawns()
+	}
+	return lvl
+}
+
+func loadTheme(path string, issues *Issues) *Theme {
+	t := &Theme{}
+	if err := readJSON(path, t); err != nil {
+		*issues = append(*issues, Issue{File: path, Message: err.Error()})
+		return nil
+	}
+	t.dir = filepath.Dir(path)
+	validateTheme(path, t, issues)
+	return t
+}
+
+func loadSpawns(path string, rules Rules, issues *Issues) *SpawnTable {
+	s := &SpawnTable{}
+	if err := readJSON(path, s); err != nil {
+		*issues = append(*issues, Issue{File: path, Message: err.Error()})
+		return nil
+	}
+	validateSpawns(path, s, rules, issues)
+	return s
+}
@@ -0,0 +1,205 @@
+package level
+
+import (
+	"fmt"
+	"image"
+	_ "image/png"
+	"math"
+	"os"
+	"slices"
+	"sort"
+
+	"doodlejump/game/spritegen"
+)
+
+// ReachMargin is how much higher than the platform spacing a jump must reach,
+// so landings aren't pixel perfect
+const ReachMargin = 1.25
+
+var edgeNames = map[string]bool{"wrap": true, "bounce": true, "death": true}
+
+func validateLevel(path string, lvl *Level, rules Rules, issues *Issues) {
+	add := func(field, format string, args ...any) {
+		*issues = append(*issues, Issue{File: path, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if lvl.Kind != KindLevel {
+		add("kind", "got %q, want %q", lvl.Kind, KindLevel)
+	}
+	if lvl.Name == "" {
+		add("name", "missing; give the level a name to show in menus")
+	}
+	if lvl.Edges != "" && !edgeNames[lvl.Edges] {
+		add("edges", "unknown policy %q; want wrap, bounce or death", lvl.Edges)
+	}
+
+	gravity, jump := lvl.Physics.Resolve(rules)
+	if gravity <= 0 {
+		add("physics.gravity", "must be positive, got %g", gravity)
+	}
+	if jump >= 0 {
+		add("physics.jump_velocity", "must be negative (up), got %g", jump)
+	}
+	if gravity > 0 && jump < 0 {
+		// Peak height of a ballistic jump: v^2 / 2g
+		height := jump * jump / (2 * gravity)
+		need := rules.PlatformSpacing * ReachMargin
+		if height < need {
+			add("physics", "jump reaches %.0fpx but platforms are %.0fpx apart; raise jump_velocity to at least %.2f or lower gravity to at most %.3f",
+				height, rules.PlatformSpacing, -math.Sqrt(2*gravity*need), jump*jump/(2*need))
+		}
+	}
+}
+
+// Resolve fills unset physics values with the engine defaults
+func (p Physics) Resolve(rules Rules) (gravity, jumpVelocity float64) {
+	gravity, jumpVelocity = rules.DefaultGravity, rules.DefaultJumpVelocity
+	if p.Gravity != 0 {
+		gravity = p.Gravity
+	}
+	if p.JumpVelocity != 0 {
+		jumpVelocity = p.JumpVelocity
+	}
+	return gravity, jumpVelocity
+}
+
+func validateTheme(path string, t *Theme, issues *Issues) {
+	add := func(field, format string, args ...any) {
+		*issues = append(*issues, Issue{File: path, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t.Kind != KindTheme {
+		add("kind", "got %q, want %q", t.Kind, KindTheme)
+	}
+	if t.Name == "" {
+		add("name", "missing; give the theme a name to show in menus")
+	}
+	if t.Skin != "" && !slices.Contains(spritegen.PlatformSkins, t.Skin) {
+		add("skin", "unknown skin %q; want one of %v", t.Skin, spritegen.PlatformSkins)
+	}
+
+	known := map[string]bool{}
+	for _, name := range SpriteNames {
+		known[name] = true
+	}
+	for _, name := range sortedKeys(t.Sprites) {
+		field := "sprites." + name
+		if !known[name] {
+			add(field, "unknown sprite; want one of %v", SpriteNames)
+			continue
+		}
+		file := t.SpritePath(name)
+		if file == "" {
+			add(field, "empty path; remove the entry to keep the default sprite")
+			continue
+		}
+		if err := checkPNG(file); err != nil {
+			add(field, "%v", err)
+		}
+	}
+}
+
+// checkPNG makes sure file exists and decodes as a PNG
+func checkPNG(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("missing asset %s", file)
+	}
+	defer f.Close()
+	if _, format, err := image.DecodeConfig(f); err != nil || format != "png" {
+		return fmt.Errorf("asset %s is not a valid PNG", file)
+	}
+	return nil
+}
+
+func validateSpawns(path string, s *SpawnTable, rules Rules, issues *Issues) {
+	add := func(field, format string, args ...any) {
+		*issues = append(*issues, Issue{File: path, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Kind != KindSpawns {
+		add("kind", "got %q, want %q", s.Kind, KindSpawns)
+	}
+
+	validateWeights(s.Platforms, PlatformTypes, "platforms", add)
+	solid := s.Platforms["normal"] + s.Platforms["sticky"] + s.Platforms["spring"] + s.Platforms["gamble"] + s.Platforms["moving"]
+	if s.Platforms["disappearing"] > 0 && solid == 0 {
+		add("platforms", "only disappearing platforms spawn, so the player can never stand still; give normal, sticky, spring, gamble or moving a weight")
+	}
+
+	if s.BoostChance < 0 || s.BoostChance > 1 {
+		add("boost_chance", "must be between 0 and 1, got %g", s.BoostChance)
+	}
+	if s.BoostChance > 0 {
+		validateWeights(s.Boosts, BoostTypes, "boosts", add)
+	}
+
+	if s.MaxBirds < 0 || s.MaxBirds > rules.MaxBirds {
+		add("max_birds", "must be between 0 and %d, got %d", rules.MaxBirds, s.MaxBirds)
+	}
+	if s.BirdSpeedScale <= 0 || s.BirdSpeedScale > 4 {
+		add("bird_speed_scale", "must be above 0 and at most 4, got %g", s.BirdSpeedScale)
+	}
+}
+
+// validateWeights checks a weight table uses known keys, has no negative
+// weights and can pick at least one entry
+func validateWeights(weights map[string]int, names []string, field string, add func(field, format string, args ...any)) {
+	known := map[string]bool{}
+	for _, n := range names {
+		known[n] = true
+	}
+	total := 0
+	for _, name := range sortedKeys(weights) {
+		w := weights[name]
+		if !known[name] {
+			add(field+"."+name, "unknown type; want one of %v", names)
+			continue
+		}
+		if w < 0 {
+			add(field+"."+name, "weight must not be negative, got %d", w)
+			continue
+		}
+		total += w
+	}
+	if total == 0 {
+		add(field, "no weights set, nothing can spawn; give at least one of %v a positive weight", names)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Pick chooses a key from weights using roll in [0, 1). Keys are tried in
+// the order of names so the same roll always picks the same entry.
+func Pick(weights map[string]int, names []string, roll float64) string {
+	total := 0
+	for _, n := range names {
+		if w := weights[n]; w > 0 {
+			total += w
+		}
+	}
+	if total == 0 {
+		return names[0]
+	}
+	target := roll * float64(total)
+	last := names[0]
+	for _, n := range names {
+		w := weights[n]
+		if w <= 0 {
+			continue
+		}
+		if target < float64(w) {
+			return n
+		}
+		target -= float64(w)
+		last = n
+	}
+	return last
+}
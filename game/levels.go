@@ -0,0 +1,113 @@
+package game
+
+import (
+	"image"
+	"log"
+	"os"
+
+	"doodlejump/game/level"
+)
+
+// LevelRules are the engine limits custom levels are validated against
+func LevelRules() level.Rules {
+	return DefaultGameConfig().levelRules()
+}
+
+// loadLevel reads the level at path, falling back to the classic level
+// when it fails validation so a bad file never blocks the game. The
+// classic level takes its spawn rates from the tuning.
+func loadLevel(path string, t GameConfig) *level.Level {
+	classic := func() *level.Level {
+		lvl := level.Classic()
+		lvl.SpawnTable.BoostChance = t.BoostSpawnChance
+		lvl.SpawnTable.MaxBirds = t.MaxBirds
+		return lvl
+	}
+	if path == "" {
+		return classic()
+	}
+	lvl, err := level.Load(path, t.levelRules())
+	if err != nil {
+		log.Printf("Failed to load level %s, playing classic instead:\n%v", path, err)
+		return classic()
+	}
+	return lvl
+}
+
+// applyLevel installs the level's rules and, unless headless, its sprites
+func (g *Game) applyLevel(lvl *level.Level, headless bool) {
+	g.level = lvl
+	g.difficultyCurve = newDifficultyProfile(lvl, g.tuning)
+	g.gravity, g.jumpVelocity = lvl.Physics.Resolve(g.tuning.levelRules())
+	if lvl.Edges != "" {
+		if p, err := ParseEdgePolicy(lvl.Edges); err == nil {
+			g.world.Edges = p
+		}
+	}
+	g.birdSpeedMin *= lvl.SpawnTable.BirdSpeedScale
+	g.birdSpeedMax *= lvl.SpawnTable.BirdSpeedScale
+	g.loadBirdHitboxes()
+
+	if headless || lvl.ThemeData == nil {
+		return
+	}
+	// A theme's own platform replaces the built-in skins it doesn't supply
+	if lvl.ThemeData.SpritePath(EntityPlatform) != "" {
+		g.sprites.clearVariants(EntityPlatform)
+	}
+	for _, name := range level.SpriteNames {
+		if file := lvl.ThemeData.SpritePath(name); file != "" {
+			if img := loadImageFile(file); img != nil {
+				g.sprites.Register(spriteKeyFor(name), g.sprites.upload(img))
+			}
+		}
+	}
+}
+
+// loadImageFile loads a sprite from disk, returning nil if it can't be read
+func loadImageFile(path string) image.Image {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("Keeping default sprite: %v", err)
+		return nil
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		log.Printf("Keeping default sprite: decode %s: %v", path, err)
+		return nil
+	}
+	return img
+}
+
+// platformType maps a spawn-table platform name to its type
+func platformType(name string) int {
+	switch name {
+	case "sticky":
+		return PlatformSticky
+	case "disappearing":
+		return PlatformDisappearing
+	case "spring":
+		return PlatformSpring
+	case "gamble":
+		return PlatformGamble
+	case "moving":
+		return PlatformMoving
+	case "phase":
+		return PlatformPhase
+	}
+	return PlatformNormal
+}
+
+// boostType maps a spawn-table boost name to its type
+func boostType(name string) int {
+	switch name {
+	case "jump":
+		return BoostJump
+	case "shield":
+		return BoostShield
+	case "multiplier":
+		return BoostMultiplier
+	}
+	return BoostSpeed
+}
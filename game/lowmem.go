@@ -0,0 +1,57 @@
+package game
+
+import (
+	"image"
+	"image/color"
+)
+
+// LowMemoryRAM is the total RAM under which the launcher turns the
+// low-memory profile on by itself
+const LowMemoryRAM = 2 << 30
+
+// Low-memory caps on a run's history
+const (
+	LowMemoryReplayFrames = 3 * 60 * 60 // Frames a replay keeps: the last three minutes or so
+	LowMemoryTraceSamples = 2000        // Trajectory samples before the trace is thinned out
+)
+
+// WithLowMemory plays on low-end devices: sprites are kept at half
+// resolution, the mountains go without their cached lighting, and replays
+// and trajectories are capped
+func WithLowMemory() Option {
+	return func(o *gameOptions) {
+		o.lowMemory = true
+	}
+}
+
+// SmallMemory reports whether the machine has less than LowMemoryRAM in
+// all, false where the total can't be read
+func SmallMemory() bool {
+	total, ok := totalMemory()
+	return ok && total < LowMemoryRAM
+}
+
+// halfImage shrinks img to half its size, averaging each 2x2 block
+func halfImage(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	half := image.NewRGBA(image.Rect(0, 0, (b.Dx()+1)/2, (b.Dy()+1)/2))
+	for y := 0; y < half.Rect.Dy(); y++ {
+		for x := 0; x < half.Rect.Dx(); x++ {
+			var sum [4]uint32
+			n := uint32(0)
+			for dy := 0; dy < 2; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					px, py := b.Min.X+2*x+dx, b.Min.Y+2*y+dy
+					if px >= b.Max.X || py >= b.Max.Y {
+						continue
+					}
+					r, g, bl, a := img.At(px, py).RGBA() // Premultiplied, so averaging keeps edges clean
+					sum[0], sum[1], sum[2], sum[3] = sum[0]+r, sum[1]+g, sum[2]+bl, sum[3]+a
+					n++
+				}
+			}
+			half.SetRGBA64(x, y, color.RGBA64{uint16(sum[0] / n), uint16(sum[1] / n), uint16(sum[2] / n), uint16(sum[3] / n)})
+		}
+	}
+	return half
+}
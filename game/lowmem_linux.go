@@ -0,0 +1,26 @@
+package game
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// totalMemory reads the machine's RAM in bytes from /proc/meminfo
+func totalMemory() (uint64, bool) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text()) // "MemTotal: 16318460 kB"
+		if len(fields) == 3 && fields[0] == "MemTotal:" && fields[2] == "kB" {
+			kb, err := strconv.ParseUint(fields[1], 10, 64)
+			return kb << 10, err == nil
+		}
+	}
+	return 0, false
+}
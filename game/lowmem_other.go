@@ -0,0 +1,8 @@
+//go:build !linux && !windows
+
+package game
+
+// totalMemory can't tell the machine's RAM here
+func totalMemory() (uint64, bool) {
+	return 0, false
+}
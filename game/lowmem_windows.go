@@ -0,0 +1,31 @@
+package game
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var globalMemoryStatusEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GlobalMemoryStatusEx")
+
+// memoryStatusEx is the MEMORYSTATUSEX structure
+type memoryStatusEx struct {
+	length               uint32
+	memoryLoad           uint32
+	totalPhys            uint64
+	availPhys            uint64
+	totalPageFile        uint64
+	availPageFile        uint64
+	totalVirtual         uint64
+	availVirtual         uint64
+	availExtendedVirtual uint64
+}
+
+// totalMemory asks Windows for the machine's RAM in bytes
+func totalMemory() (uint64, bool) {
+	var s memoryStatusEx
+	s.length = uint32(unsafe.Sizeof(s))
+	if ok, _, _ := globalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&s))); ok == 0 {
+		return 0, false
+	}
+	return s.totalPhys, true
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MaxAltitudeMarkers caps how many flags a leaderboard can plant
+const MaxAltitudeMarkers = 10
+
+// AltitudeMarker is someone's best climb, shown as a flag during a run
+type AltitudeMarker struct {
+	Name     string
+	Altitude float64 // Meters
+	Friend   bool    // Friends get a different flag color than global entries
+}
+
+// Leaderboard supplies markers from an online service. The game ships
+// without one; embedders pass theirs with WithLeaderboard.
+type Leaderboard interface {
+	// TopAltitudes may block on the network; it is called off the game loop
+	TopAltitudes() ([]AltitudeMarker, error)
+}
+
+// WithLeaderboard shows friends' and global best altitudes from lb as flags,
+// when the player has opted in with the LeaderboardMarkers setting
+func WithLeaderboard(lb Leaderboard) Option {
+	return func(o *gameOptions) {
+		o.leaderboard = lb
+	}
+}
+
+// markerBoard fetches markers once in the background; restart keeps it so
+// the service isn't asked again every run
+type markerBoard struct {
+	fetched chan []AltitudeMarker
+	list    []AltitudeMarker
+}
+
+// newMarkerBoard starts fetching from lb, or returns nil without one
+func newMarkerBoard(lb Leaderboard) *markerBoard {
+	if lb == nil {
+		return nil
+	}
+	m := &markerBoard{fetched: make(chan []AltitudeMarker, 1)}
+	go func() {
+		list, err := lb.TopAltitudes()
+		if err != nil {
+			log.Printf("Leaderboard unavailable, playing without markers: %v", err)
+		}
+		if len(list) > MaxAltitudeMarkers {
+			list = list[:MaxAltitudeMarkers]
+		}
+		m.fetched <- list
+	}()
+	return m
+}
+
+// markers returns what has arrived so far
+func (m *markerBoard) markers() []AltitudeMarker {
+	if m == nil {
+		return nil
+	}
+	select {
+	case list := <-m.fetched:
+		m.list = list
+	default:
+	}
+	return m.list
+}
+
+// drawAltitudeMarkers plants a flag at the right edge for every marker on
+// screen, labelled with its owner
+func (g *Game) drawAltitudeMarkers(screen *ebiten.Image) {
+	if !g.save.Settings.LeaderboardMarkers {
+		return
+	}
+	if g.markerBoard == nil {
+		// Only contact the service once the player has opted in
+		g.markerBoard = newMarkerBoard(g.opts.leaderboard)
+	}
+	for _, m := range g.markerBoard.markers() {
+		y := g.screenY(ScreenHeight - m.Altitude*PixelsPerMeter)
+		if y < -TextSmall || y > ScreenHeight {
+			continue
+		}
+		flag := color.RGBA{255, 200, 60, 220}
+		if m.Friend {
+			flag = color.RGBA{90, 220, 140, 220}
+		}
+		pole := float64(ScreenWidth - 4)
+		drawLine(screen, pole, y, pole, y+16, color.RGBA{220, 220, 220, 200})
+		drawRect(screen, pole-10, y, 10, 6, flag)
+		drawTextAlpha(screen, m.Name, pole-12-textWidth(m.Name, TextSmall), y, TextSmall, textColor, 0.8)
+	}
+}
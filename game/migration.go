@@ -0,0 +1,137 @@
+package game
+
+import (
+	"math"
+
+	"doodlejump/game/physics"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Migration parameters
+const (
+	FlockSize        = 9   // Birds per formation
+	FlockSpacingX    = 18  // Horizontal gap between ranks of the V
+	FlockSpacingY    = 10  // How far each rank trails behind the one ahead
+	FlockSpeed       = 2.2 // Pixels per frame across the screen
+	FlockBirdScale   = 0.5 // Migrating birds are drawn at half size
+	FlockKnockback   = 3.0 // Downward speed a collision knocks the player to
+	FlockPush        = 2.5 // Sideways shove from a collision
+	FlockFollowDelay = 2.5 // Seconds between the two flocks of a migration
+)
+
+// TimerFlock releases the second flock of a migration
+const TimerFlock timerName = "flock"
+
+// Day phases that start a migration
+const (
+	phaseOther = iota
+	phaseDawn
+	phaseDusk
+)
+
+// Flock is a V formation of migrating birds crossing the screen. Flocks
+// don't kill; they knock the player about, which can still cost a landing.
+type Flock struct {
+	X, Y      float64 // World position of the lead bird
+	Direction int
+	Hit       [FlockSize]bool // Birds already bumped into, so each shoves only once
+}
+
+// dayPhase classifies a time of day for migrations
+func dayPhase(timeOfDay float64) int {
+	switch {
+	case timeOfDay >= SunriseStart && timeOfDay < SunriseEnd:
+		return phaseDawn
+	case timeOfDay >= SunsetStart && timeOfDay < SunsetEnd:
+		return phaseDusk
+	}
+	return phaseOther
+}
+
+// member returns the world position of bird i of the formation
+func (f *Flock) member(i int) (float64, float64) {
+	rank := float64((i + 1) / 2)
+	side := 1.0
+	if i%2 == 0 {
+		side = -1
+	}
+	// Ranks trail behind the leader and fan out above and below
+	return f.X - float64(f.Direction)*rank*FlockSpacingX, f.Y + side*rank*FlockSpacingY
+}
+
+// memberRect is the hitbox of bird i
+func (f *Flock) memberRect(i int) physics.Rect {
+	x, y := f.member(i)
+	w, h := BirdWidth*FlockBirdScale, BirdHeight*FlockBirdScale
+	return physics.Rect{X: x - w/2, Y: y - h/2, W: w, H: h}
+}
+
+// updateMigration starts a migration of two flocks whenever dawn or dusk
+// begins, then flies and retires the flocks
+func (g *Game) updateMigration() {
+	if phase := dayPhase(g.timeOfDay()); phase != g.migrationPhase {
+		g.migrationPhase = phase
+		if phase != phaseOther {
+			spawnFlock(g)
+			g.timers.Add(TimerFlock, FlockFollowDelay, spawnFlock)
+			g.events.Publish(Event{Kind: EventMigration, Value: phase})
+		}
+	}
+
+	for i := 0; i < len(g.flocks); i++ {
+		f := g.flocks[i]
+		f.X += FlockSpeed * float64(f.Direction) * g.step
+
+		// The formation is gone once its last rank has left the far side
+		tail := float64(FlockSize/2) * FlockSpacingX
+		if f.Direction > 0 && f.X-tail > ScreenWidth+BirdWidth || f.Direction < 0 && f.X+tail < -BirdWidth {
+			g.flocks[i] = g.flocks[len(g.flocks)-1]
+			g.flocks = g.flocks[:len(g.flocks)-1]
+			i--
+			continue
+		}
+
+		for j := range f.Hit {
+			if f.Hit[j] || !g.hitsPlayer(f.memberRect(j)) {
+				continue
+			}
+			f.Hit[j] = true
+			g.player.VelocityY = math.Max(g.player.VelocityY, FlockKnockback)
+			g.player.VelocityX += FlockPush * float64(f.Direction)
+			g.feedback(FeedbackShieldHit)
+		}
+	}
+}
+
+// spawnFlock sends a formation across the upper half of the screen from a
+// random side. Zen has no birds, migrating or not.
+func spawnFlock(g *Game) {
+	if g.mode == ModeZen {
+		return
+	}
+	f := &Flock{Direction: 1, X: -BirdWidth}
+	if g.rng.Float64() < 0.5 {
+		f.Direction, f.X = -1, ScreenWidth+BirdWidth
+	}
+	f.Y = g.worldTop() + ScreenHeight*(0.15+g.rng.Float64()*0.35)
+	g.flocks = append(g.flocks, f)
+}
+
+// drawFlocks draws every migrating bird small and bobbing
+func (g *Game) drawFlocks(screen *ebiten.Image) {
+	for _, f := range g.flocks {
+		img := g.sprites.Lookup(SpriteKey{Entity: EntityBird, Facing: facingOf(f.Direction)})
+		for i := 0; i < FlockSize; i++ {
+			x, y := f.member(i)
+			bob := math.Sin(g.gameTime*10+float64(i)) * 1.5
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Scale(FlockBirdScale, FlockBirdScale)
+			op.GeoM.Translate(x-BirdWidth*FlockBirdScale/2, g.screenY(y)-BirdHeight*FlockBirdScale/2+bob)
+			if g.nightMode {
+				op.ColorM.Scale(0.7, 0.7, 0.8, 1)
+			}
+			g.drawSprite(screen, img, op)
+		}
+	}
+}
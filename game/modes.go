@@ -0,0 +1,130 @@
+package game
+
+import (
+	"log"
+	"strconv"
+
+	"doodlejump/game/level"
+)
+
+// TimeAttackSeconds is how long a time attack run lasts
+const TimeAttackSeconds = 120.0
+
+// Mode is the goal of a run
+type Mode int
+
+const (
+	ModeClassic    Mode = iota // Score as much as possible before falling
+	ModeTimeAttack             // Climb as high as possible in TimeAttackSeconds
+	ModeZen                    // Climb at leisure: no birds and no breaking platforms
+	modeCount
+)
+
+var modeNames = [modeCount]string{
+	ModeClassic:    "Classic",
+	ModeTimeAttack: "Time Attack",
+	ModeZen:        "Zen",
+}
+
+// modeBoards name each mode's top list on the leaderboard service; classic
+// runs go without a mode, as they did before there were modes. Zen runs
+// aren't ranked.
+var modeBoards = [modeCount]string{
+	ModeClassic:    "",
+	ModeTimeAttack: "time_attack",
+}
+
+func (m Mode) String() string {
+	if m < 0 || m >= modeCount {
+		return "Unknown"
+	}
+	return modeNames[m]
+}
+
+// WithMode plays mode m instead of the one picked on the title screen
+func WithMode(m Mode) Option {
+	return func(o *gameOptions) {
+		o.mode, o.modeSet = m, true
+	}
+}
+
+// picksMode reports whether the player's pick on the title decides the
+// mode. Sessions with rules of their own always play classic.
+func (g *Game) picksMode() bool {
+	o := g.opts
+	return !o.modeSet && !o.sandbox && !o.idle && o.tournament == nil && o.hotseat == nil && o.kiosk == nil && o.race == nil && o.bench == nil
+}
+
+// resolveMode settles the mode of the game once its save is loaded
+func (g *Game) resolveMode() Mode {
+	switch {
+	case g.opts.modeSet:
+		return g.opts.mode
+	case g.picksMode() && g.save.Mode >= 0 && g.save.Mode < modeCount:
+		return g.save.Mode
+	}
+	return ModeClassic
+}
+
+// selectMode moves the title's mode pick by step and rebuilds the game on it
+func (g *Game) selectMode(step int) {
+	g.save.Mode = (g.mode + Mode(step) + modeCount) % modeCount
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+	g.restartWith()
+	g.scenes.Switch(titleScene)
+	if b := g.onlineLeaderboard(); b != nil && g.mode.ranked() {
+		go b.refresh(modeBoards[g.mode])
+	}
+}
+
+// ranked reports whether runs of mode m go to the online leaderboard
+func (m Mode) ranked() bool {
+	return m != ModeZen
+}
+
+// calmLevel takes the danger out of lvl for zen: its spawn table no longer
+// sends birds or lays disappearing platforms. Weather and the day cycle
+// are left as they are.
+func calmLevel(lvl *level.Level) {
+	platforms := make(map[string]int, len(lvl.SpawnTable.Platforms))
+	for name, w := range lvl.SpawnTable.Platforms {
+		if name != "disappearing" {
+			platforms[name] = w
+		}
+	}
+	lvl.SpawnTable.Platforms = platforms
+	lvl.SpawnTable.MaxBirds = 0
+}
+
+// timeUp ends a run when its time limit runs out
+func timeUp(g *Game) {
+	g.endRun(DeathTime)
+}
+
+// scoresHeight reports whether the mode's result is the height reached
+// rather than points
+func (m Mode) scoresHeight() bool {
+	return m == ModeTimeAttack || m == ModeZen
+}
+
+// scoreLine is the run's result so far: points, or height in the modes
+// that score it
+func (g *Game) scoreLine() string {
+	if g.mode.scoresHeight() {
+		return "Height: " + strconv.Itoa(g.score) + "m"
+	}
+	return "Score: " + strconv.Itoa(g.score)
+}
+
+// best is the profile's best result at mode m
+func (s *SaveData) best(m Mode) int {
+	switch m {
+	case ModeTimeAttack:
+		return s.AttackBest
+	case ModeZen:
+		return s.ZenBest
+	}
+	return s.BestScore
+}
@@ -0,0 +1,146 @@
+package game
+
+// PlatformSlots is how many items fit side by side on a platform
+const PlatformSlots = 3
+
+// Platform slots, left to right
+const (
+	SlotLeft = iota
+	SlotMiddle
+	SlotRight
+)
+
+// Mount ties an item to a slot on a platform: each slot holds at most one
+// item, and the item rides along when its platform moves
+type Mount struct {
+	Platform int  // Index in platforms of the platform carrying the item
+	Slot     int  // SlotLeft to SlotRight
+	Mounted  bool // False for loose items, which stay where they are
+}
+
+// slotX is the world X of the middle of slot s on p
+func (p *Platform) slotX(s int) float64 {
+	return p.X + (float64(s)+0.5)*PlatformWidth/PlatformSlots
+}
+
+// on reports whether m holds slot s of platform i
+func (m Mount) on(i, s int) bool {
+	return m.Mounted && m.Platform == i && m.Slot == s
+}
+
+// slotTaken reports whether an item sits in slot s of platform i. A bird
+// perched on the platform takes every slot.
+func (g *Game) slotTaken(i, s int) bool {
+	for j := range g.boosts {
+		if g.boosts[j].Active && g.boosts[j].Mount.on(i, s) {
+			return true
+		}
+	}
+	for _, c := range g.cannons {
+		if c.Mount.on(i, s) {
+			return true
+		}
+	}
+	for _, c := range g.campfires {
+		if c.Mount.on(i, s) {
+			return true
+		}
+	}
+	return g.perchTaken(i)
+}
+
+// freeSlot picks a free slot of platform i: prefer if it is free, else the
+// first free one, false when the platform is full
+func (g *Game) freeSlot(i, prefer int) (int, bool) {
+	if !g.slotTaken(i, prefer) {
+		return prefer, true
+	}
+	for s := range PlatformSlots {
+		if !g.slotTaken(i, s) {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// bare reports whether nothing sits on platform i
+func (g *Game) bare(i int) bool {
+	for s := range PlatformSlots {
+		if g.slotTaken(i, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// nearestSlot is the slot of p under world X x
+func (p *Platform) nearestSlot(x float64) int {
+	s := int((x - p.X) / (PlatformWidth / PlatformSlots))
+	return min(max(s, 0), PlatformSlots-1)
+}
+
+// mountNear rests boost b in the free slot nearest it on a platform just
+// below, false when there is no platform with room
+func (g *Game) mountNear(b *Boost) bool {
+	for i := range g.platforms {
+		p := &g.platforms[i]
+		if b.X < p.X || b.X > p.X+PlatformWidth || b.Y < p.Y-PlatformHeight*4 || b.Y > p.Y+PlatformHeight {
+			continue
+		}
+		if s, ok := g.freeSlot(i, p.nearestSlot(b.X)); ok {
+			b.X, b.Y, b.Mount = p.slotX(s), p.Y-PlatformHeight*2, Mount{Platform: i, Slot: s, Mounted: true}
+			return true
+		}
+	}
+	return false
+}
+
+// unmount leaves everything on platform i, which is being recycled, loose
+// where it is
+func (g *Game) unmount(i int) {
+	for j := range g.boosts {
+		if m := &g.boosts[j].Mount; m.Mounted && m.Platform == i {
+			m.Mounted = false
+		}
+	}
+	for _, c := range g.cannons {
+		if c.Mount.Mounted && c.Mount.Platform == i {
+			c.Mount.Mounted = false
+		}
+	}
+	for j := range g.campfires {
+		if m := &g.campfires[j].Mount; m.Mounted && m.Platform == i {
+			m.Mounted = false
+		}
+	}
+}
+
+// followMounts keeps every mounted item in its slot, after the platforms
+// moved this tick
+func (g *Game) followMounts() {
+	for j := range g.boosts {
+		b := &g.boosts[j]
+		if p, ok := g.mountedOn(b.Mount); ok {
+			b.X, b.Y = p.slotX(b.Mount.Slot), p.Y-PlatformHeight*2
+		}
+	}
+	for _, c := range g.cannons {
+		if p, ok := g.mountedOn(c.Mount); ok {
+			c.X, c.Y = p.slotX(c.Mount.Slot), p.Y-CannonRadius
+		}
+	}
+	for j := range g.campfires {
+		c := &g.campfires[j]
+		if p, ok := g.mountedOn(c.Mount); ok {
+			c.X, c.Y = p.slotX(c.Mount.Slot), p.Y
+		}
+	}
+}
+
+// mountedOn is the platform m sits on, false for loose items
+func (g *Game) mountedOn(m Mount) (*Platform, bool) {
+	if !m.Mounted || m.Platform < 0 || m.Platform >= len(g.platforms) {
+		return nil, false
+	}
+	return &g.platforms[m.Platform], true
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"image"
+	"math"
+	"math/rand"
+
+	"doodlejump/game/spritegen"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Mountain lighting parameters, in mountain image pixels
+const (
+	RimWidth      = 10   // Depth of the rim light below the ridge
+	RimStrength   = 0.8  // Rim alpha when a slope faces the light head on
+	LightBuckets  = 48   // Lighting is recomputed this many times per day cycle
+	SnowBuckets   = 10   // Snow cover steps that trigger a recompute
+	SnowLineDry   = 0.36 // Snow line as a fraction of layer height in clear weather
+	SnowLineWet   = 0.52 // Snow line after a long snowfall
+	SnowCoverRate = 0.02 // Snow cover gained per second of snowfall
+	SnowMeltRate  = 0.01 // Snow cover lost per second otherwise
+)
+
+// mountainLayer is one generated skyline with its lighting overlay. Unlit
+// layers keep only the skyline.
+type mountainLayer struct {
+	img     *ebiten.Image
+	src     *image.RGBA // CPU copy for alpha lookups when relighting
+	ridge   []int       // First opaque row per column
+	overlay *ebiten.Image
+	pix     []byte // Overlay staging buffer, reused between relights
+}
+
+// Mountains holds the skyline for a run and its cached lighting
+type Mountains struct {
+	layers    []mountainLayer
+	snowCover float64 // 0 bare peaks, 1 fully snowed in
+	lightKey  int     // Bucket the overlays were last computed for
+	snowKey   int
+}
+
+// newMountains draws the mountain layers for a run. Each layer has its
+// own stream derived from the run seed, so scenery is unique per run but
+// reproducible, and never consumes the gameplay rng. Without lit the
+// layers skip the rim light and snow caps and the buffers they're cached in.
+func newMountains(seed int64, lit bool) *Mountains {
+	m := &Mountains{lightKey: -1, snowKey: -1}
+	for i := 0; i < MountainCount; i++ {
+		rng := rand.New(rand.NewSource(seed + int64(i)))
+		src := spritegen.Mountain(
+			spritegen.MountainWidth,
+			spritegen.MountainHeight,
+			spritegen.Classic.Mountains[i],
+			spritegen.MountainRoughness(i),
+			rng,
+		)
+		layer := mountainLayer{img: ebiten.NewImageFromImage(src)}
+		if lit {
+			layer.src = src
+			layer.ridge = ridgeLine(src)
+			layer.overlay = ebiten.NewImage(spritegen.MountainWidth, spritegen.MountainHeight)
+			layer.pix = make([]byte, len(src.Pix))
+		}
+		m.layers = append(m.layers, layer)
+	}
+	return m
+}
+
+// ridgeLine finds the silhouette of a layer
+func ridgeLine(img *image.RGBA) []int {
+	b := img.Bounds()
+	ridge := make([]int, b.Dx())
+	for x := range ridge {
+		ridge[x] = b.Dy()
+		for y := 0; y < b.Dy(); y++ {
+			if img.RGBAAt(x, y).A > 0 {
+				ridge[x] = y
+				break
+			}
+		}
+	}
+	return ridge
+}
+
+// Update grows snow caps over dt seconds while it snows and melts them
+// otherwise
+func (m *Mountains) Update(weather int, dt float64) {
+	if weather == WeatherSnow {
+		m.snowCover = math.Min(1, m.snowCover+SnowCoverRate*dt)
+	} else {
+		m.snowCover = math.Max(0, m.snowCover-SnowMeltRate*dt)
+	}
+}
+
+// celestialLight returns where the sun or moon is (-1 far left to 1 far
+// right), how strong it is and its color for timeOfDay
+func celestialLight(timeOfDay float64) (x, strength float64, r, g, b float64) {
+	if timeOfDay < SunsetEnd {
+		// Sun rises on the left and sets on the right
+		p := (timeOfDay - SunriseStart) / (SunsetEnd - SunriseStart)
+		warm := math.Abs(p*2 - 1) // Redder near the horizon
+		return p*2 - 1, 0.6 + 0.4*math.Sin(p*math.Pi), 1, 0.95 - 0.25*warm, 0.8 - 0.4*warm
+	}
+	// Moon crosses during the night phase
+	p := (timeOfDay - SunsetEnd) / (1 - SunsetEnd + SunriseStart)
+	return p*2 - 1, 0.5, 0.7, 0.8, 1
+}
+
+// relight recomputes the overlays when the time or snow bucket changes
+func (m *Mountains) relight(timeOfDay float64) {
+	if len(m.layers) == 0 || m.layers[0].overlay == nil {
+		return // Unlit
+	}
+	lightKey := int(timeOfDay * LightBuckets)
+	snowKey := int(m.snowCover * SnowBuckets)
+	if lightKey == m.lightKey && snowKey == m.snowKey {
+		return
+	}
+	m.lightKey, m.snowKey = lightKey, snowKey
+
+	lightX, strength, lr, lg, lb := celestialLight(timeOfDay)
+	snow := float64(snowKey) / SnowBuckets
+	for i := range m.layers {
+		m.layers[i].relight(lightX, strength, lr, lg, lb, snow)
+	}
+}
+
+// relight paints rim light and snow caps into the layer overlay
+func (l *mountainLayer) relight(lightX, strength, lr, lg, lb, snow float64) {
+	clear(l.pix)
+	w, h := spritegen.MountainWidth, spritegen.MountainHeight
+	snowLine := int(float64(h) * (SnowLineDry + (SnowLineWet-SnowLineDry)*snow))
+
+	for x := 0; x < w; x++ {
+		top := l.ridge[x]
+		if top >= h {
+			continue
+		}
+
+		// Horizontal facing of the slope: positive faces right
+		left, right := l.ridge[max(x-2, 0)], l.ridge[min(x+2, w-1)]
+		facing := math.Tanh(float64(right-left) / 4)
+		lit := math.Max(0, facing*lightX) * strength
+
+		// Snow reaches further down gentle slopes; a little per-column wobble
+		// keeps the edge from looking ruled
+		capEnd := snowLine + int(6*math.Sin(float64(x)*0.13)) - int(math.Abs(facing)*8)
+
+		for y := top; y < h && (y < top+RimWidth || y < capEnd); y++ {
+			baseA := float64(l.src.RGBAAt(x, y).A) / 255
+			var r, g, b, a float64
+
+			if y < capEnd {
+				// Snow is bright white, tinted by the light on lit slopes
+				shade := 0.85 + 0.15*lit
+				r, g, b = shade, shade, shade*1.02
+				a = 0.9
+			}
+			if y < top+RimWidth && lit > 0 {
+				rim := lit * RimStrength * (1 - float64(y-top)/RimWidth)
+				r = r*(1-rim) + lr*rim
+				g = g*(1-rim) + lg*rim
+				b = b*(1-rim) + lb*rim
+				a = math.Max(a, rim)
+			}
+
+			a *= baseA
+			o := (y*w + x) * 4
+			// Premultiplied alpha, as ebiten expects
+			l.pix[o] = uint8(math.Min(1, r) * a * 255)
+			l.pix[o+1] = uint8(math.Min(1, g) * a * 255)
+			l.pix[o+2] = uint8(math.Min(1, b) * a * 255)
+			l.pix[o+3] = uint8(a * 255)
+		}
+	}
+	l.overlay.WritePixels(l.pix)
+}
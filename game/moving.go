@@ -0,0 +1,78 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Moving platform parameters
+const (
+	MovingSpeedMin  = 0.6   // Slowest a moving platform slides, in pixels per frame
+	MovingSpeedMax  = 1.6   // And fastest
+	MovingRangeMin  = 60.0  // Narrowest patrol, in pixels of travel
+	MovingRangeMax  = 180.0 // And widest
+	MovingRampScore = 300   // Climb score at which moving platforms reach their full weight
+)
+
+// velocity is how far p slides a frame, 0 for platforms that stay put
+func (p *Platform) velocity() float64 {
+	if p.Type != PlatformMoving {
+		return 0
+	}
+	return p.Speed * float64(p.Direction)
+}
+
+// patrol sets moving platform p sliding from where it is, at a random
+// speed and heading, within a random range clamped to the screen
+func (p *Platform) patrol(rng *rand.Rand) {
+	p.Speed = MovingSpeedMin + rng.Float64()*(MovingSpeedMax-MovingSpeedMin)
+	p.Direction = 1
+	if rng.Float64() < 0.5 {
+		p.Direction = -1
+	}
+	reach := MovingRangeMin + rng.Float64()*(MovingRangeMax-MovingRangeMin)
+	p.MinX = max(0, p.X-reach/2)
+	p.MaxX = min(ScreenWidth-PlatformWidth, p.X+reach/2)
+}
+
+// slide moves p step frames along its patrol, turning at either end
+func (p *Platform) slide(step float64) {
+	if p.Type != PlatformMoving {
+		return
+	}
+	p.X += p.velocity() * step
+	switch {
+	case p.X <= p.MinX:
+		p.X, p.Direction = p.MinX, 1
+	case p.X >= p.MaxX:
+		p.X, p.Direction = p.MaxX, -1
+	}
+}
+
+// movingWeights returns weights with the moving platforms' weight ramped
+// in over the first MovingRampScore of climb score, so they start rare
+// and grow common; the rest are weights as they are
+func movingWeights(weights map[string]int, score int) map[string]int {
+	w, ok := weights["moving"]
+	if !ok || score >= MovingRampScore {
+		return weights
+	}
+	ramped := make(map[string]int, len(weights))
+	for name, v := range weights {
+		ramped[name] = v
+	}
+	ramped["moving"] = w * max(0, score) / MovingRampScore
+	return ramped
+}
+
+// drawMovingPlatform draws a moving platform tinted violet
+func (g *Game) drawMovingPlatform(screen *ebiten.Image, img *ebiten.Image, p *Platform, py float64) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(p.X, py)
+	if g.nightMode {
+		op.ColorM.Scale(0.7, 0.7, 0.9, 1)
+	}
+	op.ColorM.Scale(0.8, 0.6, 1.3, 1)
+	g.drawSprite(screen, img, op)
+}
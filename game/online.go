@@ -0,0 +1,126 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+
+	"doodlejump/game/leaderboard"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TitleTopEntries is how many of the global top list the title shows
+const TitleTopEntries = 5
+
+// WithOnlineLeaderboard submits every finished run to the leaderboard
+// service at endpoint and shows its global top list on the title screen
+func WithOnlineLeaderboard(endpoint string) Option {
+	return func(o *gameOptions) {
+		o.onlineURL = endpoint
+	}
+}
+
+// onlineBoard is the game's link to the leaderboard service. Requests run
+// in the background; restart keeps it so queued scores aren't sent twice.
+type onlineBoard struct {
+	client *leaderboard.Client
+
+	mu  sync.Mutex
+	top map[string][]leaderboard.Entry // Latest top list of each mode fetched, best first
+}
+
+// newOnlineBoard starts sending what earlier sessions left queued and
+// fetching the top list of mode
+func newOnlineBoard(c *leaderboard.Client, mode string) *onlineBoard {
+	b := &onlineBoard{client: c, top: map[string][]leaderboard.Entry{}}
+	go func() {
+		if err := c.Flush(context.Background()); err != nil {
+			log.Printf("Leaderboard: some queued scores are still unsent: %v", err)
+		}
+		b.refresh(mode)
+	}()
+	return b
+}
+
+// refresh fetches the top list of mode, keeping the old one if that fails
+func (b *onlineBoard) refresh(mode string) {
+	top, err := b.client.Top(context.Background(), mode)
+	if err != nil {
+		log.Printf("Leaderboard unavailable: %v", err)
+		return
+	}
+	b.mu.Lock()
+	b.top[mode] = top
+	b.mu.Unlock()
+}
+
+// submit sends s in the background, then refetches the top list so it
+// shows the new score. An unsent score stays queued for the next run.
+func (b *onlineBoard) submit(s leaderboard.Score) {
+	go func() {
+		if err := b.client.Submit(context.Background(), s); err != nil {
+			log.Printf("Leaderboard: score queued for later: %v", err)
+			return
+		}
+		b.refresh(s.Mode)
+	}()
+}
+
+// entries returns the latest top list of mode
+func (b *onlineBoard) entries(mode string) []leaderboard.Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.top[mode]
+}
+
+// onlineLeaderboard returns the game's leaderboard link, connecting on
+// first use; nil when no service is configured
+func (g *Game) onlineLeaderboard() *onlineBoard {
+	if g.online == nil && g.opts.onlineURL != "" {
+		queue := ""
+		if g.save.path != "" {
+			queue = filepath.Join(filepath.Dir(g.save.path), leaderboard.QueueFile)
+		}
+		g.online = newOnlineBoard(leaderboard.New(g.opts.onlineURL, queue), modeBoards[g.mode])
+	}
+	return g.online
+}
+
+// submitRun reports the run that just ended. Only classic runs count:
+// custom levels, tuning, Hardcore and shared sessions play a different game.
+func (g *Game) submitRun() {
+	o := g.opts
+	if o.level != "" || o.hardcore || o.tournament != nil || o.hotseat != nil || g.tuning != DefaultGameConfig() || !g.mode.ranked() {
+		return
+	}
+	if b := g.onlineLeaderboard(); b != nil {
+		b.submit(leaderboard.Score{Name: o.profile, Score: g.score, Seed: g.seed, Duration: g.gameTime, Mode: modeBoards[g.mode]})
+	}
+}
+
+// drawOnlineTop lists the head of the mode's global top list on the
+// title, with the profile's own rank if it made the list
+func (g *Game) drawOnlineTop(screen *ebiten.Image, y float64) {
+	b := g.onlineLeaderboard()
+	if b == nil || !g.mode.ranked() {
+		return
+	}
+	top := b.entries(modeBoards[g.mode])
+	if len(top) == 0 {
+		drawTextCentered(screen, "World Top: connecting...", y, TextSmall, textColor)
+		return
+	}
+	drawTextCentered(screen, "World Top", y, TextSmall, textColor)
+	for i, e := range top[:min(len(top), TitleTopEntries)] {
+		drawTextCentered(screen, fmt.Sprintf("%d. %s  %d", e.Rank, e.Name, e.Score), y+float64(i+1)*textLineHeight, TextSmall, textColor)
+	}
+	for _, e := range top {
+		if e.Name == g.opts.profile {
+			drawTextCentered(screen, fmt.Sprintf("You: #%d", e.Rank), y+float64(TitleTopEntries+1)*textLineHeight, TextSmall, textColor)
+			break
+		}
+	}
+}
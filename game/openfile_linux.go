@@ -0,0 +1,8 @@
+package game
+
+import "os/exec"
+
+// openFile shows path in the desktop's viewer for its type
+func openFile(path string) error {
+	return exec.Command("xdg-open", path).Start()
+}
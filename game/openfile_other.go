@@ -0,0 +1,10 @@
+//go:build !linux && !windows
+
+package game
+
+import "os/exec"
+
+// openFile shows path in the viewer macOS opens its type with
+func openFile(path string) error {
+	return exec.Command("open", path).Start()
+}
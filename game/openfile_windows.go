@@ -0,0 +1,8 @@
+package game
+
+import "os/exec"
+
+// openFile shows path in the program Windows opens its type with
+func openFile(path string) error {
+	return exec.Command("rundll32", "url.dll,FileProtocolHandler", path).Start()
+}
@@ -0,0 +1,265 @@
+package game
+
+import (
+	"time"
+
+	"doodlejump/game/config"
+	"doodlejump/game/input"
+)
+
+// DefaultRenderScale is the window size multiplier used by the launcher
+const DefaultRenderScale = 2
+
+// Option customizes a Game created by NewGame
+type Option func(*gameOptions)
+
+// gameOptions collects everything an Option can set
+type gameOptions struct {
+	seed        int64
+	seeded      bool
+	settings    *Settings
+	controller  input.Source
+	renderScale float64
+	scaleSet    bool // renderScale was asked for rather than the window's last size
+	headless    bool
+	edges       EdgePolicy
+	profile     string
+	profileSet  bool // profile was asked for, so even a headless game keeps its save
+	level       string
+	hardcore    bool
+	sandbox     bool
+	tournament  *Tournament
+	hotseat     *Hotseat
+	coop        bool
+	idle        bool
+	config      *config.Config
+	tuning      GameConfig
+	leaderboard Leaderboard
+	onlineURL   string // Endpoint of the leaderboard service, "" for none
+	replay      *replayViewer
+	tickRate    int      // Ticks per second the replay was recorded at, 0 for the config's
+	daily       string   // UTC day of the daily challenge played, "" outside one
+	dailyBase   []Option // Options to go back to on leaving the challenge
+	kiosk       *Kiosk
+	attract     []Option // Options to go back to on leaving the attract demo
+	mode        Mode
+	modeSet     bool
+	race        *Race // Split-screen race the game is one player's half of
+	seat        int   // Player of the race, from 0
+	bench       *benchScenario
+	ghost       *ghostRace
+	lowMemory   bool
+	summary     SessionSummary
+}
+
+// WithSeed makes the run deterministic: the same seed produces the same
+// platforms, birds, boosts and weather
+func WithSeed(seed int64) Option {
+	return func(o *gameOptions) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// WithSettings overrides the settings stored in the save file for this game
+func WithSettings(s Settings) Option {
+	return func(o *gameOptions) {
+		o.settings = &s
+	}
+}
+
+// WithController drives the player from src instead of the keyboard and mouse
+func WithController(src input.Source) Option {
+	return func(o *gameOptions) {
+		o.controller = src
+	}
+}
+
+// WithRenderScale sets the window size multiplier reported by WindowSize,
+// instead of the size the window last had. Scales that aren't positive
+// leave it to the window's last size.
+func WithRenderScale(scale float64) Option {
+	return func(o *gameOptions) {
+		if scale > 0 {
+			o.renderScale, o.scaleSet = scale, true
+		}
+	}
+}
+
+// WithHeadless runs the simulation without loading sprites, audio or
+// haptics; Draw must not be called. Useful for tests, bots and servers.
+// Unless WithProfile or WithConfig say otherwise, it plays on a fresh
+// save kept in memory and the default settings.json preferences, so the
+// machine's files neither steer it nor record it.
+func WithHeadless() Option {
+	return func(o *gameOptions) {
+		o.headless = true
+	}
+}
+
+// WithEdgePolicy selects how the screen sides behave
+func WithEdgePolicy(p EdgePolicy) Option {
+	return func(o *gameOptions) {
+		o.edges = p
+	}
+}
+
+// WithProfile loads and writes the save of the named profile, so several
+// players can share one machine
+func WithProfile(name string) Option {
+	return func(o *gameOptions) {
+		o.profile, o.profileSet = name, true
+	}
+}
+
+// WithLevel plays the custom level file at path instead of the classic game
+func WithLevel(path string) Option {
+	return func(o *gameOptions) {
+		o.level = path
+	}
+}
+
+// WithHardcore turns on Hardcore rules: a warmth meter that the cold of
+// high altitude drains and sun and campfires refill
+func WithHardcore() Option {
+	return func(o *gameOptions) {
+		o.hardcore = true
+	}
+}
+
+// WithSandbox starts a sandbox: nothing ends the run, and a palette
+// places platforms, birds and boosts wherever the mouse is clicked
+func WithSandbox() Option {
+	return func(o *gameOptions) {
+		o.sandbox = true
+	}
+}
+
+// WithTournament plays the turns of t on its seed, showing the standings
+// between runs. t is shared by every restart, so it keeps the bracket.
+func WithTournament(t *Tournament) Option {
+	return func(o *gameOptions) {
+		o.tournament = t
+		o.seed = t.Seed
+		o.seeded = true
+	}
+}
+
+// WithHotseat plays the turns of a pass-and-play session, each player with
+// their handicap, showing the scoreboard between runs. Like a tournament,
+// h is shared by every restart.
+func WithHotseat(h *Hotseat) Option {
+	return func(o *gameOptions) {
+		o.hotseat = h
+		o.seed = h.RoundSeed()
+		o.seeded = true
+	}
+}
+
+// WithCoop splits the avatar between two players: one flies it from the
+// keyboard or first gamepad, the other aims and shoots with the mouse or
+// second gamepad. It has no effect when WithController supplies the input.
+func WithCoop() Option {
+	return func(o *gameOptions) {
+		o.coop = true
+	}
+}
+
+// WithIdle runs the game as a screensaver: a bot plays endless runs with
+// the HUD hidden, music low and fewer frames drawn. Runs aren't saved.
+func WithIdle() Option {
+	return func(o *gameOptions) {
+		o.idle = true
+	}
+}
+
+// WithConfig overrides the settings.json preferences for this game, which
+// then never writes them
+func WithConfig(c config.Config) Option {
+	return func(o *gameOptions) {
+		o.config = &c
+	}
+}
+
+// WithGameConfig plays with tuning c instead of the built-in values
+func WithGameConfig(c GameConfig) Option {
+	return func(o *gameOptions) {
+		o.tuning = c
+	}
+}
+
+// resolveOptions applies opts over the defaults
+func resolveOptions(opts []Option) gameOptions {
+	o := gameOptions{
+		renderScale: DefaultRenderScale,
+		edges:       defaultWorld().Edges,
+		profile:     DefaultProfile,
+		tuning:      DefaultGameConfig(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.seeded {
+		o.seed = time.Now().UnixNano()
+	}
+	return o
+}
+
+// State is a snapshot of a run for embedders
+type State struct {
+	Score      int
+	Breakdown  ScoreBreakdown // Score by category
+	BestScore  int
+	Altitude   int // Meters climbed
+	Difficulty int
+	GameOver   bool
+	PlayerX    float64 // World coordinates
+	PlayerY    float64
+	Seed       int64
+}
+
+// State returns a snapshot of the current run
+func (g *Game) State() State {
+	return State{
+		Score:      g.score,
+		Breakdown:  g.scoreBy,
+		BestScore:  g.save.BestScore,
+		Altitude:   g.altitude(),
+		Difficulty: g.difficulty,
+		GameOver:   g.gameOver,
+		PlayerX:    g.player.X,
+		PlayerY:    g.player.Y,
+		Seed:       g.seed,
+	}
+}
+
+// Score returns the current run's score
+func (g *Game) Score() int {
+	return g.score
+}
+
+// IsGameOver reports whether the current run has ended
+func (g *Game) IsGameOver() bool {
+	return g.gameOver
+}
+
+// Seed returns the seed of the current run
+func (g *Game) Seed() int64 {
+	return g.seed
+}
+
+// WindowSize returns the window size matching the render scale
+func (g *Game) WindowSize() (int, int) {
+	scale := g.windowScale()
+	return int(ScreenWidth * scale), int(ScreenHeight * scale)
+}
+
+// Fullscreen reports whether the settings ask for a fullscreen window
+func (g *Game) Fullscreen() bool {
+	return g.config.Fullscreen
+}
+
+// Restart abandons the current run and starts a new one with the same options
+func (g *Game) Restart() {
+	g.restart()
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"doodlejump/game/achievements"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Parental control parameters
+const (
+	PINLength      = 4        // Digits of the parental PIN
+	SessionWarning = 5 * 60.0 // Seconds before the session limit the HUD starts counting down
+)
+
+// Choices of the settings screen, in minutes; 0 is off
+var (
+	sessionLimits  = []int{0, 15, 30, 45, 60, 90, 120}
+	breakIntervals = []int{0, 15, 20, 30, 45, 60}
+)
+
+// playSession is the play since launch, for the parental limits and the
+// session summary. It outlives the games rebuilt for every run, and
+// switching profiles doesn't start it over.
+type playSession struct {
+	played    float64 // Seconds spent in runs
+	lastBreak float64 // played when the last break reminder came up
+	reminder  bool    // The pause screen is showing a break reminder
+
+	started      time.Time
+	runs         int // Runs finished
+	best         int // Best score of those runs
+	achievements []achievements.Achievement
+}
+
+// countsSession reports whether this game's runs are the player's play
+// time: the screensaver's, replays and kiosks don't count, and a race
+// counts once, on player one's game
+func (g *Game) countsSession() bool {
+	return !g.opts.idle && g.replayView == nil && g.opts.kiosk == nil && g.opts.seat == 0
+}
+
+// countPlay adds a tick to the session and pauses for a break reminder
+// when one is due
+func (g *Game) countPlay() {
+	if !g.countsSession() {
+		return
+	}
+	s := g.session
+	s.played += g.dt
+	if every := g.save.Settings.BreakEvery; every > 0 && s.played-s.lastBreak >= float64(every)*60 {
+		s.lastBreak = s.played
+		s.reminder = true
+		g.scenes.Switch(pauseScene)
+		g.feedback(FeedbackUIClick)
+	}
+}
+
+// sessionLeft is the play time left before the profile's session limit,
+// +Inf without one
+func (g *Game) sessionLeft() float64 {
+	limit := g.save.Settings.SessionLimit
+	if limit == 0 || !g.countsSession() {
+		return math.Inf(1)
+	}
+	return max(0, float64(limit)*60-g.session.played)
+}
+
+// canStartRun reports whether a new run may start. Past the session limit
+// the run in progress plays out, but no new one begins.
+func (g *Game) canStartRun() bool {
+	return g.sessionLeft() > 0
+}
+
+// sessionLines count the session down near its end and announce the
+// last run after it
+func sessionLines(g *Game) []hudLine {
+	left := g.sessionLeft()
+	switch {
+	case left > SessionWarning || g.gameOver:
+		return nil
+	case left == 0:
+		return []hudLine{{"Last run of the session", TextSmall}}
+	}
+	return []hudLine{{fmt.Sprintf("Session: %d min left", int(math.Ceil(left/60))), TextSmall}}
+}
+
+// breakLine is the pause screen's reminder
+func (g *Game) breakLine() string {
+	return fmt.Sprintf("You've played %d minutes. Time for a break?", int(g.session.played/60))
+}
+
+// hashPIN is what the save keeps of a PIN, so it can't simply be read out
+func hashPIN(pin string) string {
+	sum := sha256.Sum256([]byte("godlejump-pin:" + pin))
+	return hex.EncodeToString(sum[:])
+}
+
+// askPIN opens next, asking for the parental PIN first if the profile has one
+func (g *Game) askPIN(next Scene) {
+	if g.save.Settings.PINHash == "" {
+		g.scenes.Switch(next)
+		return
+	}
+	g.pinNext, g.pinSetting, g.pinEntry, g.pinWrong = next, false, "", false
+	g.scenes.Switch(pinScene)
+}
+
+// setPIN has the player choose a new PIN, back to the settings after
+func (g *Game) setPIN() {
+	g.pinNext, g.pinSetting, g.pinEntry, g.pinWrong = settingsScene, true, "", false
+	g.scenes.Switch(pinScene)
+}
+
+// cycleChoice steps cur through choices by dir, wrapping around
+func cycleChoice[T comparable](choices []T, cur T, dir int) T {
+	i := max(0, slices.Index(choices, cur))
+	return choices[(i+dir+len(choices))%len(choices)]
+}
+
+// minutes labels a choice of minutes
+func minutes(m int) string {
+	if m == 0 {
+		return "Off"
+	}
+	return fmt.Sprintf("%d min", m)
+}
+
+// scenePIN takes the parental PIN, to check it or to set a new one. It
+// reads typed digits rather than actions.
+type scenePIN struct{}
+
+func (scenePIN) Update(g *Game) error {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r >= '0' && r <= '9' && len(g.pinEntry) < PINLength {
+			g.pinEntry += string(r)
+			g.pinWrong = false
+		}
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		if g.pinSetting {
+			g.scenes.Switch(settingsScene)
+		} else {
+			g.scenes.Switch(titleScene)
+		}
+		g.feedback(FeedbackUIClick)
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && g.pinEntry != "":
+		g.pinEntry = g.pinEntry[:len(g.pinEntry)-1]
+	case len(g.pinEntry) < PINLength:
+	case g.pinSetting:
+		g.save.Settings.PINHash = hashPIN(g.pinEntry) // Written on leaving the settings
+		g.scenes.Switch(g.pinNext)
+		g.feedback(FeedbackUIClick)
+	case hashPIN(g.pinEntry) == g.save.Settings.PINHash:
+		g.scenes.Switch(g.pinNext)
+		g.feedback(FeedbackUIClick)
+	default:
+		g.pinEntry, g.pinWrong = "", true
+	}
+	return nil
+}
+
+func (scenePIN) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	title := "Enter the parental PIN"
+	if g.pinSetting {
+		title = "Choose a parental PIN"
+	}
+	drawTextCentered(screen, title, ScreenHeight/3, TextSmall, textColor)
+	entry := strings.Repeat("* ", len(g.pinEntry)) + strings.Repeat("_ ", PINLength-len(g.pinEntry))
+	drawTextCentered(screen, strings.TrimSpace(entry), ScreenHeight/3+30, TextLarge, color.RGBA{255, 220, 100, 255})
+	if g.pinWrong {
+		drawTextCentered(screen, "Wrong PIN", ScreenHeight/3+60, TextSmall, color.RGBA{255, 120, 120, 255})
+	}
+	drawTextCentered(screen, "Type "+fmt.Sprint(PINLength)+" digits  ESC: back", ScreenHeight-40, TextSmall, textColor)
+}
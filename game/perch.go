@@ -0,0 +1,137 @@
+package game
+
+import "math"
+
+// Perching parameters
+const (
+	PerchChance  = 0.15  // Chance per second that a bird on screen looks for a platform to land on
+	PerchReach   = 140.0 // Pixels ahead of and below a bird that it looks for a platform in
+	PerchGlide   = 2.5   // Pixels per frame a landing bird covers
+	PerchTimeMin = 1.5   // Seconds a bird sits on its platform, at the least
+	PerchTimeMax = 3.0   // And at the most
+)
+
+// perchSpot is where a bird sits on platform p: in the middle, feet on top
+func perchSpot(p *Platform) (x, y float64) {
+	return p.X + (PlatformWidth-BirdWidth)/2, p.Y - BirdHeight
+}
+
+// perchable reports whether birds may land on p. Only plain and moving
+// platforms hold them; anything that breaks, bounces or pays out would
+// throw them off.
+func perchable(p *Platform) bool {
+	return p.Type == PlatformNormal || p.Type == PlatformMoving
+}
+
+// leavePerch puts b back in flight wherever it is
+func (b *Bird) leavePerch() {
+	b.Landing, b.Perched = false, 0
+}
+
+// perchOf is the platform b is landing on or sits on, false when it's
+// gone from under it
+func (g *Game) perchOf(b *Bird) (*Platform, bool) {
+	if b.Perch < 0 || b.Perch >= len(g.platforms) || !perchable(&g.platforms[b.Perch]) {
+		return nil, false
+	}
+	return &g.platforms[b.Perch], true
+}
+
+// releasePerch sends off every bird on platform i, which is being recycled
+func (g *Game) releasePerch(i int) {
+	for j := range g.birds {
+		if b := &g.birds[j]; b.Perch == i && (b.Landing || b.Perched > 0) {
+			b.leavePerch()
+		}
+	}
+}
+
+// findPerch picks a platform for b to land on: the nearest plain one on
+// screen, ahead of it and below within PerchReach, with nothing on it
+func (g *Game) findPerch(b *Bird) (int, bool) {
+	best, bestDist := -1, math.Inf(1)
+	for i := range g.platforms {
+		p := &g.platforms[i]
+		if sy := g.screenY(p.Y); !perchable(p) || sy < BirdHeight || sy > ScreenHeight || !g.bare(i) {
+			continue
+		}
+		x, y := perchSpot(p)
+		ahead, below := (x-b.X)*float64(b.Direction), y-b.Y
+		if ahead <= 0 || ahead > PerchReach || below < 0 || below > PerchReach {
+			continue
+		}
+		if d := math.Hypot(ahead, below); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best, best >= 0
+}
+
+// perchTaken reports whether a bird sits on or is landing on platform i
+func (g *Game) perchTaken(i int) bool {
+	for _, b := range g.birds {
+		if b.Perch == i && (b.Landing || b.Perched > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// updatePerch now and then lands bird b on a platform, glides it down,
+// keeps it there for a couple of seconds and sends it off again. It
+// reports whether b is perched or landing, so it doesn't fly this tick.
+func (g *Game) updatePerch(b *Bird) bool {
+	if !b.Landing && b.Perched == 0 {
+		if b.Wave || g.rng.Float64() >= PerchChance*g.dt {
+			return false
+		}
+		i, ok := g.findPerch(b)
+		if !ok {
+			return false
+		}
+		b.Perch, b.Landing = i, true
+	}
+	p, ok := g.perchOf(b)
+	if !ok {
+		b.leavePerch()
+		return false
+	}
+	x, y := perchSpot(p)
+	if b.Landing {
+		dx, dy := x-b.X, y-b.Y
+		if d, glide := math.Hypot(dx, dy), PerchGlide*g.step; d > glide {
+			b.X += dx / d * glide
+			b.Y += dy / d * glide
+			return true
+		}
+		b.Landing = false
+		b.Perched = PerchTimeMin + g.rng.Float64()*(PerchTimeMax-PerchTimeMin)
+	}
+	b.X, b.Y = x, y
+	if b.Perched -= g.dt; b.Perched <= 0 {
+		b.leavePerch() // Off again the way it was flying
+	}
+	return true
+}
+
+// stomps reports whether the player comes down on perched bird b from
+// above, which knocks it off instead of ending the run
+func (g *Game) stomps(b *Bird) bool {
+	if b.Perched == 0 || g.player.VelocityY <= 0 {
+		return false
+	}
+	bottom := g.player.Y + PlayerHeight/4 - g.player.VelocityY*g.step // The body's bottom the tick before
+	return bottom <= g.birdRect(b).Y
+}
+
+// stompBird knocks perched bird b off its platform, bouncing the player
+// like a landing would
+func (g *Game) stompBird(b *Bird) {
+	g.feedbackAt(FeedbackKill, b.X+BirdWidth/2, b.Y+BirdHeight/2)
+	g.addScore(ScoreKills, KillScore)
+	g.events.Publish(Event{Kind: EventKill, Points: KillScore, X: b.X, Y: b.Y})
+	b.leavePerch()
+	b.Y = g.worldTop() - BirdHeight*2 // Off screen, to be regenerated
+	g.player.VelocityY = g.jumpVelocity
+	g.feedback(FeedbackLanding)
+}
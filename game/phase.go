@@ -0,0 +1,54 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Phase platform parameters
+const (
+	PhaseOnTime  = 2.0 // Seconds a phase platform is solid
+	PhaseOffTime = 1.2 // Seconds it is gone
+	PhaseWarn    = 0.5 // Seconds before fading that it starts to flicker
+	PhaseGhost   = 0.2 // Opacity of a phase platform while it is gone
+)
+
+// solid reports whether p catches whatever lands on it right now: broken
+// platforms and phase platforms in their off phase let it fall through
+func (p *Platform) solid() bool {
+	return !(p.Type == PlatformDisappearing && p.State == PlatformBroken) && p.State != PlatformFaded
+}
+
+// blink advances phase platform p dt seconds along its on/off cycle
+func (p *Platform) blink(dt float64) {
+	if p.Type != PlatformPhase {
+		return
+	}
+	p.Phase = math.Mod(p.Phase+dt, PhaseOnTime+PhaseOffTime)
+	p.State = PlatformIntact
+	if p.Phase >= PhaseOnTime {
+		p.State = PlatformFaded
+	}
+}
+
+// drawPhasePlatform draws a phase platform cyan: solid while on, flickering
+// as it is about to fade, and faint while off
+func (g *Game) drawPhasePlatform(screen *ebiten.Image, img *ebiten.Image, p *Platform, py float64) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(p.X, py)
+	if g.nightMode {
+		op.ColorM.Scale(0.7, 0.7, 0.9, 1)
+	}
+	op.ColorM.Scale(0.5, 1.1, 1.3, 1)
+	switch {
+	case p.State == PlatformFaded:
+		op.ColorM.Scale(1, 1, 1, PhaseGhost)
+	case p.Phase < PhaseOnTime-PhaseWarn: // Steady for most of its on phase
+	case g.save.Settings.ReducedMotion:
+		op.ColorM.Scale(1, 1, 1, 0.6) // Dimmed steadily instead of flickering
+	case math.Sin(g.gameTime*12*math.Pi) > 0:
+		op.ColorM.Scale(1, 1, 1, PhaseGhost)
+	}
+	g.drawSprite(screen, img, op)
+}
@@ -0,0 +1,119 @@
+// Package physics holds the geometric collision tests shared by the game
+// and its previews. It knows nothing about players or platforms, only
+// shapes and motion, so it can be reasoned about (and reused) on its own.
+package physics
+
+import "math"
+
+// Rect is an axis-aligned box with its top-left corner at X, Y. Edges are
+// inclusive, matching the hand-written comparisons it replaced.
+type Rect struct {
+	X, Y, W, H float64
+}
+
+// Overlaps reports whether r and o share any point
+func (r Rect) Overlaps(o Rect) bool {
+	return r.X <= o.X+o.W && o.X <= r.X+r.W &&
+		r.Y <= o.Y+o.H && o.Y <= r.Y+r.H
+}
+
+// Contains reports whether the point x, y lies in r
+func (r Rect) Contains(x, y float64) bool {
+	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
+}
+
+// Segment tests the segment from x0, y0 to x1, y1 against r with the slab
+// method. It returns the fraction of the segment at which it enters r (0
+// if it starts inside) and whether it touches r at all.
+func Segment(x0, y0, x1, y1 float64, r Rect) (float64, bool) {
+	tMin, tMax := 0.0, 1.0
+	if !slab(x0, x1-x0, r.X, r.X+r.W, &tMin, &tMax) ||
+		!slab(y0, y1-y0, r.Y, r.Y+r.H, &tMin, &tMax) {
+		return 0, false
+	}
+	return tMin, true
+}
+
+// slab narrows [tMin, tMax] to where p + t*d lies within [lo, hi]
+func slab(p, d, lo, hi float64, tMin, tMax *float64) bool {
+	if d == 0 {
+		return p >= lo && p <= hi
+	}
+	t0, t1 := (lo-p)/d, (hi-p)/d
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	*tMin = math.Max(*tMin, t0)
+	*tMax = math.Min(*tMax, t1)
+	return *tMin <= *tMax
+}
+
+// Sweep tests box m moving by dx, dy against static box r, so movers
+// faster than the thing they hit can't tunnel through it. It returns the
+// fraction of the move at which they first touch.
+func Sweep(m Rect, dx, dy float64, r Rect) (float64, bool) {
+	// Shrink m to its corner and grow r by m's size (a Minkowski sum), which
+	// turns the box sweep into a segment test
+	grown := Rect{r.X - m.W, r.Y - m.H, r.W + m.W, r.H + m.H}
+	return Segment(m.X, m.Y, m.X+dx, m.Y+dy, grown)
+}
+
+// Circle is a disc centered at X, Y
+type Circle struct {
+	X, Y, R float64
+}
+
+// closest returns the point of r nearest to x, y
+func (r Rect) closest(x, y float64) (float64, float64) {
+	return math.Max(r.X, math.Min(x, r.X+r.W)), math.Max(r.Y, math.Min(y, r.Y+r.H))
+}
+
+// CircleRect reports whether c and r share any point
+func CircleRect(c Circle, r Rect) bool {
+	px, py := r.closest(c.X, c.Y)
+	dx, dy := c.X-px, c.Y-py
+	return dx*dx+dy*dy <= c.R*c.R
+}
+
+// SweepCircle tests disc c moving by dx, dy against static box r. The
+// center's path is tested against r grown by the radius with rounded
+// corners, which is exactly the set of centers where the two touch.
+func SweepCircle(c Circle, dx, dy float64, r Rect) (float64, bool) {
+	t, hit := math.Inf(1), false
+	try := func(ti float64, ok bool) {
+		if ok && ti < t {
+			t, hit = ti, true
+		}
+	}
+	x1, y1 := c.X+dx, c.Y+dy
+	try(Segment(c.X, c.Y, x1, y1, Rect{r.X - c.R, r.Y, r.W + 2*c.R, r.H}))
+	try(Segment(c.X, c.Y, x1, y1, Rect{r.X, r.Y - c.R, r.W, r.H + 2*c.R}))
+	for _, corner := range [4][2]float64{{r.X, r.Y}, {r.X + r.W, r.Y}, {r.X, r.Y + r.H}, {r.X + r.W, r.Y + r.H}} {
+		try(segmentCircle(c.X, c.Y, dx, dy, Circle{corner[0], corner[1], c.R}))
+	}
+	if !hit {
+		return 0, false
+	}
+	return t, true
+}
+
+// segmentCircle finds where the segment from x, y along dx, dy first
+// enters disc c
+func segmentCircle(x, y, dx, dy float64, c Circle) (float64, bool) {
+	ox, oy := x-c.X, y-c.Y
+	cc := ox*ox + oy*oy - c.R*c.R
+	if cc <= 0 {
+		return 0, true // Starts inside
+	}
+	a := dx*dx + dy*dy
+	if a == 0 {
+		return 0, false
+	}
+	b := ox*dx + oy*dy
+	disc := b*b - a*cc
+	if disc < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(disc)) / a
+	return t, t >= 0 && t <= 1
+}
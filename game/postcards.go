@@ -0,0 +1,185 @@
+package game
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+
+	"doodlejump/game/i18n"
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Postcard display parameters
+const (
+	PostcardSeconds = 4.0 // How long a postcard stays up
+	PostcardFade    = 0.5 // Seconds of fade in and out
+	postcardWidth   = 220
+	postcardHeight  = 110
+)
+
+// Postcard is a collectible card for a major altitude. Its title and
+// flavor text are the i18n keys postcard.<ID>.title and postcard.<ID>.text.
+type Postcard struct {
+	ID        string
+	Biome     string // Biome the card shows
+	Milestone int    // Altitude milestone that sends it; 0 sends it on entering Biome
+}
+
+// postcards are every card in gallery order
+var postcards = []Postcard{
+	{ID: "sky", Biome: "sky"},
+	{ID: "frost", Biome: "frost"},
+	{ID: "orbit", Biome: "orbit"},
+	{ID: "km1", Biome: "orbit", Milestone: 1000},
+	{ID: "km2", Biome: "orbit", Milestone: 2000},
+}
+
+// biomeColors tint each biome's postcards
+var biomeColors = map[string]color.RGBA{
+	"meadow": {120, 190, 90, 255},
+	"sky":    {120, 180, 240, 255},
+	"frost":  {200, 225, 245, 255},
+	"orbit":  {30, 30, 70, 255},
+}
+
+// postcardFor returns the card e sends, if any
+func postcardFor(e Event) (Postcard, bool) {
+	for _, p := range postcards {
+		switch {
+		case e.Kind == EventBiome && p.Milestone == 0 && biomes[e.Value].Name == p.Biome,
+			e.Kind == EventMilestone && p.Milestone != 0 && e.Value == p.Milestone:
+			return p, true
+		}
+	}
+	return Postcard{}, false
+}
+
+// postcardShow is the card on screen and how long it has left
+type postcardShow struct {
+	card  Postcard
+	timer float64
+}
+
+// showPostcard puts up and collects the card an event sends. It is
+// subscribed to the run's event bus.
+func (g *Game) showPostcard(e Event) {
+	p, ok := postcardFor(e)
+	if !ok {
+		return
+	}
+	g.postcard = &postcardShow{card: p, timer: PostcardSeconds}
+	if !g.opts.idle && g.replayView == nil && !slices.Contains(g.save.Postcards, p.ID) {
+		g.save.Postcards = append(g.save.Postcards, p.ID) // Written with the run
+	}
+}
+
+// updatePostcard counts the card on screen down
+func (g *Game) updatePostcard() {
+	if g.postcard == nil {
+		return
+	}
+	if g.postcard.timer -= g.dt; g.postcard.timer <= 0 {
+		g.postcard = nil
+	}
+}
+
+// drawPostcard draws the card on screen, fading at both ends
+func (g *Game) drawPostcard(screen *ebiten.Image) {
+	if g.postcard == nil {
+		return
+	}
+	t := g.postcard.timer
+	alpha := min(1, t/PostcardFade, (PostcardSeconds-t)/PostcardFade)
+	x := float64(ScreenWidth-postcardWidth) / 2
+	g.drawCard(screen, g.postcard.card, x, 60, 1, alpha, true)
+}
+
+// drawCard draws card p at x, y, scale times the full postcard size.
+// Locked cards are drawn blank, with a question mark.
+func (g *Game) drawCard(screen *ebiten.Image, p Postcard, x, y, scale, alpha float64, unlocked bool) {
+	fade := func(c color.RGBA) color.RGBA {
+		return color.RGBA{uint8(float64(c.R) * alpha), uint8(float64(c.G) * alpha), uint8(float64(c.B) * alpha), uint8(float64(c.A) * alpha)}
+	}
+	w, h := postcardWidth*scale, postcardHeight*scale
+	drawRect(screen, x, y, w, h, fade(color.RGBA{245, 240, 225, 255}))
+	if !unlocked {
+		drawText(screen, "?", x+w/2-4, y+h/2-6, TextLarge, fade(textOutline))
+		return
+	}
+
+	// The picture: the biome's sky with one of its platforms, and a stamp
+	pic := 6 * scale
+	drawRect(screen, x+pic, y+pic, w/2, h-2*pic, fade(biomeColors[p.Biome]))
+	if img := g.sprites.Lookup(SpriteKey{Entity: EntityPlatform, Variant: biomeSkin(p.Biome)}); img != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale*0.6, scale*0.6)
+		op.GeoM.Translate(x+pic+w/4-PlatformWidth*scale*0.3, y+h*0.6)
+		op.ColorScale.Scale(float32(alpha), float32(alpha), float32(alpha), float32(alpha))
+		g.drawSprite(screen, img, op)
+	}
+	drawRect(screen, x+w-pic-14*scale, y+pic, 14*scale, 16*scale, fade(color.RGBA{200, 60, 60, 255}))
+
+	if scale < 1 {
+		drawText(screen, i18n.T("postcard."+p.ID+".title"), x+w/2+2, y+h/2, TextSmall, fade(textOutline))
+		return
+	}
+	drawText(screen, i18n.T("postcard."+p.ID+".title"), x+w/2+6, y+pic+20, TextSmall, fade(textOutline))
+	ly := y + pic + 36
+	for _, line := range strings.Split(i18n.T("postcard."+p.ID+".text"), "\n") {
+		drawText(screen, line, x+w/2+6, ly, TextSmall, fade(textOutline))
+		ly += textLineHeight
+	}
+}
+
+// biomeSkin is the platform skin of the biome called name
+func biomeSkin(name string) string {
+	for _, b := range biomes {
+		if b.Name == name {
+			return b.Skin
+		}
+	}
+	return ""
+}
+
+// sceneGallery shows every postcard, collected or not
+type sceneGallery struct{}
+
+func (sceneGallery) Update(g *Game) error {
+	count := len(postcards)
+	switch {
+	case g.controller.JustPressed(input.ActionGallery), g.controller.JustPressed(input.ActionPause):
+		g.scenes.Switch(titleScene)
+	case g.controller.JustPressed(input.ActionLeft):
+		g.galleryCursor = (g.galleryCursor + count - 1) % count
+	case g.controller.JustPressed(input.ActionRight):
+		g.galleryCursor = (g.galleryCursor + 1) % count
+	default:
+		return nil
+	}
+	g.feedback(FeedbackUIClick)
+	return nil
+}
+
+func (sceneGallery) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Postcards", 40, TextLarge, textColor)
+
+	const scale = 0.6
+	w, h := postcardWidth*scale, postcardHeight*scale
+	for i, p := range postcards {
+		x := 20 + float64(i%2)*(w+16)
+		y := 70 + float64(i/2)*(h+14)
+		if i == g.galleryCursor {
+			drawRect(screen, x-3, y-3, w+6, h+6, color.RGBA{255, 220, 100, 255})
+		}
+		g.drawCard(screen, p, x, y, scale, 1, slices.Contains(g.save.Postcards, p.ID))
+	}
+
+	p := postcards[g.galleryCursor]
+	if slices.Contains(g.save.Postcards, p.ID) {
+		g.drawCard(screen, p, float64(ScreenWidth-postcardWidth)/2, ScreenHeight-180, 1, 1, true)
+	}
+	drawTextCentered(screen, joinHints("  ", g.moveHint("pick"), g.actionHint(input.ActionGallery, "back")), ScreenHeight-40, TextSmall, textColor)
+}
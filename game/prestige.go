@@ -0,0 +1,91 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Prestige parameters
+const (
+	PrestigeScore           = 2000                   // A run must score this much to offer prestige
+	PrestigeScoreBonus      = 0.05                   // Extra score per prestige level, as a fraction of every point
+	PrestigeDifficultyScore = 5 * ScorePerDifficulty // Extra climb difficulty counts per prestige level
+)
+
+// prestigeColors are the badge colors by level: bronze, silver, gold, then platinum for good
+var prestigeColors = []color.RGBA{
+	{205, 127, 50, 255},
+	{192, 192, 200, 255},
+	{255, 200, 40, 255},
+	{180, 230, 255, 255},
+}
+
+// prestigeColor is the badge color of a prestige level above zero
+func prestigeColor(level int) color.RGBA {
+	return prestigeColors[min(level, len(prestigeColors))-1]
+}
+
+// canPrestige reports whether the run that just ended may be traded in for a prestige level
+func (g *Game) canPrestige() bool {
+	return g.gameOver && !g.prestiged && g.mode == ModeClassic && g.score >= PrestigeScore
+}
+
+// prestige resets the profile's best score in exchange for a permanent
+// badge, a score bonus and harder birds from the next run on
+func (g *Game) prestige() {
+	if !g.canPrestige() {
+		return
+	}
+	g.save.Prestige++
+	g.save.BestScore = 0
+	g.prestiged = true
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+	g.feedback(FeedbackBoostPickup)
+}
+
+// addPrestigeBonus pays the prestige share of points, carrying fractions
+// over so single-point platforms still add up
+func (g *Game) addPrestigeBonus(points int) {
+	if g.save.Prestige == 0 {
+		return
+	}
+	g.prestigeCarry += float64(points) * PrestigeScoreBonus * float64(g.save.Prestige)
+	if whole := math.Floor(g.prestigeCarry); whole >= 1 {
+		g.prestigeCarry -= whole
+		g.score += int(whole)
+		g.scoreBy[ScorePrestige] += int(whole)
+	}
+}
+
+// prestigeLines is the game-over offer or confirmation, nil when neither applies
+func prestigeLines(g *Game) []hudLine {
+	switch {
+	case g.prestiged:
+		return []hudLine{{fmt.Sprintf("Prestige %d reached!", g.save.Prestige), TextSmall}}
+	case g.canPrestige():
+		return []hudLine{{g.actionHint(input.ActionPrestige, "Prestige (resets best score)"), TextSmall}}
+	}
+	return nil
+}
+
+// drawPrestigeBadge pins the profile's prestige medal to the player
+func (g *Game) drawPrestigeBadge(screen *ebiten.Image) {
+	level := g.save.Prestige
+	if level == 0 {
+		return
+	}
+	clr := prestigeColor(level)
+	x, y := g.player.X+PlayerWidth/2-6, g.screenY(g.player.Y)+8
+	ribbon := color.RGBA{200, 50, 60, 255}
+	drawLine(screen, x-2, y-5, x, y, ribbon)
+	drawLine(screen, x+2, y-5, x, y, ribbon)
+	drawCircle(screen, x, y+2, 3, clr)
+}
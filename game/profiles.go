@@ -0,0 +1,187 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// MaxProfileName caps the length of a profile name typed on the title
+const MaxProfileName = 16
+
+// Profiles lists the profiles with a save on this machine, the default
+// one first and the rest in name order
+func Profiles() ([]string, error) {
+	names := []string{DefaultProfile}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return names, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "godlejump", "profiles"))
+	if errors.Is(err, os.ErrNotExist) {
+		return names, nil
+	}
+	if err != nil {
+		return names, err
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != DefaultProfile {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// ValidProfileName reports why name can't be a profile, or nil. Names are
+// directory names, so only letters, digits, '-' and '_' are allowed.
+func ValidProfileName(name string) error {
+	if name == "" || len(name) > MaxProfileName {
+		return fmt.Errorf("profile names are 1-%d characters", MaxProfileName)
+	}
+	for _, r := range name {
+		if !profileRune(r) {
+			return fmt.Errorf("invalid character %q in profile name", r)
+		}
+	}
+	return nil
+}
+
+// profileRune reports whether r may appear in a profile name
+func profileRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_'
+}
+
+// profileInfo is one row of the profile picker
+type profileInfo struct {
+	Name string
+	Best int
+}
+
+// loadProfileList reads every profile's save for the picker
+func (g *Game) loadProfileList() {
+	names, err := Profiles()
+	if err != nil {
+		log.Printf("Failed to list profiles: %v", err)
+	}
+	g.profiles = g.profiles[:0]
+	for _, name := range names {
+		info := profileInfo{Name: name}
+		if name == g.opts.profile {
+			info.Best = g.save.BestScore
+		} else if s, err := LoadSave(name); err == nil {
+			info.Best = s.BestScore
+		}
+		g.profiles = append(g.profiles, info)
+		if name == g.opts.profile {
+			g.profileCursor = len(g.profiles) - 1
+		}
+	}
+}
+
+// switchProfile rebuilds the game on profile name's save, settings and
+// bindings, and writes the save so a new profile shows up in the list
+// right away
+func (g *Game) switchProfile(name string) {
+	if name != g.opts.profile {
+		g.restartWith(WithProfile(name))
+	}
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+	g.scenes.Switch(titleScene)
+}
+
+// sceneProfiles picks whose save the game plays on; the row after the
+// last profile creates a new one from a typed name
+type sceneProfiles struct{}
+
+func (sceneProfiles) Update(g *Game) error {
+	if g.profileTyping {
+		updateProfileName(g)
+		return nil
+	}
+	count := len(g.profiles) + 1 // The "new profile" row
+	switch {
+	case g.controller.JustPressed(input.ActionProfiles), g.controller.JustPressed(input.ActionPause):
+		g.scenes.Switch(titleScene)
+	case g.controller.JustPressed(input.ActionLeft):
+		g.profileCursor = (g.profileCursor + count - 1) % count
+	case g.controller.JustPressed(input.ActionRight):
+		g.profileCursor = (g.profileCursor + 1) % count
+	case g.controller.JustPressed(input.ActionRestart) && g.profileCursor == len(g.profiles):
+		g.profileTyping = true
+		g.profileName = ""
+	case g.controller.JustPressed(input.ActionRestart):
+		g.switchProfile(g.profiles[g.profileCursor].Name)
+	default:
+		return nil
+	}
+	g.feedback(FeedbackUIClick)
+	return nil
+}
+
+// updateProfileName edits the name of a new profile. It reads raw keys
+// rather than actions, so letters bound to actions can be typed.
+func updateProfileName(g *Game) {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if profileRune(r) && len(g.profileName) < MaxProfileName {
+			g.profileName += string(r)
+		}
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && g.profileName != "":
+		g.profileName = g.profileName[:len(g.profileName)-1]
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		g.profileTyping = false
+		g.feedback(FeedbackUIClick)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) && ValidProfileName(g.profileName) == nil:
+		g.profileTyping = false
+		g.feedback(FeedbackUIClick)
+		g.switchProfile(g.profileName)
+	}
+}
+
+func (sceneProfiles) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Profiles", 40, TextLarge, textColor)
+
+	// A window of rows around the cursor, the "new profile" row included
+	const rows = 12
+	count := len(g.profiles) + 1
+	first := max(0, min(g.profileCursor-rows/2, count-rows))
+	y := 80.0
+	for i := first; i < count && i < first+rows; i++ {
+		clr := textColor
+		prefix := "  "
+		if i == g.profileCursor {
+			prefix = "> "
+			clr = color.RGBA{255, 220, 100, 255}
+		}
+		line := "+ New profile"
+		if i < len(g.profiles) {
+			p := g.profiles[i]
+			line = p.Name + "  Best: " + strconv.Itoa(p.Best)
+			if p.Name == g.opts.profile {
+				line += "  (playing)"
+			}
+		}
+		drawText(screen, prefix+line, 30, y, TextSmall, clr)
+		y += 1.5 * textLineHeight
+	}
+
+	if g.profileTyping {
+		drawTextCentered(screen, "Name: "+g.profileName+"_", ScreenHeight-80, TextSmall, color.RGBA{255, 220, 100, 255})
+		drawTextCentered(screen, "ENTER: create  ESC: cancel", ScreenHeight-40, TextSmall, textColor)
+		return
+	}
+	drawTextCentered(screen, joinHints("  ", g.moveHint("pick"), g.actionHint(input.ActionRestart, "select"), g.actionHint(input.ActionProfiles, "back")), ScreenHeight-40, TextSmall, textColor)
+}
@@ -0,0 +1,236 @@
+package game
+
+import (
+	"image/color"
+	"slices"
+	"strconv"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Split-screen race parameters
+const (
+	RacePlayers     = 2
+	RaceHeight      = 500 // Meters to climb to win
+	RaceResultDelay = 1.5 // Seconds the result stays up before a new race can start
+	SplitGap        = 16  // Pixels between the viewports, where the race bar runs
+	RaceWidth       = RacePlayers*ScreenWidth + SplitGap
+)
+
+// raceSeats are each player's controls and color. The keyboard is split
+// down the middle; every player also gets a gamepad of their own.
+var raceSeats = [RacePlayers]struct {
+	preset string // Input preset the player's keys come from
+	hint   string // Controls reminder at the bottom of the viewport
+	again  string // Key that starts the next race
+	clr    color.RGBA
+}{
+	{"one_handed_left", "A/D: Move W: Jump S: Shoot E: Fly", "S", color.RGBA{120, 200, 255, 255}},
+	{"one_handed_right", "Arrows: Move Down: Shoot RShift: Fly", "Down", color.RGBA{255, 150, 120, 255}},
+}
+
+// Race is local split-screen play: two players race up the same world side
+// by side, each in a viewport with their own camera, keys and gamepad. The
+// first to RaceHeight wins, and a fall hands the race to the other player.
+// It runs as a game of its own, twice as wide as a Game.
+type Race struct {
+	opts    []Option
+	games   [RacePlayers]*Game
+	frames  [RacePlayers]*ebiten.Image
+	decided bool
+	winner  int     // Seat that won the race, -1 for a draw
+	shown   float64 // Seconds the result has been up
+	wins    [RacePlayers]int
+}
+
+// NewRace starts a split-screen race, both players' games built on opts.
+// Without a seed in opts every race climbs a new world.
+func NewRace(opts ...Option) *Race {
+	r := &Race{opts: opts}
+	for i := range r.frames {
+		r.frames[i] = ebiten.NewImage(ScreenWidth, ScreenHeight)
+	}
+	r.start()
+	return r
+}
+
+// withRaceSeat plays the game as player seat of race r
+func withRaceSeat(r *Race, seat int) Option {
+	return func(o *gameOptions) {
+		o.race, o.seat = r, seat
+	}
+}
+
+// seatController drives player seat with their half of the keyboard and
+// their own gamepad; the mouse is nobody's
+func seatController(seat int, s Settings) *input.Controller {
+	c := input.NewController(input.PresetByID(raceSeats[seat].preset).Bindings)
+	c.Shared = true
+	c.Seat = seat + 1
+	c.AutoMove = s.AutoMove
+	return c
+}
+
+// start builds both players' games on one seed. Player one's game owns the
+// audio, which outlives the race like it does a restart.
+func (r *Race) start() {
+	prev := r.games[0]
+	seed := resolveOptions(r.opts).seed
+	for i := range r.games {
+		r.games[i] = NewGame(append(slices.Clip(r.opts), WithSeed(seed), withRaceSeat(r, i))...)
+	}
+	first := r.games[0]
+	if prev != nil {
+		first.session = prev.session
+		if prev.audio != nil {
+			first.audio, first.ambience = prev.audio, prev.ambience
+			first.applyAudioSettings()
+		}
+	}
+	r.games[1].audio = first.audio // Sounds only; the music and weather follow player one
+	r.decided, r.shown = false, 0
+}
+
+// Update runs both players' games a tick. A player pausing pauses the race.
+func (r *Race) Update() error {
+	if ebiten.IsWindowBeingClosed() {
+		r.games[0].saveWindow(RaceWidth)
+		r.games[0].sendSessionSummary()
+		return ebiten.Termination
+	}
+	if r.decided {
+		r.shown += r.games[0].dt
+		if r.shown >= RaceResultDelay && r.games[0].canStartRun() && r.again() {
+			r.start()
+			r.games[0].feedback(FeedbackUIClick)
+		}
+		return nil
+	}
+	for _, g := range r.games {
+		if g.scenes.Current() == pauseScene {
+			return g.tick()
+		}
+	}
+	for _, g := range r.games {
+		if err := g.tick(); err != nil {
+			return err
+		}
+	}
+	r.judge()
+	return nil
+}
+
+// again reports whether either player asked for the next race
+func (r *Race) again() bool {
+	for _, g := range r.games {
+		if g.controller.JustPressed(input.ActionRestart) {
+			return true
+		}
+	}
+	return false
+}
+
+// judge settles the race once a player reaches RaceHeight or falls. Two
+// players out on the same tick are split by the height they reached.
+func (r *Race) judge() {
+	a, b := r.games[0], r.games[1]
+	if !a.gameOver && !b.gameOver && a.altitude() < RaceHeight && b.altitude() < RaceHeight {
+		return
+	}
+	switch {
+	case a.gameOver != b.gameOver:
+		r.winner = 0
+		if a.gameOver {
+			r.winner = 1
+		}
+	case a.altitude() > b.altitude():
+		r.winner = 0
+	case b.altitude() > a.altitude():
+		r.winner = 1
+	default:
+		r.winner = -1
+	}
+	if r.winner >= 0 {
+		r.wins[r.winner]++
+	}
+	r.decided = true
+	r.games[0].feedback(FeedbackGameOver)
+}
+
+// Draw draws each player's game into their viewport, with the race bar
+// between them
+func (r *Race) Draw(screen *ebiten.Image) {
+	for i, g := range r.games {
+		frame := r.frames[i]
+		frame.Clear()
+		g.Draw(frame)
+		if r.decided {
+			r.drawResult(frame, i)
+		}
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(i*(ScreenWidth+SplitGap)), 0)
+		screen.DrawImage(frame, op)
+	}
+	r.drawBar(screen)
+}
+
+// drawBar draws the race's progress between the viewports: a track from
+// the start at the bottom to the finish at the top, with a marker for
+// each player's height
+func (r *Race) drawBar(screen *ebiten.Image) {
+	const margin = 20.0
+	x := float64(ScreenWidth)
+	drawRect(screen, x, 0, SplitGap, ScreenHeight, color.RGBA{20, 20, 30, 255})
+	drawRect(screen, x+SplitGap/2-1, margin, 2, ScreenHeight-2*margin, color.RGBA{80, 80, 100, 255})
+	drawRect(screen, x+2, margin-2, SplitGap-4, 2, color.RGBA{255, 220, 100, 255})
+	for i, g := range r.games {
+		progress := min(float64(g.altitude())/RaceHeight, 1)
+		y := ScreenHeight - margin - progress*(ScreenHeight-2*margin)
+		drawRect(screen, x+2+float64(i)*(SplitGap/2-2), y-2, SplitGap/2-2, 4, raceSeats[i].clr)
+	}
+}
+
+// drawResult announces the race's outcome over the viewport of seat
+func (r *Race) drawResult(frame *ebiten.Image, seat int) {
+	drawRect(frame, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 120})
+	title, clr := "Draw!", textColor
+	switch r.winner {
+	case seat:
+		title, clr = "You win!", color.RGBA{255, 220, 100, 255}
+	case -1:
+	default:
+		title, clr = "Player "+strconv.Itoa(r.winner+1)+" wins", raceSeats[r.winner].clr
+	}
+	drawTextCentered(frame, title, ScreenHeight/3, TextLarge, clr)
+	drawTextCentered(frame, "Height: "+strconv.Itoa(r.games[seat].altitude())+"m", ScreenHeight/3+30, TextSmall, textColor)
+	drawTextCentered(frame, "Races won: "+strconv.Itoa(r.wins[0])+" - "+strconv.Itoa(r.wins[1]), ScreenHeight/3+30+textLineHeight, TextSmall, textColor)
+	switch {
+	case !r.games[0].canStartRun():
+		drawTextCentered(frame, "Play time is up. See you next time!", ScreenHeight/3+60, TextSmall, textColor)
+	case r.shown >= RaceResultDelay:
+		drawTextCentered(frame, raceSeats[seat].again+": Race again", ScreenHeight/3+60, TextSmall, textColor)
+	}
+}
+
+// Layout fits both viewports and the bar between them
+func (r *Race) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return RaceWidth, ScreenHeight
+}
+
+// WindowSize is the size of the window the launcher should open
+func (r *Race) WindowSize() (int, int) {
+	scale := r.games[0].windowScale()
+	return int(RaceWidth * scale), int(ScreenHeight * scale)
+}
+
+// PlaceWindow puts the window back where player one's game would
+func (r *Race) PlaceWindow(monitor string) error {
+	return r.games[0].PlaceWindow(monitor)
+}
+
+// Fullscreen reports whether player one's settings ask for a fullscreen window
+func (r *Race) Fullscreen() bool {
+	return r.games[0].Fullscreen()
+}
@@ -0,0 +1,438 @@
+package game
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"doodlejump/game/config"
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ReplayFile is the replay of the profile's last finished run, kept next
+// to the save file
+const ReplayFile = "replay.json"
+
+// ReplayVersion is the replay schema written by this build; replays of
+// any other version can't be played back. A replay only keeps the seed
+// and the input, so bump it whenever a seed's world or rng draws change.
+const ReplayVersion = 5
+
+// Replay playback parameters
+const (
+	ReplayKeyframeEvery = 300  // Ticks between the states kept for seeking
+	ReplaySeekStep      = 300  // Frames Left/Right seek by
+	FreeLookSpeed       = 6.0  // Pixels per frame the free-look camera pans
+	replayBarY          = 452  // Top of the seek bar
+	replayBarH          = 6.0  // Height of the seek bar
+	replayBarX          = 16.0 // Left end of the seek bar
+)
+
+// replaySpeeds are the playback rates Up and Down step through
+var replaySpeeds = [...]float64{0.5, 1, 2}
+
+// ReplayFrame is the input of one tick of play: only what the simulation
+// asked for that tick, which is all a deterministic re-run needs
+type ReplayFrame struct {
+	Held    uint32  `json:"h,omitempty"` // Bit per input.Action reported held
+	Pressed uint32  `json:"p,omitempty"` // Bit per input.Action reported pressed this tick
+	Move    float64 `json:"m,omitempty"` // Horizontal steering
+	AimX    float64 `json:"x,omitempty"` // Co-op reticle, in screen space
+	AimY    float64 `json:"y,omitempty"`
+}
+
+// Replay is a finished run: the state it started from, the rules it was
+// played by and the input of every tick
+type Replay struct {
+	Version  int             `json:"version"`
+	Seed     int64           `json:"seed"`
+	Level    string          `json:"level"`
+	Hardcore bool            `json:"hardcore"`
+	Coop     bool            `json:"coop"`
+	Mode     Mode            `json:"mode"`
+	Tuning   GameConfig      `json:"tuning"`
+	Settings Settings        `json:"settings"` // Aim assist and the landing magnet steer the run
+	Score    int             `json:"score"`
+	TickRate int             `json:"tick_rate,omitempty"` // Ticks per second it was played at; 0 in older replays, which ran at 60
+	Start    json.RawMessage `json:"start"`               // RunSnapshot at the first tick
+	Frames   []ReplayFrame   `json:"frames"`
+}
+
+// replayPath is where the replay of this save's profile's last run is kept
+func (s *SaveData) replayPath() string {
+	if s.path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(s.path), ReplayFile)
+}
+
+// replayRecorder stands in for the controller during play ticks, noting
+// every answer it passes on. A recorder with a window keeps only the run's
+// last window ticks or so: every half window it marks the state, and once
+// the window is full the replay starts over from the older mark.
+type replayRecorder struct {
+	input.Source
+	replay Replay
+	frame  *ReplayFrame
+	window int             // Frames kept at most, 0 for the whole run
+	mark   json.RawMessage // RunSnapshot half a window back
+}
+
+// newReplayRecorder starts an empty replay of a run by g's rules
+func newReplayRecorder(g *Game) *replayRecorder {
+	settings := g.save.Settings
+	settings.PINHash = "" // Replays get passed around
+	r := &replayRecorder{replay: Replay{
+		Version:  ReplayVersion,
+		Seed:     g.seed,
+		Level:    g.opts.level,
+		Hardcore: g.opts.hardcore,
+		Coop:     g.gunner != nil,
+		Mode:     g.mode,
+		Tuning:   g.tuning,
+		Settings: settings,
+		TickRate: g.tps,
+	}}
+	if g.opts.lowMemory {
+		r.window = g.ticks(LowMemoryReplayFrames)
+	}
+	return r
+}
+
+// begin opens the frame of a play tick about to read src. The first one
+// also captures the state the run starts from.
+func (r *replayRecorder) begin(g *Game, src input.Source) input.Source {
+	if r.replay.Start == nil {
+		start, err := json.Marshal(g.snapshot())
+		if err != nil {
+			log.Printf("Failed to start the replay: %v", err)
+		}
+		r.replay.Start = start
+	}
+	if half := r.window / 2; half > 0 && len(r.replay.Frames) > 0 && len(r.replay.Frames)%half == 0 {
+		if len(r.replay.Frames) >= r.window {
+			r.replay.Start = r.mark
+			r.replay.Frames = append([]ReplayFrame(nil), r.replay.Frames[half:]...)
+		}
+		mark, err := json.Marshal(g.snapshot())
+		if err != nil {
+			log.Printf("Failed to mark the replay: %v", err)
+		}
+		r.mark = mark
+	}
+	r.Source = src
+	r.replay.Frames = append(r.replay.Frames, ReplayFrame{})
+	r.frame = &r.replay.Frames[len(r.replay.Frames)-1]
+	return r
+}
+
+func (r *replayRecorder) Pressed(a input.Action) bool {
+	held := r.Source.Pressed(a)
+	if held {
+		r.frame.Held |= 1 << a
+	}
+	return held
+}
+
+func (r *replayRecorder) JustPressed(a input.Action) bool {
+	pressed := r.Source.JustPressed(a)
+	if pressed {
+		r.frame.Pressed |= 1 << a
+	}
+	return pressed
+}
+
+func (r *replayRecorder) Horizontal(playerX float64) float64 {
+	r.frame.Move = r.Source.Horizontal(playerX)
+	return r.frame.Move
+}
+
+// recordedPlay runs one play tick with the recorder between the
+// simulation and the controller
+func (g *Game) recordedPlay() error {
+	src := g.controller
+	g.controller = g.recorder.begin(g, src)
+	defer func() {
+		g.controller = src
+		if g.gunner != nil {
+			g.recorder.frame.AimX, g.recorder.frame.AimY = g.gunner.X, g.gunner.Y
+		}
+	}()
+	return g.play()
+}
+
+// keepReplay finishes the replay of the run that just ended and writes it
+// over the profile's last one
+func (g *Game) keepReplay() {
+	if g.recorder == nil || g.recorder.replay.Start == nil {
+		return
+	}
+	r := g.recorder.replay
+	r.Score = g.score
+	g.lastReplay = &r
+	if err := writeReplay(g.save.replayPath(), &r); err != nil {
+		log.Printf("Failed to save the replay: %v", err)
+	}
+}
+
+// writeReplay saves r to path
+func writeReplay(path string, r *Replay) error {
+	if path == "" {
+		return errors.New("no save path available")
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// LoadReplay reads the replay file at path
+func LoadReplay(path string) (*Replay, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Replay
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("parse replay %s: %w", path, err)
+	}
+	if r.Version != ReplayVersion {
+		return nil, fmt.Errorf("replay %s: version %d can't be played by this build", path, r.Version)
+	}
+	var start RunSnapshot
+	if err := json.Unmarshal(r.Start, &start); err != nil || start.Version != RunVersion {
+		return nil, fmt.Errorf("replay %s: unreadable start state", path)
+	}
+	return &r, nil
+}
+
+// replayInput feeds a replay's recorded frames back to the simulation
+type replayInput struct {
+	frame ReplayFrame
+}
+
+var _ input.Source = (*replayInput)(nil)
+
+func (r *replayInput) Pressed(a input.Action) bool        { return r.frame.Held&(1<<a) != 0 }
+func (r *replayInput) JustPressed(a input.Action) bool    { return r.frame.Pressed&(1<<a) != 0 }
+func (r *replayInput) Horizontal(playerX float64) float64 { return r.frame.Move }
+func (r *replayInput) Update()                            {}
+
+// replayViewer is the state of the replay scene. It outlives the games
+// rebuilt for seeking back, which every one of them shares.
+type replayViewer struct {
+	replay    *Replay
+	input     *replayInput
+	keyframes [][]byte // keyframes[i] is the state at tick i*ReplayKeyframeEvery, nil until played to
+	tick      int      // Frames played
+	paused    bool
+	speed     int     // Index into replaySpeeds
+	owed      float64 // Fractional ticks not played yet at slow speeds
+	freeLook  bool    // The camera is detached from the run
+	lookY     float64 // Free-look camera offset from the run's own
+	options   []Option
+}
+
+// withReplay rebuilds the game to play v back: the recorded rules and
+// settings, driven by the recorded input
+func withReplay(v *replayViewer) Option {
+	return func(o *gameOptions) {
+		o.replay = v
+		o.seed, o.seeded = v.replay.Seed, true
+		o.level = v.replay.Level
+		o.hardcore = v.replay.Hardcore
+		o.mode, o.modeSet = v.replay.Mode, true
+		o.tuning = v.replay.Tuning
+		o.settings = &v.replay.Settings
+		o.controller = v.input
+		o.tickRate = cmp.Or(v.replay.TickRate, config.DefaultTickRate)
+	}
+}
+
+// WatchReplay plays r back in the replay scene; leaving it returns to
+// the title
+func (g *Game) WatchReplay(r *Replay) {
+	v := &replayViewer{
+		replay:    r,
+		input:     &replayInput{},
+		keyframes: make([][]byte, len(r.Frames)/ReplayKeyframeEvery+1),
+		speed:     1,
+		options:   g.optionList,
+	}
+	v.keyframes[0] = r.Start
+	g.rewindReplay(v, 0)
+}
+
+// rewindReplay rebuilds the game at keyframe i of v
+func (g *Game) rewindReplay(v *replayViewer, i int) {
+	var r RunSnapshot
+	if err := json.Unmarshal(v.keyframes[i], &r); err != nil {
+		log.Printf("Failed to rewind the replay: %v", err)
+		return
+	}
+	g.optionList = v.options
+	g.restartWith(withReplay(v))
+	g.optionList = v.options // Leaving goes back to the game's own options
+	g.restore(&r)
+	v.tick = i * ReplayKeyframeEvery
+	g.scenes.Switch(replayScene)
+}
+
+// stepReplay plays the next recorded frame, keeping a keyframe whenever
+// it reaches one not seen yet
+func (g *Game) stepReplay() {
+	v := g.replayView
+	if v.tick >= len(v.replay.Frames) {
+		return
+	}
+	v.input.frame = v.replay.Frames[v.tick]
+	if g.gunner != nil {
+		g.gunner.X, g.gunner.Y = v.input.frame.AimX, v.input.frame.AimY
+	}
+	if err := g.play(); err != nil {
+		log.Printf("Replay: %v", err)
+	}
+	v.tick++
+	if i := v.tick / ReplayKeyframeEvery; v.tick%ReplayKeyframeEvery == 0 && i < len(v.keyframes) && v.keyframes[i] == nil {
+		if data, err := json.Marshal(g.snapshot()); err == nil {
+			v.keyframes[i] = data
+		}
+	}
+}
+
+// seekReplay jumps to tick t, from the nearest keyframe before it when
+// that beats playing on from here. Seeking is silent.
+func (g *Game) seekReplay(t int) {
+	v := g.replayView
+	t = max(0, min(t, len(v.replay.Frames)))
+	i := t / ReplayKeyframeEvery
+	for v.keyframes[i] == nil {
+		i--
+	}
+	if t < v.tick || i*ReplayKeyframeEvery > v.tick {
+		g.rewindReplay(v, i)
+	}
+	g.seeking = true
+	for v.tick < t {
+		g.stepReplay()
+	}
+	g.seeking = false
+}
+
+// leaveReplay puts the game back as it was before watching
+func (g *Game) leaveReplay() {
+	g.optionList = g.replayView.options
+	g.restartWith()
+	g.scenes.Switch(titleScene)
+}
+
+// replayTime formats ticks at tps as minutes and seconds
+func replayTime(ticks, tps int) string {
+	s := ticks / tps
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// sceneReplay plays a replay back under scrubbing controls. It reads fixed
+// keys, as the controller is the replay's own.
+type sceneReplay struct{}
+
+func (sceneReplay) Update(g *Game) error {
+	v := g.replayView
+	end := len(v.replay.Frames)
+	switch {
+	case menuPressed(ebiten.KeyEscape, ebiten.StandardGamepadButtonRightRight):
+		g.leaveReplay()
+		return nil
+	case menuPressed(ebiten.KeySpace, ebiten.StandardGamepadButtonRightBottom):
+		if v.tick >= end {
+			g.seekReplay(0) // Play again from the top
+		}
+		v.paused = !v.paused
+	case menuPressed(ebiten.KeyUp, ebiten.StandardGamepadButtonLeftTop):
+		v.speed = min(v.speed+1, len(replaySpeeds)-1)
+	case menuPressed(ebiten.KeyDown, ebiten.StandardGamepadButtonLeftBottom):
+		v.speed = max(v.speed-1, 0)
+	case menuPressed(ebiten.KeyLeft, ebiten.StandardGamepadButtonLeftLeft):
+		g.seekReplay(v.tick - g.ticks(ReplaySeekStep))
+	case menuPressed(ebiten.KeyRight, ebiten.StandardGamepadButtonLeftRight):
+		g.seekReplay(v.tick + g.ticks(ReplaySeekStep))
+	case menuPressed(ebiten.KeyComma, ebiten.StandardGamepadButtonFrontTopLeft):
+		v.paused = true
+		g.seekReplay(v.tick - 1)
+	case menuPressed(ebiten.KeyPeriod, ebiten.StandardGamepadButtonFrontTopRight):
+		v.paused = true
+		g.seekReplay(v.tick + 1)
+	case menuPressed(ebiten.KeyF, ebiten.StandardGamepadButtonRightTop):
+		v.freeLook = !v.freeLook
+		v.lookY = 0
+	}
+
+	// Click or drag along the seek bar to jump there
+	if cx, cy := ebiten.CursorPosition(); ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) &&
+		float64(cy) >= replayBarY-4 && float64(cy) <= replayBarY+replayBarH+4 {
+		frac := (float64(cx) - replayBarX) / (ScreenWidth - 2*replayBarX)
+		g.seekReplay(int(math.Round(max(0, min(frac, 1)) * float64(end))))
+	}
+
+	if v.freeLook {
+		_, wheel := ebiten.Wheel()
+		v.lookY += wheel * FreeLookSpeed * 4
+		if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyPageUp) {
+			v.lookY += FreeLookSpeed * g.step
+		}
+		if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyPageDown) {
+			v.lookY -= FreeLookSpeed * g.step
+		}
+	}
+
+	if v.paused || v.tick >= end {
+		return nil
+	}
+	for v.owed += replaySpeeds[v.speed]; v.owed >= 1; v.owed-- {
+		g.stepReplay()
+	}
+	return nil
+}
+
+func (sceneReplay) Draw(g *Game, screen *ebiten.Image) {
+	v := g.replayView
+	g.camera += v.lookY
+	g.drawPlay(screen)
+	g.camera -= v.lookY
+
+	end := len(v.replay.Frames)
+	width := ScreenWidth - 2*replayBarX
+	drawRect(screen, 0, replayBarY-18, ScreenWidth, ScreenHeight-replayBarY+18, color.RGBA{0, 0, 0, 120})
+	drawRect(screen, replayBarX, replayBarY, width, replayBarH, color.RGBA{90, 90, 90, 255})
+	if end > 0 {
+		played := width * float64(v.tick) / float64(end)
+		drawRect(screen, replayBarX, replayBarY, played, replayBarH, color.RGBA{255, 220, 100, 255})
+		drawRect(screen, replayBarX+played-1, replayBarY-2, 3, replayBarH+4, textColor)
+	}
+
+	status := replayTime(v.tick, g.tps) + " / " + replayTime(end, g.tps) + "  " + strconv.FormatFloat(replaySpeeds[v.speed], 'g', -1, 64) + "x"
+	switch {
+	case v.tick >= end:
+		status += "  END"
+	case v.paused:
+		status += "  PAUSED"
+	}
+	if v.freeLook {
+		status += "  FREE LOOK"
+	}
+	drawText(screen, status, replayBarX, replayBarY-14, TextSmall, textColor)
+	drawText(screen, "Score "+strconv.Itoa(v.replay.Score), ScreenWidth-replayBarX-72, replayBarY-14, TextSmall, textColor)
+	if v.paused {
+		drawTextCentered(screen, "SPACE play  </> step  Up/Down speed  F look  ESC exit", replayBarY+replayBarH+6, TextSmall, textColor)
+	}
+}
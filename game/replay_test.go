@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+
+	"doodlejump/game/achievements"
+)
+
+// recordReplay plays ticks of a seeded run under the bot, recording it
+// like a windowed game's own run. window, when set, caps the frames the
+// recorder keeps, as in low-memory mode. It returns the replay and the game
+// that played it.
+func recordReplay(t *testing.T, ticks, window int) (*Replay, *Game) {
+	t.Helper()
+	g := NewGame(WithHeadless(), WithSeed(testSeed))
+	g.controller = newBot(g)
+	g.recorder = newReplayRecorder(g)
+	g.recorder.window = window
+	for range ticks {
+		if err := g.recordedPlay(); err != nil {
+			t.Fatal(err)
+		}
+		if g.gameOver {
+			t.Fatalf("the bot's run ended after %d ticks; pick another testSeed", len(g.recorder.replay.Frames))
+		}
+	}
+	r := g.recorder.replay
+	return &r, g
+}
+
+// watchTo plays r back from its start up to tick
+func watchTo(r *Replay, tick int) *Game {
+	g := NewGame(WithHeadless())
+	g.WatchReplay(r)
+	for g.replayView.tick < tick {
+		g.stepReplay()
+	}
+	return g
+}
+
+func TestSeekMatchesPlayback(t *testing.T) {
+	r, _ := recordReplay(t, 3*ReplayKeyframeEvery, 0)
+	tick := ReplayKeyframeEvery + ReplayKeyframeEvery/2
+	want := state(t, watchTo(r, tick))
+
+	g := watchTo(r, 2*ReplayKeyframeEvery) // Keeps keyframe 1 on the way
+	g.seekReplay(tick)
+	if got := state(t, g); got != want {
+		t.Errorf("seeking back to tick %d from keyframe 1 left a different run than playing straight to it", tick)
+	}
+}
+
+func TestWindowedReplayMatchesRun(t *testing.T) {
+	const ticks, window = 1500, 600
+	r, g := recordReplay(t, ticks, window)
+	if len(r.Frames) == ticks {
+		t.Fatal("the recorder kept every frame; the window never rolled")
+	}
+	watched := watchTo(r, len(r.Frames))
+	g.achievementRun, watched.achievementRun = achievements.Run{}, achievements.Run{} // Replays don't count them
+	if got := state(t, watched); got != state(t, g) {
+		t.Error("a replay starting from the window's mark ended up away from the run it recorded")
+	}
+}
@@ -0,0 +1,401 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"doodlejump/game/achievements"
+)
+
+// RunFile is the name of the suspended run, kept next to the save file
+const RunFile = "run.json"
+
+// RunVersion is the suspended run schema written by this build; runs of
+// any other version are dropped rather than migrated. Bump it along with
+// ReplayVersion when generation changes: a run rebuilds its unstreamed
+// chunks and rng state from the seed.
+const RunVersion = 6
+
+// runPath is where the suspended run of this save's profile is kept
+func (s *SaveData) runPath() string {
+	if s.path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(s.path), RunFile)
+}
+
+// countingSource is the game's rng source. It counts the values drawn, so
+// a suspended run can rebuild the exact rng state from its seed.
+type countingSource struct {
+	src   rand.Source64
+	draws uint64
+}
+
+var _ rand.Source64 = (*countingSource)(nil)
+
+// newCountingSource returns a source drawing the same values as
+// rand.NewSource(seed), so seeds keep their worlds
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.draws = 0
+}
+
+// skipTo draws and discards values until n have been drawn in all
+func (s *countingSource) skipTo(n uint64) {
+	for s.draws < n {
+		s.Int63()
+	}
+}
+
+// fxFloat64 returns a value in [0, 1) for effects such as particles,
+// trails and tips. Effects are only decoration, so they draw on the
+// unseeded rand: the draws of g.rng, which seeds, replays and suspended
+// runs count on, stay the same however many are shown.
+func fxFloat64() float64 {
+	return rand.Float64()
+}
+
+// fxShuffle shuffles n things for an effect, off g.rng like fxFloat64
+func fxShuffle(n int, swap func(i, j int)) {
+	rand.Shuffle(n, swap)
+}
+
+// resumableTimers are the named timers a suspended run keeps, with the
+// callbacks they were started with. TimerStuck never expires, which JSON
+// can't hold, so restore starts it again from the sticky platform.
+var resumableTimers = map[timerName]func(g *Game){
+	TimerFly:        nil,
+	TimerShoot:      nil,
+	TimerBoost:      expireBoost,
+	TimerInvincible: nil,
+	TimerWeather:    changeWeather,
+	TimerLightning:  telegraphLightning,
+	TimerStrike:     strikeLightning,
+	TimerFlash:      nil,
+	TimerFlock:      spawnFlock,
+	TimerWager:      nil,
+	TimerLimit:      timeUp, // Runs with a limit aren't suspended, but replays keep their state
+}
+
+// runTimer is one running timer of a suspended run
+type runTimer struct {
+	Name      timerName `json:"name,omitempty"`
+	Platform  int       `json:"platform"` // Index of the crumbling platform for platform timers, -1 otherwise
+	Duration  float64   `json:"duration"`
+	Remaining float64   `json:"remaining"`
+	Elapsed   float64   `json:"elapsed"`
+	Stacks    int       `json:"stacks"`
+}
+
+// RunSnapshot is a run in progress as written when the window closes.
+// Pointers into the game's slices are stored as indices, -1 for none.
+type RunSnapshot struct {
+	Version  int    `json:"version"`
+	Seed     int64  `json:"seed"`
+	Draws    uint64 `json:"draws"` // Values drawn from the seed's rng so far
+	Level    string `json:"level"`
+	Hardcore bool   `json:"hardcore"`
+
+	Player         Player           `json:"player"`
+	Platforms      []Platform       `json:"platforms"`
+	Birds          []Bird           `json:"birds"`
+	Boosts         []Boost          `json:"boosts"`
+	Bullets        []Bullet         `json:"bullets"`
+	Cannons        []Cannon         `json:"cannons"`
+	LoadedCannon   int              `json:"loaded_cannon"`
+	Grapple        Grapple          `json:"grapple"`
+	Updrafts       []Updraft        `json:"updrafts"`
+	Flocks         []Flock          `json:"flocks"`
+	Campfires      []Campfire       `json:"campfires"`
+	Clouds         []Cloud          `json:"clouds"` // Recycling them draws on the rng
+	Stuck          int              `json:"stuck"`
+	JumpPressed    bool             `json:"jump_pressed"`
+	CanJumpRelease bool             `json:"can_jump_release"`
+	Camera         float64          `json:"camera"`
+	NextSlot       int              `json:"next_slot"`
+	Score          int              `json:"score"`
+	ScoreBy        ScoreBreakdown   `json:"score_by"`
+	PrestigeCarry  float64          `json:"prestige_carry"`
+	Difficulty     int              `json:"difficulty"`
+	BirdCount      int              `json:"bird_count"`
+	BirdSpeedMin   float64          `json:"bird_speed_min"`
+	BirdSpeedMax   float64          `json:"bird_speed_max"`
+	Weather        int              `json:"weather"`
+	BoltX          float64          `json:"bolt_x"`
+	GameTime       float64          `json:"game_time"`
+	TimeOfDay      float64          `json:"initial_time_of_day"`
+	Day            int              `json:"day"`
+	MigrationPhase int              `json:"migration_phase"`
+	Warmth         float64          `json:"warmth"`
+	Shields        int              `json:"shields"`
+	Combo          int              `json:"combo"`
+	NextAltitude   int              `json:"next_altitude"`
+	Biome          int              `json:"biome"`
+	Trace          [][2]float64     `json:"trace"`
+	TraceTicks     int              `json:"trace_ticks"`
+	Timers         []runTimer       `json:"timers"`
+	Achievements   achievements.Run `json:"achievements"`
+}
+
+// resumable reports whether runs of this game can be suspended: the
+// screensaver, sandbox, shared sessions, daily attempts, kiosks and timed
+// modes always start fresh
+func (g *Game) resumable() bool {
+	o := g.opts
+	return !o.headless && !o.idle && !o.sandbox && o.tournament == nil && o.hotseat == nil && o.daily == "" && o.kiosk == nil && g.mode == ModeClassic
+}
+
+// indexOf returns the index in s of the element p points to, or -1
+func indexOf[T any](s []T, p *T) int {
+	for i := range s {
+		if &s[i] == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// snapshot captures the run in progress
+func (g *Game) snapshot() *RunSnapshot {
+	r := &RunSnapshot{
+		Version:        RunVersion,
+		Seed:           g.seed,
+		Draws:          g.rngSource.draws,
+		Level:          g.opts.level,
+		Hardcore:       g.opts.hardcore,
+		Player:         g.player,
+		Platforms:      g.platforms,
+		Birds:          g.birds,
+		Boosts:         g.boosts,
+		Bullets:        g.bullets,
+		LoadedCannon:   -1,
+		Grapple:        g.grapple,
+		Updrafts:       g.updrafts,
+		Campfires:      g.campfires,
+		Clouds:         g.clouds,
+		Stuck:          indexOf(g.platforms, g.stuckToPlatform),
+		JumpPressed:    g.jumpPressed,
+		CanJumpRelease: g.canJumpRelease,
+		Camera:         g.camera,
+		NextSlot:       g.nextSlot,
+		Score:          g.score,
+		ScoreBy:        g.scoreBy,
+		PrestigeCarry:  g.prestigeCarry,
+		Difficulty:     g.difficulty,
+		BirdCount:      g.birdCount,
+		BirdSpeedMin:   g.birdSpeedMin,
+		BirdSpeedMax:   g.birdSpeedMax,
+		Weather:        g.weather,
+		BoltX:          g.boltX,
+		GameTime:       g.gameTime,
+		TimeOfDay:      g.initialTimeOfDay,
+		Day:            g.day,
+		MigrationPhase: g.migrationPhase,
+		Warmth:         g.warmth,
+		Shields:        g.shields,
+		Combo:          g.combo,
+		NextAltitude:   g.announcer.nextAltitude,
+		Biome:          g.announcer.biome,
+		TraceTicks:     g.traceTicks,
+		Achievements:   g.achievementRun,
+	}
+	for i, c := range g.cannons {
+		r.Cannons = append(r.Cannons, *c)
+		if c == g.loadedCannon {
+			r.LoadedCannon = i
+		}
+	}
+	for _, f := range g.flocks {
+		r.Flocks = append(r.Flocks, *f)
+	}
+	for _, s := range g.trace {
+		r.Trace = append(r.Trace, [2]float64{s.x, s.altitude})
+	}
+	for i, key := range g.timers.keys {
+		t := g.timers.timers[i]
+		rt := runTimer{Platform: -1, Duration: t.Duration, Remaining: t.Remaining, Elapsed: t.Elapsed, Stacks: t.Stacks}
+		switch k := key.(type) {
+		case timerName:
+			if _, ok := resumableTimers[k]; !ok {
+				continue
+			}
+			rt.Name = k
+		case platformBreak:
+			if rt.Platform = indexOf(g.platforms, k.p); rt.Platform < 0 {
+				continue
+			}
+		default:
+			continue
+		}
+		r.Timers = append(r.Timers, rt)
+	}
+	return r
+}
+
+// restore puts the run of r back in play. The game must be freshly built
+// from r's seed, so everything r doesn't hold, such as the streamed
+// chunks and the stars, comes out as it was.
+func (g *Game) restore(r *RunSnapshot) {
+	g.rngSource.skipTo(r.Draws)
+	g.player = r.Player
+	g.platforms = append(g.platforms[:0], r.Platforms...)
+	g.birds = r.Birds
+	g.boosts = r.Boosts
+	g.bullets = r.Bullets
+	g.grapple = r.Grapple
+	g.updrafts = r.Updrafts
+	g.campfires = r.Campfires
+	g.clouds = append(g.clouds[:0], r.Clouds...)
+	g.jumpPressed = r.JumpPressed
+	g.canJumpRelease = r.CanJumpRelease
+	g.camera = r.Camera
+	g.nextSlot = r.NextSlot
+	g.score = r.Score
+	g.scoreBy = r.ScoreBy
+	g.prestigeCarry = r.PrestigeCarry
+	g.difficulty = r.Difficulty
+	g.birdCount = r.BirdCount
+	g.birdSpeedMin = r.BirdSpeedMin
+	g.birdSpeedMax = r.BirdSpeedMax
+	g.weather = r.Weather
+	g.boltX = r.BoltX
+	g.gameTime = r.GameTime
+	g.initialTimeOfDay = r.TimeOfDay
+	g.day = r.Day
+	g.migrationPhase = r.MigrationPhase
+	g.warmth = r.Warmth
+	g.shields = r.Shields
+	g.combo = r.Combo
+	g.announcer.nextAltitude = r.NextAltitude
+	g.announcer.biome = r.Biome
+	g.traceTicks = r.TraceTicks
+	g.achievementRun = r.Achievements
+
+	g.stuckToPlatform = nil
+	g.cannons, g.loadedCannon = nil, nil
+	for i := range r.Cannons {
+		c := &r.Cannons[i]
+		g.cannons = append(g.cannons, c)
+		if i == r.LoadedCannon {
+			g.loadedCannon = c
+		}
+	}
+	g.flocks = nil
+	for i := range r.Flocks {
+		g.flocks = append(g.flocks, &r.Flocks[i])
+	}
+	g.trace = g.trace[:0]
+	for _, s := range r.Trace {
+		g.trace = append(g.trace, heatSample{s[0], s[1]})
+	}
+
+	g.timers = Timers{}
+	for _, rt := range r.Timers {
+		var t *Timer
+		switch {
+		case rt.Name != "":
+			t = g.timers.Add(rt.Name, rt.Duration, resumableTimers[rt.Name])
+		case rt.Platform >= 0 && rt.Platform < len(g.platforms):
+			p := &g.platforms[rt.Platform]
+			t = g.timers.Add(platformBreak{p}, rt.Duration, func(*Game) { p.State = PlatformBroken })
+		default:
+			continue
+		}
+		t.Remaining, t.Elapsed, t.Stacks = rt.Remaining, rt.Elapsed, rt.Stacks
+	}
+	if r.Stuck >= 0 && r.Stuck < len(g.platforms) {
+		g.stuckToPlatform = &g.platforms[r.Stuck]
+		g.timers.Add(TimerStuck, math.Inf(1), nil)
+	}
+	g.stream.Update(g.worldTop())
+}
+
+// suspendRun writes the run in progress to disk so the next launch can
+// continue it. It is called as the window closes.
+func (g *Game) suspendRun() {
+	cur := g.scenes.Current()
+	if !g.resumable() || g.gameOver || (cur != playScene && cur != pauseScene) {
+		return
+	}
+	if err := writeRun(g.save.runPath(), g.snapshot()); err != nil {
+		log.Printf("Failed to save the run: %v", err)
+	}
+}
+
+// discardRun forgets the suspended run, on disk and in memory
+func (g *Game) discardRun() {
+	g.suspended = nil
+	if path := g.save.runPath(); path != "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Failed to remove the saved run: %v", err)
+		}
+	}
+}
+
+// continueRun rebuilds the game from the suspended run and plays it,
+// paused so the player can find their feet first
+func (g *Game) continueRun() {
+	r := g.suspended
+	g.discardRun()
+	options := g.optionList
+	g.restartWith(WithSeed(r.Seed))
+	g.optionList = options // Later restarts go back to the game's own seed
+	g.restore(r)
+	g.outfitted = true // The run brought its boost along
+	g.scenes.Switch(pauseScene)
+}
+
+// loadRun reads the suspended run at path. A missing file is not an error;
+// a run of another version, level or rule set is passed over.
+func (g *Game) loadRun(path string) (*RunSnapshot, error) {
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r RunSnapshot
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("parse run %s: %w", path, err)
+	}
+	if r.Version != RunVersion || r.Level != g.opts.level || r.Hardcore != g.opts.hardcore {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// writeRun saves r to path, replacing the previous run
+func writeRun(path string, r *RunSnapshot) error {
+	if path == "" {
+		return errors.New("no save path available")
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
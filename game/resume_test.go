@@ -0,0 +1,50 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testSeed is a seed the bot climbs for well over the ticks these tests play
+const testSeed = 38
+
+// state is g's run as a suspended run would keep it
+func state(t *testing.T, g *Game) string {
+	t.Helper()
+	data, err := json.Marshal(g.snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestResumeMatchesRun(t *testing.T) {
+	const before, after = 400, 300
+	play := func(g *Game, ticks int) {
+		for range ticks {
+			if err := g.play(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	g := NewGame(WithHeadless(), WithSeed(testSeed))
+	g.controller = newBot(g)
+	play(g, before)
+	data, err := json.Marshal(g.snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	play(g, after)
+
+	var r RunSnapshot
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	resumed := NewGame(WithHeadless(), WithSeed(r.Seed))
+	resumed.restore(&r)
+	resumed.controller = newBot(resumed)
+	play(resumed, after)
+	if state(t, resumed) != state(t, g) {
+		t.Errorf("a run resumed at tick %d played on differently from the run itself", before)
+	}
+}
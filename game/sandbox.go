@@ -0,0 +1,147 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Sandbox parameters
+const (
+	SandboxMaxPlatforms = 20   // Extra platforms the sandbox may place
+	SandboxSlotSize     = 28   // Palette slot width and height
+	SandboxGravityStep  = 0.01 // Gravity change per key press
+	SandboxGravityMin   = 0.02
+	SandboxGravityMax   = 0.5
+)
+
+// sandboxTool is one slot of the sandbox palette
+type sandboxTool struct {
+	label string
+	color color.RGBA
+	place func(g *Game, x, y float64) // Spawns at a world position; nil for click-only tools
+	click func(g *Game)               // Runs when the slot itself is clicked
+}
+
+// sandboxPlatform returns a tool that places a platform of type t
+func sandboxPlatform(label string, t int, clr color.RGBA) sandboxTool {
+	return sandboxTool{label: label, color: clr, place: func(g *Game, x, y float64) {
+		if len(g.platforms) == cap(g.platforms) {
+			return // Appending would move platforms other code points into
+		}
+		g.platforms = append(g.platforms, Platform{X: x - PlatformWidth/2, Y: y - PlatformHeight/2, Type: t, State: PlatformIntact})
+	}}
+}
+
+// sandboxBoost returns a tool that places a boost of type t, resting on
+// the platform under it if there is one with room
+func sandboxBoost(label string, t int) sandboxTool {
+	return sandboxTool{label: label, color: boostColors[t], place: func(g *Game, x, y float64) {
+		b := Boost{X: x, Y: y, Type: t, Active: true}
+		g.mountNear(&b)
+		g.boosts = append(g.boosts, b)
+	}}
+}
+
+// sandboxTools is the palette, in slot order; number keys pick slots 1-9
+var sandboxTools = []sandboxTool{
+	sandboxPlatform("P", PlatformNormal, color.RGBA{120, 200, 120, 255}),
+	sandboxPlatform("St", PlatformSticky, color.RGBA{230, 200, 80, 255}),
+	sandboxPlatform("Br", PlatformDisappearing, color.RGBA{230, 120, 120, 255}),
+	sandboxPlatform("Sp", PlatformSpring, color.RGBA{80, 180, 255, 255}),
+	{label: "B", color: color.RGBA{160, 110, 70, 255}, place: func(g *Game, x, y float64) {
+		g.birds = append(g.birds, Bird{
+			X: x - BirdWidth/2, Y: y - BirdHeight/2,
+			SpeedX:    g.birdSpeedMin + g.rng.Float64()*(g.birdSpeedMax-g.birdSpeedMin),
+			Direction: 1,
+		})
+		g.birdCount++
+	}},
+	sandboxBoost(">", BoostSpeed),
+	sandboxBoost("J", BoostJump),
+	sandboxBoost("S", BoostShield),
+	{label: "W", color: color.RGBA{150, 150, 200, 255}, click: func(g *Game) {
+		g.setWeather((g.weather + 1) % weatherCount)
+	}},
+}
+
+// updateSandbox handles the palette, placement and gravity keys
+func (g *Game) updateSandbox() {
+	for i := range sandboxTools {
+		if i < 9 && inpututil.IsKeyJustPressed(ebiten.Key1+ebiten.Key(i)) {
+			g.selectSandboxTool(i)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		g.gravity = max(SandboxGravityMin, g.gravity-SandboxGravityStep)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		g.gravity = min(SandboxGravityMax, g.gravity+SandboxGravityStep)
+	}
+
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+	mx, my := ebiten.CursorPosition()
+	if slot, ok := sandboxSlotAt(float64(mx), float64(my)); ok {
+		g.selectSandboxTool(slot)
+		return
+	}
+	if tool := sandboxTools[g.sandboxTool]; tool.place != nil {
+		tool.place(g, float64(mx), float64(my)-g.camera)
+		g.feedback(FeedbackUIClick)
+	}
+}
+
+// selectSandboxTool picks a placement tool or runs a click-only one
+func (g *Game) selectSandboxTool(i int) {
+	if sandboxTools[i].click != nil {
+		sandboxTools[i].click(g)
+	} else {
+		g.sandboxTool = i
+	}
+	g.feedback(FeedbackUIClick)
+}
+
+// sandboxPaletteTop is the screen Y of the palette strip
+const sandboxPaletteTop = ScreenHeight - SandboxSlotSize - 4
+
+// sandboxSlotAt returns the palette slot under a screen position
+func sandboxSlotAt(x, y float64) (int, bool) {
+	if y < sandboxPaletteTop || y > sandboxPaletteTop+SandboxSlotSize || x < 4 {
+		return 0, false
+	}
+	slot := int((x - 4) / SandboxSlotSize)
+	return slot, slot < len(sandboxTools)
+}
+
+// sandboxRespawn replaces death in the sandbox: the player is put back
+// mid-screen with a bounce and a moment of invincibility
+func (g *Game) sandboxRespawn() {
+	g.player.X = ScreenWidth / 2
+	g.player.Y = g.worldTop() + ScreenHeight/2
+	g.player.VelocityY = g.jumpVelocity
+	g.player.VelocityX, g.player.LaunchX = 0, 0
+	g.loadedCannon = nil
+	g.stuckToPlatform = nil
+	g.timers.Add(TimerInvincible, g.tuning.SpawnInvincibility, nil)
+	g.warmth = 1
+}
+
+// drawSandbox draws the palette and the sandbox's gravity readout
+func (g *Game) drawSandbox(screen *ebiten.Image) {
+	for i, tool := range sandboxTools {
+		x := 4 + float64(i)*SandboxSlotSize
+		bg := color.RGBA{20, 20, 40, 200}
+		if i == g.sandboxTool && tool.place != nil {
+			bg = color.RGBA{90, 90, 140, 230}
+		}
+		drawRect(screen, x, sandboxPaletteTop, SandboxSlotSize-2, SandboxSlotSize, bg)
+		drawRect(screen, x+3, sandboxPaletteTop+3, SandboxSlotSize-8, 5, tool.color)
+		drawText(screen, tool.label, x+(SandboxSlotSize-2-textWidth(tool.label, TextSmall))/2, sandboxPaletteTop+12, TextSmall, textColor)
+	}
+	label := fmt.Sprintf("Gravity %.2f (-/=)", g.gravity)
+	drawText(screen, label, ScreenWidth-textWidth(label, TextSmall)-4, sandboxPaletteTop-12, TextSmall, textColor)
+}
@@ -0,0 +1,249 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"doodlejump/game/achievements"
+)
+
+// CurrentSaveVersion is the save schema version written by this build.
+// Bump it and append a migration to saveMigrations whenever SaveData changes shape.
+const CurrentSaveVersion = 4
+
+// SaveData is everything persisted between runs
+type SaveData struct {
+	Version      int                   `json:"version"`
+	BestScore    int                   `json:"best_score"`
+	GamesPlayed  int                   `json:"games_played"`
+	Settings     Settings              `json:"settings"`
+	Heatmap      Heatmap               `json:"heatmap"` // Trajectories and deaths of every run
+	Bookmarks    []SeedBookmark        `json:"bookmarks"`
+	Prestige     int                   `json:"prestige"`  // Prestige level, kept across best-score resets
+	Postcards    []string              `json:"postcards"` // IDs of the postcards collected
+	Codex        map[string]CodexStats `json:"codex"`     // Entity types met, by codex entry ID
+	Achievements achievements.State    `json:"achievements"`
+	Daily        DailyRecord           `json:"daily"`            // Record at the latest daily challenge played
+	Kiosk        []KioskScore          `json:"kiosk"`            // Cabinet high-score table, best first
+	Mode         Mode                  `json:"mode"`             // Mode picked on the title
+	AttackBest   int                   `json:"time_attack_best"` // Best time attack height, in meters
+	ZenBest      int                   `json:"zen_best"`         // Best zen height, in meters
+	DeathCounts  map[DeathCause]int    `json:"death_counts"`     // Runs ended by each cause, over the profile's life
+	Coins        int                   `json:"coins"`            // Paid by runs, spent in the shop
+	Shop         ShopState             `json:"shop"`
+
+	path     string // file the save was loaded from
+	readOnly bool   // set when the file is from a newer build, so we never overwrite it
+}
+
+// saveMigration upgrades a raw save document by exactly one version
+type saveMigration func(doc map[string]any) error
+
+// saveMigrations[i] upgrades a version i document to version i+1
+var saveMigrations = []saveMigration{
+	migrateSaveV0,
+	migrateSaveV1,
+	migrateSaveV2,
+	migrateSaveV3,
+}
+
+// migrateSaveV0 upgrades unversioned saves; they only ever held a best score
+func migrateSaveV0(doc map[string]any) error {
+	if _, ok := doc["best_score"]; !ok {
+		doc["best_score"] = 0
+	}
+	if _, ok := doc["games_played"]; !ok {
+		doc["games_played"] = 0
+	}
+	return nil
+}
+
+// migrateSaveV1 adds settings. Nothing to rewrite: keys absent from the
+// document keep the DefaultSettings values newSaveData starts from.
+func migrateSaveV1(doc map[string]any) error {
+	return nil
+}
+
+// migrateSaveV2 adds lifetime death counts, starting them from the deaths
+// the heatmap still remembers
+func migrateSaveV2(doc map[string]any) error {
+	if _, ok := doc["death_counts"]; ok {
+		return nil
+	}
+	counts := map[string]any{}
+	heatmap, _ := doc["heatmap"].(map[string]any)
+	deaths, _ := heatmap["deaths"].([]any)
+	for _, d := range deaths {
+		record, ok := d.(map[string]any)
+		if !ok {
+			return fmt.Errorf("heatmap death is %T, not an object", d)
+		}
+		cause, _ := record["cause"].(float64)
+		key := strconv.Itoa(int(cause))
+		n, _ := counts[key].(int)
+		counts[key] = n + 1
+	}
+	doc["death_counts"] = counts
+	return nil
+}
+
+// migrateSaveV3 adds coins and the shop. Nothing to rewrite: a save
+// without them starts with no coins and nothing bought.
+func migrateSaveV3(doc map[string]any) error {
+	return nil
+}
+
+// DefaultProfile is the save profile used when none is selected
+const DefaultProfile = "default"
+
+// savePath returns the location of the save file in the user config dir.
+// The default profile keeps the original location so existing saves load.
+func savePath(profile string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if profile == "" || profile == DefaultProfile {
+		return filepath.Join(dir, "godlejump", "save.json"), nil
+	}
+	if filepath.Base(profile) != profile || profile == "." || profile == ".." {
+		return "", fmt.Errorf("invalid profile name %q", profile)
+	}
+	return filepath.Join(dir, "godlejump", "profiles", profile, "save.json"), nil
+}
+
+// newSaveData returns an empty save at the current version
+func newSaveData(path string) *SaveData {
+	return &SaveData{
+		Version:  CurrentSaveVersion,
+		Settings: DefaultSettings(),
+		path:     path,
+	}
+}
+
+// LoadSave reads the save file of profile, migrating it to CurrentSaveVersion.
+// A missing file is not an error; a fresh save is returned instead.
+func LoadSave(profile string) (*SaveData, error) {
+	path, err := savePath(profile)
+	if err != nil {
+		return newSaveData(""), err
+	}
+	return loadSaveFrom(path)
+}
+
+// loadSaveFrom reads and migrates the save file at path
+func loadSaveFrom(path string) (*SaveData, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return newSaveData(path), nil
+	}
+	if err != nil {
+		return newSaveData(path), err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		// Keep the broken file around instead of silently replacing it
+		s := newSaveData(path)
+		s.readOnly = true
+		return s, fmt.Errorf("parse save %s: %w", path, err)
+	}
+
+	if err := migrateSave(doc); err != nil {
+		s := newSaveData(path)
+		s.readOnly = true
+		return s, err
+	}
+
+	// Round-trip through JSON to decode the migrated document into SaveData
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return newSaveData(path), err
+	}
+	s := newSaveData(path)
+	if err := json.Unmarshal(migrated, s); err != nil {
+		s = newSaveData(path)
+		s.readOnly = true
+		return s, fmt.Errorf("decode save %s: %w", path, err)
+	}
+	s.path = path
+	return s, nil
+}
+
+// migrateSave runs the migration chain on doc until it reaches CurrentSaveVersion
+func migrateSave(doc map[string]any) error {
+	version := 0
+	if v, ok := doc["version"].(float64); ok {
+		switch {
+		case v < 0 || v != math.Trunc(v):
+			return fmt.Errorf("save version %v is not a version", v)
+		case v > CurrentSaveVersion: // Checked before converting, so a huge one can't wrap around
+			return fmt.Errorf("save version %v is newer than supported version %d", v, CurrentSaveVersion)
+		}
+		version = int(v)
+	}
+
+	for version < CurrentSaveVersion {
+		if err := saveMigrations[version](doc); err != nil {
+			return fmt.Errorf("migrate save from version %d: %w", version, err)
+		}
+		version++
+		doc["version"] = version
+	}
+	return nil
+}
+
+// Write persists the save atomically next to where it was loaded from
+func (s *SaveData) Write() error {
+	if s.readOnly {
+		return errors.New("save file is read-only for this build")
+	}
+	if s.path == "" {
+		return errors.New("no save path available")
+	}
+
+	s.Version = CurrentSaveVersion
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path, data)
+}
+
+// writeFileAtomic writes data to path through a temporary file, so a crash
+// halfway leaves the previous file whole
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// recordRun updates the save with a finished run of mode m and writes it out
+func (s *SaveData) recordRun(m Mode, score int) {
+	s.GamesPlayed++
+	best := &s.BestScore
+	switch m {
+	case ModeTimeAttack:
+		best = &s.AttackBest
+	case ModeZen:
+		best = &s.ZenBest
+	}
+	if score > *best {
+		*best = score
+	}
+	if err := s.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"image/color"
+	"strconv"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Scene is one screen of the game with its own update and draw. Scenes
+// are stateless; everything they show lives on the Game.
+type Scene interface {
+	Update(g *Game) error
+	Draw(g *Game, screen *ebiten.Image)
+}
+
+// The game's scenes
+var (
+	titleScene      Scene = sceneTitle{}
+	playScene       Scene = scenePlay{}
+	pauseScene      Scene = scenePause{}
+	pinScene        Scene = scenePIN{}
+	gameOverScene   Scene = sceneGameOver{}
+	seedsScene      Scene = sceneSeeds{}
+	controlsScene   Scene = sceneControls{}
+	standingsScene  Scene = sceneStandings{}
+	scoreboardScene Scene = sceneScoreboard{}
+	settingsScene   Scene = sceneSettings{}
+	galleryScene    Scene = sceneGallery{}
+	codexScene      Scene = sceneCodex{}
+	profilesScene   Scene = sceneProfiles{}
+	replayScene     Scene = sceneReplay{}
+	coinScene       Scene = sceneCoin{}
+	initialsScene   Scene = sceneInitials{}
+	shopScene       Scene = sceneShop{}
+)
+
+// turnScene is shown between the turns of a tournament or hot-seat
+// session, nil when the game is a single player's
+func (g *Game) turnScene() Scene {
+	switch {
+	case g.opts.tournament != nil:
+		return standingsScene
+	case g.opts.hotseat != nil:
+		return scoreboardScene
+	}
+	return nil
+}
+
+// SceneManager holds the scene the game is in
+type SceneManager struct {
+	current Scene
+}
+
+// Current returns the active scene
+func (m *SceneManager) Current() Scene {
+	return m.current
+}
+
+// Switch makes s the active scene from the next tick on
+func (m *SceneManager) Switch(s Scene) {
+	m.current = s
+}
+
+// sceneTitle waits on the first screen for the player to start
+type sceneTitle struct{}
+
+func (sceneTitle) Update(g *Game) error {
+	if g.audio != nil {
+		g.audio.Update(true)
+	}
+	g.updateTitleWait()
+	g.tips.update(g.titleTips(), g.dt)
+	if g.opts.idle {
+		return nil // The wait ran out and the attract demo took over
+	}
+	if g.controller.JustPressed(input.ActionRestart) && g.canStartRun() {
+		if g.suspended != nil {
+			g.discardRun() // A new run replaces the unfinished one
+		}
+		g.scenes.Switch(playScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.suspended != nil && g.canStartRun() && g.controller.JustPressed(input.ActionContinue) {
+		g.continueRun()
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionBookmarks) {
+		g.scenes.Switch(seedsScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionControls) && g.keyboardController() != nil {
+		g.scenes.Switch(controlsScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionSettings) {
+		g.askPIN(settingsScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionGallery) {
+		g.scenes.Switch(galleryScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionCodex) {
+		g.scenes.Switch(codexScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionShop) {
+		g.scenes.Switch(shopScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionProfiles) {
+		g.loadProfileList()
+		g.askPIN(profilesScene)
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionDaily) && g.canStartRun() {
+		g.startDaily()
+		g.feedback(FeedbackUIClick)
+	} else if g.picksMode() && g.controller.JustPressed(input.ActionLeft) {
+		g.selectMode(-1)
+		g.feedback(FeedbackUIClick)
+	} else if g.picksMode() && g.controller.JustPressed(input.ActionRight) {
+		g.selectMode(1)
+		g.feedback(FeedbackUIClick)
+	}
+	return nil
+}
+
+func (sceneTitle) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "GodleJump", ScreenHeight/3, TextLarge, textColor)
+	if g.canStartRun() {
+		drawTextCentered(screen, "Press "+g.actionKey(input.ActionRestart)+" to start", ScreenHeight/3+30, TextSmall, textColor)
+	} else {
+		drawTextCentered(screen, "Play time is up for this session", ScreenHeight/3+30, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+	if best := g.save.best(g.mode); best > 0 {
+		drawTextCentered(screen, "Best: "+strconv.Itoa(best), ScreenHeight/3+30+textLineHeight, TextSmall, textColor)
+	}
+	if len(g.save.Bookmarks) > 0 {
+		drawTextCentered(screen, g.actionHint(input.ActionBookmarks, "Seeds"), ScreenHeight/3+30+2*textLineHeight, TextSmall, textColor)
+	}
+	if g.save.Prestige > 0 {
+		drawTextCentered(screen, "Prestige "+strconv.Itoa(g.save.Prestige), ScreenHeight/3+30+3*textLineHeight, TextSmall, prestigeColor(g.save.Prestige))
+	}
+	if g.picksMode() {
+		drawTextCentered(screen, "< Mode: "+g.mode.String()+" >", ScreenHeight/3+30+4*textLineHeight, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+	if g.suspended != nil {
+		drawTextCentered(screen, g.actionHint(input.ActionContinue, "Continue run ("+strconv.Itoa(g.suspended.Score)+")"), ScreenHeight/3+30+5*textLineHeight, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+	for i, line := range g.dailyTitleLines() {
+		drawTextCentered(screen, line, ScreenHeight/3+30+float64(6+i)*textLineHeight, TextSmall, textColor)
+	}
+	g.drawOnlineTop(screen, ScreenHeight/3+30+9*textLineHeight)
+	tip := g.tips.lines()
+	for i, line := range tip {
+		drawTextCentered(screen, line, ScreenHeight-40-float64(len(tip)+1-i)*textLineHeight-6, TextSmall, color.RGBA{180, 220, 255, 255})
+	}
+	profile := g.actionHint(input.ActionProfiles, "Profile ("+g.opts.profile+")")
+	if g.save.Settings.StreamerMode {
+		profile = g.actionHint(input.ActionProfiles, "Profile") // The name stays off stream
+	}
+	drawTextCentered(screen, profile, ScreenHeight-40-textLineHeight, TextSmall, textColor)
+	if g.keyboardController() != nil {
+		drawTextCentered(screen, g.actionHint(input.ActionControls, "Controls"), ScreenHeight-40, TextSmall, textColor)
+	}
+	drawTextCentered(screen, joinHints("  ", g.actionHint(input.ActionSettings, "Settings"), g.actionHint(input.ActionGallery, "Postcards"), g.actionHint(input.ActionCodex, "Codex")), ScreenHeight-40+textLineHeight, TextSmall, textColor)
+	drawTextCentered(screen, g.actionHint(input.ActionShop, "Shop ("+strconv.Itoa(g.save.Coins)+" coins)"), ScreenHeight-40+2*textLineHeight, TextSmall, textColor)
+}
+
+// scenePlay runs the simulation
+type scenePlay struct{}
+
+func (scenePlay) Update(g *Game) error {
+	if g.controller.JustPressed(input.ActionPause) && g.opts.kiosk == nil { // Kiosk runs race the clock
+		g.scenes.Switch(pauseScene)
+		g.feedback(FeedbackUIClick)
+		return nil
+	}
+	if !g.outfitted {
+		g.outfit()
+	}
+	if g.recorder != nil {
+		return g.recordedPlay()
+	}
+	return g.play()
+}
+
+func (scenePlay) Draw(g *Game, screen *ebiten.Image) {
+	g.drawPlay(screen)
+}
+
+// scenePause freezes the run under a dimmed screen
+type scenePause struct{}
+
+func (scenePause) Update(g *Game) error {
+	if g.audio != nil {
+		g.audio.Update(false)
+	}
+	if g.controller.JustPressed(input.ActionPause) {
+		g.session.reminder = false
+		g.scenes.Switch(playScene)
+		g.feedback(FeedbackUIClick)
+	}
+	return nil
+}
+
+func (scenePause) Draw(g *Game, screen *ebiten.Image) {
+	g.drawPlay(screen)
+	drawRect(screen, 0, 0, ScreenWidth, ScreenHeight, color.RGBA{0, 0, 0, 120})
+	drawTextCentered(screen, "Paused", ScreenHeight/3, TextLarge, textColor)
+	drawTextCentered(screen, "Press "+g.actionKey(input.ActionPause)+" to resume", ScreenHeight/3+30, TextSmall, textColor)
+	if g.session.reminder {
+		drawTextCentered(screen, g.breakLine(), ScreenHeight/3+60, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+}
+
+// sceneGameOver shows the end of a run until the player restarts
+type sceneGameOver struct{}
+
+func (sceneGameOver) Update(g *Game) error {
+	if g.audio != nil {
+		g.audio.Update(false) // Fade the music out under the game-over screen
+	}
+	g.updateToasts()
+	g.tips.update(g.deathTips(), g.dt)
+	if g.opts.race != nil {
+		return nil // The race starts both players' next runs together
+	}
+	if g.opts.kiosk != nil {
+		updateKioskOver(g)
+	} else if g.controller.JustPressed(input.ActionStats) {
+		g.hud.showStats = !g.hud.showStats
+		g.feedback(FeedbackUIClick)
+	} else if g.controller.JustPressed(input.ActionBookmarks) {
+		g.bookmarkRun()
+	} else if g.controller.JustPressed(input.ActionPrestige) {
+		g.prestige()
+	} else if g.opts.daily != "" && g.controller.JustPressed(input.ActionDaily) {
+		g.leaveDaily()
+		g.feedback(FeedbackUIClick)
+	} else if g.lastReplay != nil && g.controller.JustPressed(input.ActionReplay) {
+		g.WatchReplay(g.lastReplay)
+		g.feedback(FeedbackUIClick)
+	} else if !g.hud.showStats && g.canStartRun() && g.controller.Pressed(input.ActionRestart) {
+		if s := g.turnScene(); s != nil {
+			g.scenes.Switch(s)
+		} else {
+			g.restart()
+		}
+	}
+	return nil
+}
+
+func (sceneGameOver) Draw(g *Game, screen *ebiten.Image) {
+	g.drawPlay(screen)
+	if g.hud.showStats {
+		g.drawStats(screen)
+	}
+}
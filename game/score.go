@@ -0,0 +1,96 @@
+package game
+
+import "fmt"
+
+// ScoreCategory is where a run's points came from
+type ScoreCategory int
+
+const (
+	ScorePlatforms  ScoreCategory = iota // Climbing past platforms
+	ScoreKills                           // Shooting birds
+	ScoreBonuses                         // Milestones and other rewards
+	ScorePrestige                        // The prestige bonus on top of everything else
+	ScoreWager                           // Doubled points while a won wager runs
+	ScoreCombo                           // The combo multiplier's extra points
+	ScoreMultiplier                      // Doubled points while the multiplier boost runs
+	scoreCategoryCount
+)
+
+// scoreCategoryNames label the breakdown panels
+var scoreCategoryNames = [scoreCategoryCount]string{
+	ScorePlatforms:  "Platforms",
+	ScoreKills:      "Kills",
+	ScoreBonuses:    "Bonuses",
+	ScorePrestige:   "Prestige",
+	ScoreWager:      "Wagers",
+	ScoreCombo:      "Combos",
+	ScoreMultiplier: "Multiplier",
+}
+
+func (c ScoreCategory) String() string {
+	if c < 0 || c >= scoreCategoryCount {
+		return "Unknown"
+	}
+	return scoreCategoryNames[c]
+}
+
+// bonus reports whether c holds points earned on top of other points,
+// which no other bonus multiplies again
+func (c ScoreCategory) bonus() bool {
+	return c == ScorePrestige || c == ScoreWager || c == ScoreCombo || c == ScoreMultiplier
+}
+
+// Points per scoring event
+const (
+	KillScore      = 25 // Shooting a bird
+	MilestoneScore = 50 // Every AltitudeMilestone meters
+)
+
+// ScoreBreakdown holds a run's points by category
+type ScoreBreakdown [scoreCategoryCount]int
+
+// addScore awards points and records where they came from. Every bonus
+// is worked out on the points alone, so bonuses add up rather than
+// compound.
+func (g *Game) addScore(cat ScoreCategory, points int) {
+	g.score += points
+	g.scoreBy[cat] += points
+	bonuses := [...]struct {
+		cat    ScoreCategory
+		points int
+	}{
+		{ScoreWager, g.wagerBonus(cat, points)},
+		{ScoreCombo, g.comboBonus(cat, points)},
+		{ScoreMultiplier, g.multiplierBonus(cat, points)},
+	}
+	total := points
+	for _, b := range bonuses {
+		g.score += b.points
+		g.scoreBy[b.cat] += b.points
+		total += b.points
+	}
+	g.addPrestigeBonus(total)
+}
+
+// climbed is the platform score alone; difficulty and the day cycle follow
+// it so kills and bonuses don't speed the world up
+func (g *Game) climbed() int {
+	return g.scoreBy[ScorePlatforms]
+}
+
+// breakdownLines lists every category that scored
+func breakdownLines(g *Game) []hudLine {
+	var lines []hudLine
+	for c := ScoreCategory(0); c < scoreCategoryCount; c++ {
+		lines = append(lines, hudLine{fmt.Sprintf("%s: %d", c, g.scoreBy[c]), TextSmall})
+	}
+	return lines
+}
+
+// tallyLines is the live breakdown toggled with the tally action
+func tallyLines(g *Game) []hudLine {
+	if !g.hud.showTally || g.gameOver {
+		return nil
+	}
+	return breakdownLines(g)
+}
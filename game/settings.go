@@ -0,0 +1,107 @@
+package game
+
+import (
+	"log"
+
+	"doodlejump/game/i18n"
+	"doodlejump/game/input"
+)
+
+// Settings holds player preferences persisted in the save file
+type Settings struct {
+	// HUD
+	AutoHideHints   bool `json:"auto_hide_hints"`      // Hide control hints after HintsVisibleFor seconds
+	FadeHUDNearPlay bool `json:"fade_hud_near_player"` // Fade HUD panels the player flies behind
+	StreamerMode    bool `json:"streamer_mode"`        // Hide seed and profile name
+
+	// Feedback
+	Haptics bool `json:"haptics"` // Gamepad rumble and phone vibration
+
+	// Audio, per mixer channel (volumes 0.0 - 1.0)
+	SFXVolume   float64 `json:"sfx_volume"`
+	MusicVolume float64 `json:"music_volume"`
+	UIVolume    float64 `json:"ui_volume"`
+	SFXMuted    bool    `json:"sfx_muted"`
+	MusicMuted  bool    `json:"music_muted"`
+	UIMuted     bool    `json:"ui_muted"`
+
+	// Announcer
+	Announcer bool   `json:"announcer"` // Milestone stingers with subtitles
+	Language  string `json:"language"`  // i18n language code for subtitles and menus
+
+	// Accessibility
+	Captions      bool `json:"captions"`       // Describe important sounds at the screen edge
+	ReducedMotion bool `json:"reduced_motion"` // Turn off moving overlays such as the event feed, and blinking
+	EventFeed     bool `json:"event_feed"`     // Corner feed of recent kills, weather and boosts
+
+	PhotosensitiveSafe bool `json:"photosensitive_safe"` // Replace flashes with dim pulses
+
+	// Online
+	LeaderboardMarkers bool `json:"leaderboard_markers"` // Flags at friends' and global best altitudes
+
+	// Controls
+	InputPreset string `json:"input_preset"` // input.Preset ID
+	AutoMove    bool   `json:"auto_move"`    // Tap a direction to keep moving that way
+
+	// Assists
+	AimAssist          int     `json:"aim_assist"`           // AimAssistOff, AimAssistLow or AimAssistHigh
+	StickyHoldRelease  bool    `json:"sticky_hold_release"`  // Holding jump releases sticky platforms automatically
+	StickyReleaseDelay float64 `json:"sticky_release_delay"` // Seconds jump must be held before the release
+	LandingMagnet      float64 `json:"landing_magnet"`       // Max pixels an edge landing is pulled inward, 0 = off
+	LandingMarker      bool    `json:"landing_marker"`       // Mark where the current fall will land
+
+	// Parental controls
+	SessionLimit int    `json:"session_limit"` // Minutes of play per session before new runs stop, 0 = none
+	BreakEvery   int    `json:"break_every"`   // Minutes of play between break reminders, 0 = none
+	PINHash      string `json:"pin_hash"`      // Hash of the PIN guarding settings and profiles, "" = none
+}
+
+// DefaultSettings returns the settings used for new saves and for keys
+// missing from older ones
+func DefaultSettings() Settings {
+	return Settings{
+		AutoHideHints:   true,
+		FadeHUDNearPlay: true,
+		StreamerMode:    false,
+		Haptics:         true,
+		SFXVolume:       0.8,
+		MusicVolume:     0.6,
+		UIVolume:        0.7,
+		Announcer:       true,
+		EventFeed:       true,
+		Language:        i18n.DefaultLanguage,
+		InputPreset:     input.Presets[0].ID,
+
+		StickyHoldRelease:  true,
+		StickyReleaseDelay: 0.25,
+		LandingMagnet:      0,
+		LandingMarker:      true,
+	}
+}
+
+// newController builds the input controller described by s, with
+// remapped actions from the bindings file at path on top of the preset
+func newController(s Settings, path string) *input.Controller {
+	b := input.PresetByID(s.InputPreset).Bindings
+	if path != "" {
+		var err error
+		if b, err = input.LoadBindings(path, b); err != nil {
+			log.Printf("Failed to load bindings: %v", err)
+		}
+	}
+	c := input.NewController(b)
+	c.AutoMove = s.AutoMove
+	return c
+}
+
+// rebuildController swaps the rebindable controller for one built from the
+// profile's settings as they are now. A co-op gunner stays as it was.
+func rebuildController(g *Game) {
+	c := newController(g.save.Settings, g.save.bindingsPath())
+	switch old := g.controller.(type) {
+	case *input.Coop:
+		g.controller = input.NewCoop(c, old.Gunner)
+	case *input.Controller:
+		g.controller = c
+	}
+}
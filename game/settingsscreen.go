@@ -0,0 +1,281 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"slices"
+
+	"doodlejump/game/config"
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// VolumeStep is how much one press changes a volume
+const VolumeStep = 0.1
+
+// Choices of the landing assists
+var (
+	stickyReleaseDelays = []float64{0.1, 0.25, 0.5, 1} // Seconds
+	landingMagnets      = []float64{0, 4, 8, 16}       // Pixels
+)
+
+// settingsRow is one line of the settings screen
+type settingsRow struct {
+	label  string
+	value  func(g *Game) string
+	change func(g *Game, dir int) // dir is -1 or 1; toggles ignore it
+	note   func(g *Game) string   // Drawn under the list while the row is picked, if set
+}
+
+// settingsHeading is a line heading the rows below it. The cursor skips
+// it, as it has nothing to change.
+func settingsHeading(title string) settingsRow {
+	return settingsRow{label: title}
+}
+
+// onOff labels a toggle
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+// settingsToggle is a row flipping one of the profile's settings
+func settingsToggle(label string, setting func(s *Settings) *bool) settingsRow {
+	return settingsRow{label: label, value: func(g *Game) string { return onOff(*setting(&g.save.Settings)) }, change: func(g *Game, _ int) {
+		b := setting(&g.save.Settings)
+		*b = !*b
+	}}
+}
+
+// settingsVolume is a row stepping one of the profile's channel volumes
+func settingsVolume(label string, volume func(s *Settings) *float64) settingsRow {
+	return settingsRow{label: label, value: func(g *Game) string { return fmt.Sprintf("%.0f%%", *volume(&g.save.Settings)*100) }, change: func(g *Game, dir int) {
+		v := volume(&g.save.Settings)
+		*v = max(0, min(1, *v+float64(dir)*VolumeStep))
+	}}.andThen(applyAudio)
+}
+
+// andThen has r run apply after every change, for settings that take
+// effect at once
+func (r settingsRow) andThen(apply func(g *Game)) settingsRow {
+	change := r.change
+	r.change = func(g *Game, dir int) {
+		change(g, dir)
+		apply(g)
+	}
+	return r
+}
+
+// applyAudio puts volume changes into effect, when the game has audio
+func applyAudio(g *Game) {
+	if g.audio != nil {
+		g.applyAudioSettings()
+	}
+}
+
+// settingsRows are the settings screen's lines, top to bottom
+var settingsRows = []settingsRow{
+	settingsRow{label: "Volume", value: func(g *Game) string { return fmt.Sprintf("%.0f%%", g.config.Volume*100) }, change: func(g *Game, dir int) {
+		g.config.Volume = max(0, min(1, g.config.Volume+float64(dir)*VolumeStep))
+	}}.andThen(applyAudio),
+	{label: "Difficulty", value: func(g *Game) string { return string(g.config.Difficulty) }, change: func(g *Game, _ int) {
+		g.config.Difficulty = g.config.Difficulty.Next()
+	}},
+	{label: "Weather", value: func(g *Game) string { return onOff(g.config.Weather) }, change: func(g *Game, _ int) {
+		g.config.Weather = !g.config.Weather
+	}},
+	{label: "Fullscreen", value: func(g *Game) string { return onOff(g.config.Fullscreen) }, change: func(g *Game, _ int) {
+		g.config.Fullscreen = !g.config.Fullscreen
+		ebiten.SetFullscreen(g.config.Fullscreen)
+	}},
+	{label: "Show FPS", value: func(g *Game) string { return onOff(g.config.ShowFPS) }, change: func(g *Game, _ int) {
+		g.config.ShowFPS = !g.config.ShowFPS
+	}},
+	{label: "Tick rate", value: tickRateLabel, change: func(g *Game, dir int) {
+		g.config.TickRate = cycleChoice(config.TickRates, g.config.TickRate, dir)
+		g.setTickRate(g.config.TickRate)
+		ebiten.SetTPS(g.tps)
+	}},
+
+	// HUD and feedback, kept with the profile
+	settingsHeading("HUD and feedback"),
+	settingsToggle("Streamer mode", func(s *Settings) *bool { return &s.StreamerMode }),
+	settingsToggle("Auto-hide hints", func(s *Settings) *bool { return &s.AutoHideHints }),
+	settingsToggle("Haptics", func(s *Settings) *bool { return &s.Haptics }),
+	settingsToggle("Announcer", func(s *Settings) *bool { return &s.Announcer }),
+
+	// Audio mix, kept with the profile
+	settingsHeading("Audio mix"),
+	settingsVolume("Effects volume", func(s *Settings) *float64 { return &s.SFXVolume }),
+	settingsToggle("Mute effects", func(s *Settings) *bool { return &s.SFXMuted }).andThen(applyAudio),
+	settingsVolume("Music volume", func(s *Settings) *float64 { return &s.MusicVolume }),
+	settingsToggle("Mute music", func(s *Settings) *bool { return &s.MusicMuted }).andThen(applyAudio),
+	settingsVolume("Menu volume", func(s *Settings) *float64 { return &s.UIVolume }),
+	settingsToggle("Mute menus", func(s *Settings) *bool { return &s.UIMuted }).andThen(applyAudio),
+
+	// Accessibility, kept with the profile
+	settingsHeading("Accessibility"),
+	settingsToggle("Captions", func(s *Settings) *bool { return &s.Captions }),
+	settingsToggle("Event feed", func(s *Settings) *bool { return &s.EventFeed }),
+	settingsToggle("Reduced motion", func(s *Settings) *bool { return &s.ReducedMotion }),
+	settingsToggle("Photosensitive safe", func(s *Settings) *bool { return &s.PhotosensitiveSafe }),
+
+	// Controls, kept with the profile
+	settingsHeading("Controls"),
+	settingsRow{label: "Controls preset", value: func(g *Game) string { return input.PresetByID(g.save.Settings.InputPreset).Name }, change: func(g *Game, dir int) {
+		i := max(0, slices.IndexFunc(input.Presets, func(p input.Preset) bool { return p.ID == g.save.Settings.InputPreset }))
+		g.save.Settings.InputPreset = input.Presets[(i+dir+len(input.Presets))%len(input.Presets)].ID
+	}, note: func(g *Game) string { return input.PresetByID(g.save.Settings.InputPreset).Description }}.andThen(rebuildController),
+	settingsToggle("Auto-move", func(s *Settings) *bool { return &s.AutoMove }).andThen(rebuildController),
+
+	// Assists, kept with the profile
+	settingsHeading("Assists"),
+	{label: "Aim assist", value: func(g *Game) string { return aimAssistLabel(g.save.Settings.AimAssist) }, change: func(g *Game, dir int) {
+		g.save.Settings.AimAssist = cycleChoice([]int{AimAssistOff, AimAssistLow, AimAssistHigh}, g.save.Settings.AimAssist, dir)
+	}},
+	settingsToggle("Sticky hold release", func(s *Settings) *bool { return &s.StickyHoldRelease }),
+	{label: "Release after", value: func(g *Game) string { return fmt.Sprintf("%gs", g.save.Settings.StickyReleaseDelay) }, change: func(g *Game, dir int) {
+		g.save.Settings.StickyReleaseDelay = cycleChoice(stickyReleaseDelays, g.save.Settings.StickyReleaseDelay, dir)
+	}},
+	{label: "Landing magnet", value: func(g *Game) string {
+		if g.save.Settings.LandingMagnet <= 0 {
+			return "Off"
+		}
+		return fmt.Sprintf("%g px", g.save.Settings.LandingMagnet)
+	}, change: func(g *Game, dir int) {
+		g.save.Settings.LandingMagnet = cycleChoice(landingMagnets, g.save.Settings.LandingMagnet, dir)
+	}},
+
+	// Parental controls, kept with the profile
+	settingsHeading("Parental controls"),
+	{label: "Session limit", value: func(g *Game) string { return minutes(g.save.Settings.SessionLimit) }, change: func(g *Game, dir int) {
+		g.save.Settings.SessionLimit = cycleChoice(sessionLimits, g.save.Settings.SessionLimit, dir)
+	}},
+	{label: "Break reminder", value: func(g *Game) string { return minutes(g.save.Settings.BreakEvery) }, change: func(g *Game, dir int) {
+		g.save.Settings.BreakEvery = cycleChoice(breakIntervals, g.save.Settings.BreakEvery, dir)
+	}},
+	{label: "Parental PIN", value: func(g *Game) string { return onOff(g.save.Settings.PINHash != "") }, change: func(g *Game, dir int) {
+		if dir < 0 {
+			g.save.Settings.PINHash = ""
+		} else {
+			g.setPIN()
+		}
+	}},
+}
+
+// tickRateLabel names the simulation rate, and what the rates off the
+// default are for
+func tickRateLabel(g *Game) string {
+	switch {
+	case g.config.TickRate < config.DefaultTickRate:
+		return fmt.Sprintf("%d Hz (battery)", g.config.TickRate)
+	case g.config.TickRate > config.DefaultTickRate:
+		return fmt.Sprintf("%d Hz (high refresh)", g.config.TickRate)
+	}
+	return fmt.Sprintf("%d Hz", g.config.TickRate)
+}
+
+// loadConfig reads settings.json; a broken one still lets the game start
+func loadConfig() (config.Config, string) {
+	path, err := config.Path()
+	if err != nil {
+		log.Printf("Failed to locate settings: %v", err)
+		return config.Default(), ""
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		log.Printf("Failed to load settings: %v", err)
+	}
+	return c, path
+}
+
+// drawFPS shows the frame rate in the top corner when the settings ask for it
+func (g *Game) drawFPS(screen *ebiten.Image) {
+	if g.config.ShowFPS {
+		drawText(screen, fmt.Sprintf("%.0f FPS", ebiten.ActualFPS()), ScreenWidth/2-20, HUDMargin, TextSmall, textColor)
+	}
+}
+
+// nextSettingsRow is the row the cursor moves to from row i in dir,
+// wrapping around and skipping headings
+func nextSettingsRow(i, dir int) int {
+	for {
+		i = (i + dir + len(settingsRows)) % len(settingsRows)
+		if settingsRows[i].change != nil {
+			return i
+		}
+	}
+}
+
+// sceneSettings edits the machine-wide settings and the profile's
+// parental controls, and writes both on the way out. Like the controls
+// screen it reads fixed keys, not actions.
+type sceneSettings struct{}
+
+func (sceneSettings) Update(g *Game) error {
+	row := settingsRows[g.settingsCursor]
+	switch {
+	case menuPressed(ebiten.KeyEscape, ebiten.StandardGamepadButtonRightRight):
+		if g.configPath != "" {
+			if err := g.config.Write(g.configPath); err != nil {
+				log.Printf("Failed to write settings: %v", err)
+			}
+		}
+		if err := g.save.Write(); err != nil {
+			log.Printf("Failed to write save: %v", err)
+		}
+		g.scenes.Switch(titleScene)
+	case menuPressed(ebiten.KeyArrowUp, ebiten.StandardGamepadButtonLeftTop):
+		g.settingsCursor = nextSettingsRow(g.settingsCursor, -1)
+	case menuPressed(ebiten.KeyArrowDown, ebiten.StandardGamepadButtonLeftBottom):
+		g.settingsCursor = nextSettingsRow(g.settingsCursor, 1)
+	case menuPressed(ebiten.KeyArrowLeft, ebiten.StandardGamepadButtonLeftLeft):
+		row.change(g, -1)
+	case menuPressed(ebiten.KeyArrowRight, ebiten.StandardGamepadButtonLeftRight),
+		menuPressed(ebiten.KeyEnter, ebiten.StandardGamepadButtonRightBottom):
+		row.change(g, 1)
+	default:
+		return nil
+	}
+	g.feedback(FeedbackUIClick)
+	return nil
+}
+
+func (sceneSettings) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Settings", 40, TextLarge, textColor)
+
+	// A window of rows around the cursor
+	const rows = 12 // Leaves room for a note
+	first := max(0, min(g.settingsCursor-rows/2, len(settingsRows)-rows))
+	y := 90.0
+	for i := first; i < len(settingsRows) && i < first+rows; i++ {
+		row := settingsRows[i]
+		if row.change == nil {
+			drawText(screen, row.label, 30, y, TextSmall, color.RGBA{180, 220, 255, 255})
+			y += 2 * textLineHeight
+			continue
+		}
+		clr := textColor
+		prefix := "  "
+		if i == g.settingsCursor {
+			prefix = "> "
+			clr = color.RGBA{255, 220, 100, 255}
+		}
+		drawText(screen, prefix+row.label, 40, y, TextSmall, clr)
+		drawText(screen, row.value(g), 180, y, TextSmall, clr)
+		y += 2 * textLineHeight
+	}
+	if note := settingsRows[g.settingsCursor].note; note != nil {
+		lines := wrapText(note(g), TextSmall, ScreenWidth-40)
+		for i, line := range lines {
+			drawTextCentered(screen, line, ScreenHeight-40-float64(len(lines)+1-i)*textLineHeight, TextSmall, color.RGBA{180, 220, 255, 255})
+		}
+	}
+	drawTextCentered(screen, "Up/Down: pick  Left/Right/Enter: change", ScreenHeight-40, TextSmall, textColor)
+	drawTextCentered(screen, "Esc: save and back", ScreenHeight-40+textLineHeight, TextSmall, textColor)
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Bird shadow parameters
+const (
+	BirdShadowRange = 120  // Birds further above a platform than this cast no shadow
+	BirdShadowAlpha = 0.45 // Shadow opacity with the bird right on the platform
+	shadowTexSize   = 32
+)
+
+// shadowTex is a soft radial falloff, stretched into an ellipse when drawn
+var shadowTex *ebiten.Image
+
+func softShadow() *ebiten.Image {
+	if shadowTex != nil {
+		return shadowTex
+	}
+	img := image.NewRGBA(image.Rect(0, 0, shadowTexSize, shadowTexSize))
+	c := float64(shadowTexSize) / 2
+	for y := 0; y < shadowTexSize; y++ {
+		for x := 0; x < shadowTexSize; x++ {
+			d := math.Hypot(float64(x)+0.5-c, float64(y)+0.5-c) / c
+			a := uint8(255 * math.Max(0, 1-d*d))
+			img.SetRGBA(x, y, color.RGBA{0, 0, 0, a})
+		}
+	}
+	shadowTex = ebiten.NewImageFromImage(img)
+	return shadowTex
+}
+
+// drawBirdShadows darkens platforms under birds so threats above are easy to
+// judge. Nearer birds cast smaller, darker shadows.
+func (g *Game) drawBirdShadows(screen *ebiten.Image) {
+	tex := softShadow()
+	for i := range g.platforms {
+		p := &g.platforms[i]
+		if !p.solid() {
+			continue
+		}
+		py := g.screenY(p.Y)
+		if py > ScreenHeight || py+PlatformHeight < 0 {
+			continue
+		}
+		// Clip to the platform so shadows never spill into the sky
+		surface := screen.SubImage(platformRect(p, py)).(*ebiten.Image)
+
+		for _, b := range g.birds {
+			d := p.Y - (b.Y + BirdHeight)
+			if d < 0 || d > BirdShadowRange {
+				continue
+			}
+			t := d / BirdShadowRange
+			w := BirdWidth * (0.6 + 0.6*t)
+			h := PlatformHeight * (0.8 + 0.4*t)
+			cx := b.X + BirdWidth/2
+			if cx+w/2 < p.X || cx-w/2 > p.X+PlatformWidth {
+				continue
+			}
+
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Scale(w/shadowTexSize, h/shadowTexSize)
+			op.GeoM.Translate(cx-w/2, py-h/4)
+			op.ColorM.Scale(1, 1, 1, BirdShadowAlpha*(1-t))
+			drawImage(surface, tex, op)
+		}
+	}
+}
+
+// drawLandingMarker shades the spot the player is predicted to land on
+func (g *Game) drawLandingMarker(screen *ebiten.Image) {
+	p, x := g.predictLanding()
+	if p == nil {
+		return
+	}
+	py := g.screenY(p.Y)
+	surface := screen.SubImage(platformRect(p, py)).(*ebiten.Image)
+
+	// Pulse gently so the marker reads as a hint, not part of the platform
+	pulse := 0.75 + 0.25*math.Sin(g.gameTime*8)
+	w, h := PlayerWidth*0.8, float64(PlatformHeight)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(w/shadowTexSize, h/shadowTexSize)
+	op.GeoM.Translate(x-w/2, py-h/4)
+	op.ColorM.Scale(1, 1, 1, 0.5*pulse)
+	drawImage(surface, softShadow(), op)
+}
+
+// platformRect is the pixel rectangle p covers on the image being drawn,
+// with its top at screen Y py
+func platformRect(p *Platform, py float64) image.Rectangle {
+	s := drawScale
+	return image.Rect(int(p.X*s), int(py*s), int((p.X+PlatformWidth)*s), int((py+PlatformHeight)*s))
+}
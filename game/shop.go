@@ -0,0 +1,342 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Coin and trail parameters
+const (
+	CoinPoints   = 10  // Points of a run for each coin it pays
+	MaxTrailDots = 48  // Trail dots alive at once; older ones make room
+	TrailLife    = 0.4 // Seconds a trail dot lasts
+)
+
+// ShopState is what the profile bought in the shop and has on
+type ShopState struct {
+	Owned map[string]int `json:"owned"` // Items bought, by ID: 1 for skins and trails, charges left for boosts
+	Skin  string         `json:"skin"`  // Skin worn, "" for the plain look
+	Trail string         `json:"trail"` // Trail worn, "" for none
+	Boost string         `json:"boost"` // Boost runs start with while its charges last, "" for none
+}
+
+// shopKind is a shelf of the shop
+type shopKind int
+
+const (
+	shopSkin  shopKind = iota // Tints the player, bought once
+	shopTrail                 // Leaves dots behind the player, bought once
+	shopBoost                 // Starts a run boosted, bought a run at a time
+)
+
+var shopKindNames = [...]string{
+	shopSkin:  "Skins",
+	shopTrail: "Trails",
+	shopBoost: "Starting boost",
+}
+
+// trailStyle is how a trail's dots look
+type trailStyle struct {
+	color   color.RGBA
+	rainbow bool    // Cycle through the hues instead of color
+	size    float64 // Dot radius when dropped
+	grow    float64 // Radius gained per second
+	jitter  float64 // How far dots scatter from the player
+}
+
+// shopItem is something coins buy
+type shopItem struct {
+	ID    string
+	Name  string
+	Kind  shopKind
+	Price int
+	tint  [3]float32 // Skins: color scale of the player sprite
+	trail trailStyle // Trails
+	boost int        // Boosts: boost type
+}
+
+// shopItems are every item on sale, shelf by shelf
+var shopItems = []shopItem{
+	{ID: "skin_ruby", Name: "Ruby", Kind: shopSkin, Price: 50, tint: [3]float32{1.3, 0.6, 0.6}},
+	{ID: "skin_ocean", Name: "Ocean", Kind: shopSkin, Price: 50, tint: [3]float32{0.6, 0.9, 1.3}},
+	{ID: "skin_forest", Name: "Forest", Kind: shopSkin, Price: 80, tint: [3]float32{0.6, 1.2, 0.6}},
+	{ID: "skin_shadow", Name: "Shadow", Kind: shopSkin, Price: 150, tint: [3]float32{0.45, 0.45, 0.55}},
+	{ID: "skin_gold", Name: "Gold", Kind: shopSkin, Price: 200, tint: [3]float32{1.3, 1.1, 0.4}},
+	{ID: "trail_sparkle", Name: "Sparkles", Kind: shopTrail, Price: 100,
+		trail: trailStyle{color: color.RGBA{255, 240, 150, 255}, size: 1.5, jitter: 4}},
+	{ID: "trail_smoke", Name: "Smoke", Kind: shopTrail, Price: 120,
+		trail: trailStyle{color: color.RGBA{200, 200, 200, 160}, size: 2, grow: 6, jitter: 2}},
+	{ID: "trail_rainbow", Name: "Rainbow", Kind: shopTrail, Price: 250,
+		trail: trailStyle{rainbow: true, size: 2.5}},
+	{ID: "boost_speed", Name: "Speed", Kind: shopBoost, Price: 30, boost: BoostSpeed},
+	{ID: "boost_jump", Name: "Jump", Kind: shopBoost, Price: 40, boost: BoostJump},
+	{ID: "boost_shield", Name: "Shield", Kind: shopBoost, Price: 60, boost: BoostShield},
+	{ID: "boost_multiplier", Name: "Multiplier", Kind: shopBoost, Price: 80, boost: BoostMultiplier},
+}
+
+// shopItemByID looks an item up, nil for an ID no longer on sale
+func shopItemByID(id string) *shopItem {
+	for i := range shopItems {
+		if shopItems[i].ID == id {
+			return &shopItems[i]
+		}
+	}
+	return nil
+}
+
+// worn points at the ID of the item of shelf k the profile has on
+func (s *ShopState) worn(k shopKind) *string {
+	switch k {
+	case shopSkin:
+		return &s.Skin
+	case shopTrail:
+		return &s.Trail
+	}
+	return &s.Boost
+}
+
+// earnCoins pays out the run that just ended. Written with the run.
+func (g *Game) earnCoins() {
+	if g.opts.kiosk != nil {
+		return // A cabinet's players have no profile to spend them
+	}
+	g.coinsEarned = g.score / CoinPoints
+	g.save.Coins += g.coinsEarned
+}
+
+// coinLines tell what the run paid on the game-over screen
+func coinLines(g *Game) []hudLine {
+	if g.coinsEarned == 0 {
+		return nil
+	}
+	return []hudLine{{fmt.Sprintf("+%d coins (%d in all)", g.coinsEarned, g.save.Coins), TextSmall}}
+}
+
+// boostedRun reports whether this run may start on a bought boost: the
+// player's own, under rules no one else is measured by
+func (g *Game) boostedRun() bool {
+	return g.countsSession() && !g.opts.headless && !g.opts.sandbox && g.opts.bench == nil &&
+		g.opts.race == nil && g.opts.daily == "" && g.opts.tournament == nil && g.opts.hotseat == nil
+}
+
+// outfit hands out the starting boost on the run's first tick, using up a
+// charge. The replay's first snapshot is taken after it, so replays and
+// continued runs keep the boost without spending another.
+func (g *Game) outfit() {
+	g.outfitted = true
+	item := shopItemByID(g.save.Shop.Boost)
+	if item == nil || !g.boostedRun() || g.save.Shop.Owned[item.ID] == 0 {
+		return
+	}
+	g.save.Shop.Owned[item.ID]--
+	if g.save.Shop.Owned[item.ID] == 0 {
+		g.save.Shop.Boost = ""
+	}
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+	g.startBoost(item.boost)
+}
+
+// tintPlayer colors the player sprite as the skin worn
+func (g *Game) tintPlayer(op *ebiten.DrawImageOptions) {
+	if item := shopItemByID(g.save.Shop.Skin); item != nil {
+		op.ColorM.Scale(float64(item.tint[0]), float64(item.tint[1]), float64(item.tint[2]), 1)
+	}
+}
+
+// trailDot is one dot of the player's trail, in world coordinates
+type trailDot struct {
+	X, Y float64
+	Life float64 // Seconds left
+	Hue  float64 // For rainbow trails
+}
+
+// updateTrail drops a dot behind the player and ages the others
+func (g *Game) updateTrail() {
+	dots := g.trail[:0]
+	for _, d := range g.trail {
+		if d.Life -= g.dt; d.Life > 0 {
+			dots = append(dots, d)
+		}
+	}
+	g.trail = dots
+	item := shopItemByID(g.save.Shop.Trail)
+	if item == nil || g.seeking || g.gameOver {
+		return
+	}
+	if len(g.trail) == MaxTrailDots {
+		g.trail = g.trail[1:]
+	}
+	j := item.trail.jitter
+	g.trail = append(g.trail, trailDot{
+		X:    g.player.X + (fxFloat64()*2-1)*j,
+		Y:    g.player.Y + PlayerHeight/3 + (fxFloat64()*2-1)*j,
+		Life: TrailLife,
+		Hue:  math.Mod(math.Abs(g.player.Y)*3, 360), // Climbing runs through the colors
+	})
+}
+
+// drawTrail draws the trail fading out behind the player
+func (g *Game) drawTrail(screen *ebiten.Image) {
+	item := shopItemByID(g.save.Shop.Trail)
+	if item == nil {
+		return
+	}
+	for _, d := range g.trail {
+		drawTrailDot(screen, item.trail, d.X, g.screenY(d.Y), d.Life, d.Hue)
+	}
+}
+
+// drawTrailDot draws one dot of style s with life seconds left
+func drawTrailDot(screen *ebiten.Image, s trailStyle, x, y, life, hue float64) {
+	a := life / TrailLife
+	c := s.color
+	if s.rainbow {
+		c = hsvToRGB(HSV{hue, 0.8, 1})
+	}
+	clr := color.RGBA{uint8(float64(c.R) * a), uint8(float64(c.G) * a), uint8(float64(c.B) * a), uint8(float64(c.A) * a)}
+	drawCircle(screen, x, y, s.size+s.grow*(TrailLife-life), clr)
+}
+
+// shopStatus is what a shop row says about item: its price, or that the
+// profile has it and whether it is on
+func (g *Game) shopStatus(item *shopItem) string {
+	owned, on := g.save.Shop.Owned[item.ID], *g.save.Shop.worn(item.Kind) == item.ID
+	switch {
+	case item.Kind == shopBoost && owned > 0 && on:
+		return fmt.Sprintf("x%d  on", owned)
+	case item.Kind == shopBoost && owned > 0:
+		return fmt.Sprintf("x%d", owned)
+	case owned > 0 && on:
+		return "Worn"
+	case owned > 0:
+		return "Owned"
+	}
+	return fmt.Sprintf("%d coins", item.Price)
+}
+
+// buy spends coins on item, false when the profile can't afford it. A
+// new item goes straight on.
+func (g *Game) buy(item *shopItem) bool {
+	s := &g.save.Shop
+	if g.save.Coins < item.Price {
+		return false
+	}
+	g.save.Coins -= item.Price
+	if s.Owned == nil {
+		s.Owned = map[string]int{}
+	}
+	s.Owned[item.ID]++
+	*s.worn(item.Kind) = item.ID
+	return true
+}
+
+// sceneShop sells skins, trails and starting boosts for the coins runs pay
+type sceneShop struct{}
+
+func (sceneShop) Update(g *Game) error {
+	item := &shopItems[g.shopCursor]
+	s := &g.save.Shop
+	switch {
+	case g.controller.JustPressed(input.ActionShop), menuPressed(ebiten.KeyEscape, ebiten.StandardGamepadButtonRightRight):
+		g.scenes.Switch(titleScene)
+	case menuPressed(ebiten.KeyArrowUp, ebiten.StandardGamepadButtonLeftTop):
+		g.shopCursor = (g.shopCursor + len(shopItems) - 1) % len(shopItems)
+	case menuPressed(ebiten.KeyArrowDown, ebiten.StandardGamepadButtonLeftBottom):
+		g.shopCursor = (g.shopCursor + 1) % len(shopItems)
+	case menuPressed(ebiten.KeyEnter, ebiten.StandardGamepadButtonRightBottom):
+		worn := s.worn(item.Kind)
+		switch {
+		case s.Owned[item.ID] == 0:
+			if g.buy(item) {
+				g.feedback(FeedbackBoostPickup)
+			}
+		case *worn == item.ID:
+			*worn = ""
+		default:
+			*worn = item.ID
+		}
+		g.writeShop()
+	case item.Kind == shopBoost && s.Owned[item.ID] > 0 && menuPressed(ebiten.KeyArrowRight, ebiten.StandardGamepadButtonLeftRight):
+		if g.buy(item) {
+			g.feedback(FeedbackBoostPickup)
+			g.writeShop()
+		}
+	default:
+		return nil
+	}
+	g.feedback(FeedbackUIClick)
+	return nil
+}
+
+// writeShop writes a purchase or a change of outfit to the save
+func (g *Game) writeShop() {
+	if err := g.save.Write(); err != nil {
+		log.Printf("Failed to write save: %v", err)
+	}
+}
+
+func (sceneShop) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	drawTextCentered(screen, "Shop", 40, TextLarge, textColor)
+	drawTextCentered(screen, fmt.Sprintf("Coins: %d", g.save.Coins), 64, TextSmall, color.RGBA{255, 220, 100, 255})
+
+	y := 84.0
+	for i := range shopItems {
+		item := &shopItems[i]
+		if i == 0 || shopItems[i-1].Kind != item.Kind {
+			y += textLineHeight / 2
+			drawText(screen, shopKindNames[item.Kind], 32, y, TextSmall, color.RGBA{180, 220, 255, 255})
+			y += textLineHeight + 2
+		}
+		clr := textColor
+		prefix := "  "
+		switch {
+		case i == g.shopCursor:
+			prefix = "> "
+			clr = color.RGBA{255, 220, 100, 255}
+		case g.save.Shop.Owned[item.ID] == 0 && g.save.Coins < item.Price:
+			clr = color.RGBA{140, 140, 140, 255} // Out of reach for now
+		}
+		drawText(screen, prefix+item.Name, 40, y, TextSmall, clr)
+		drawText(screen, g.shopStatus(item), 176, y, TextSmall, clr)
+		y += textLineHeight + 2
+	}
+
+	g.drawShopPreview(screen, &shopItems[g.shopCursor], y+40)
+	drawTextCentered(screen, "Up/Down: pick  Enter: buy/wear/remove", ScreenHeight-40, TextSmall, textColor)
+	drawTextCentered(screen, joinHints("  ", "Right: another boost", g.actionHint(input.ActionShop, "back")), ScreenHeight-40+textLineHeight, TextSmall, textColor)
+}
+
+// drawShopPreview shows item on the player: a skin in place of the one
+// worn, a trail streaming down, a boost's orb beside it
+func (g *Game) drawShopPreview(screen *ebiten.Image, item *shopItem, cy float64) {
+	cx := float64(ScreenWidth / 2)
+	if item.Kind == shopTrail {
+		for i := range 8 {
+			life := TrailLife * float64(8-i) / 8
+			drawTrailDot(screen, item.trail, cx, cy+PlayerHeight/3+float64(i)*4, life, float64(i)*45)
+		}
+	}
+	if img := g.sprite(&g.player); img != nil {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(cx-PlayerWidth/2, cy-PlayerHeight/2)
+		if item.Kind == shopSkin {
+			op.ColorM.Scale(float64(item.tint[0]), float64(item.tint[1]), float64(item.tint[2]), 1)
+		} else {
+			g.tintPlayer(op)
+		}
+		g.drawSprite(screen, img, op)
+	}
+	if item.Kind == shopBoost {
+		boostIcon(item.boost)(g, screen, cx+PlayerWidth, cy)
+		drawTextCentered(screen, "Each one starts one run boosted", cy+PlayerHeight/2+12, TextSmall, textColor)
+	}
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"doodlejump/game/audio"
+	"doodlejump/game/soundgen"
+)
+
+// feedbackSounds maps feedback events to clips: the shipped WAVs where
+// there is one, synthesized on the spot otherwise
+var feedbackSounds = map[FeedbackEvent]*audio.Sound{
+	FeedbackLanding: {
+		PCM:         audio.Clip("jump"),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.6,
+		PitchJitter: 0.08, // Bounces repeat constantly; vary them
+	},
+	FeedbackKill: {
+		PCM:         audio.Clip("hit"),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.5,
+		PitchJitter: 0.05,
+	},
+	FeedbackShieldHit: {
+		PCM:     soundgen.Tone(soundgen.WaveSine, 200, 1200, 0.25),
+		Channel: audio.ChannelSFX,
+		Volume:  0.7,
+	},
+	FeedbackGameOver: {
+		PCM:     audio.Clip("game_over"),
+		Channel: audio.ChannelSFX,
+		Volume:  0.6,
+	},
+	FeedbackBoostPickup: {
+		PCM:     audio.Clip("boost"),
+		Channel: audio.ChannelSFX,
+		Volume:  0.6,
+	},
+	FeedbackBirdChirp: {
+		PCM:         soundgen.Concat(soundgen.Tone(soundgen.WaveSine, 2600, 3400, 0.05), soundgen.Tone(soundgen.WaveSine, 3000, 2400, 0.07)),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.35,
+		PitchJitter: 0.15,
+	},
+	FeedbackPlatformCrack: {
+		PCM:         soundgen.Concat(soundgen.Tone(soundgen.WaveNoise, 0, 0, 0.04), soundgen.Tone(soundgen.WaveNoise, 0, 0, 0.08)),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.5,
+		PitchJitter: 0.1,
+	},
+	FeedbackShoot: {
+		PCM:         audio.Clip("shoot"),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.4,
+		PitchJitter: 0.1,
+	},
+	FeedbackPoof: {
+		PCM:         layered(soundgen.Tone(soundgen.WaveSine, 140, 70, 0.15), soundgen.Tone(soundgen.WaveNoise, 0, 0, 0.15)),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.4,
+		PitchJitter: 0.1,
+	},
+	FeedbackClang: {
+		PCM:         layered(soundgen.Tone(soundgen.WaveSquare, 880, 860, 0.25), soundgen.Tone(soundgen.WaveSine, 1320, 1300, 0.25)),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.35,
+		PitchJitter: 0.05,
+	},
+	FeedbackSquelch: {
+		PCM:         soundgen.Concat(soundgen.Tone(soundgen.WaveTriangle, 500, 120, 0.12), soundgen.Tone(soundgen.WaveSine, 180, 260, 0.08)),
+		Channel:     audio.ChannelSFX,
+		Volume:      0.5,
+		PitchJitter: 0.1,
+	},
+	FeedbackUIClick: {
+		PCM:     soundgen.Tone(soundgen.WaveSine, 1000, 1000, 0.05),
+		Channel: audio.ChannelUI,
+		Volume:  0.5,
+	},
+}
+
+// layered mixes clips of the same length into one, each at half volume
+// so the sum stays clear of clipping
+func layered(clips ...[]byte) []byte {
+	out := make([]byte, len(clips[0]))
+	for _, c := range clips {
+		soundgen.MixInto(out, c, 0, 0.5)
+	}
+	return out
+}
+
+// applyAudioSettings pushes the persisted volumes into the audio manager
+func (g *Game) applyAudioSettings() {
+	s := g.save.Settings
+	master := g.config.Volume
+	g.audio.SetVolume(audio.ChannelSFX, s.SFXVolume*master)
+	music := s.MusicVolume * master
+	if g.opts.idle {
+		music *= IdleMusicScale
+	}
+	g.audio.SetVolume(audio.ChannelMusic, music)
+	g.audio.SetVolume(audio.ChannelUI, s.UIVolume*master)
+	g.audio.SetMuted(audio.ChannelSFX, s.SFXMuted)
+	g.audio.SetMuted(audio.ChannelMusic, s.MusicMuted)
+	g.audio.SetMuted(audio.ChannelUI, s.UIMuted)
+}
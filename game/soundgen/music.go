@@ -0,0 +1,44 @@
+package soundgen
+
+import "math"
+
+// MusicTempo is the music loop's speed in beats per minute
+const MusicTempo = 112.0
+
+// musicChords are the roots of each four-beat bar, as semitones above A3
+var musicChords = []int{3, 0, -4, -2} // C Am F G
+
+// note returns the frequency of the note semitones above A3
+func note(semitones int) float64 {
+	return 220 * math.Pow(2, float64(semitones)/12)
+}
+
+// Music synthesizes the looping background track: a triangle bass on each
+// bar's root under a soft sine arpeggio, quiet enough to sit under the
+// effects. Notes past the end wrap to the start, so the loop is seamless.
+func Music() []byte {
+	beat := 60 / MusicTempo
+	pcm := Silence(beat * 4 * float64(len(musicChords)))
+
+	for bar, root := range musicChords {
+		start := float64(bar) * beat * 4
+
+		// Bass on beats one and three, an octave down
+		for _, b := range []float64{0, 2} {
+			f := note(root - 12)
+			MixInto(pcm, Tone(WaveTriangle, f, f, beat*1.8), start+b*beat, 0.35)
+		}
+
+		// Major or minor triad depending on the chord, arpeggiated in eighths
+		third := 4
+		if root == 0 {
+			third = 3
+		}
+		arp := []int{0, third, 7, 12, 7, third, 0, third}
+		for i, step := range arp {
+			f := note(root + step + 12)
+			MixInto(pcm, Tone(WaveSine, f, f, beat*0.45), start+float64(i)*beat/2, 0.18)
+		}
+	}
+	return pcm
+}
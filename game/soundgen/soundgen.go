@@ -0,0 +1,31 @@
+// Package soundgen synthesizes the game's sounds. It backs the asset
+// generator, the in-game fallbacks used when an embedded clip can't be
+// decoded, and the ambient loops, and needs no audio device.
+package soundgen
+
+// SampleRate is the rate every clip is synthesized at
+const SampleRate = 44100
+
+// bytesPerFrame is 16-bit little-endian stereo
+const bytesPerFrame = 4
+
+// Clips maps the name of every shipped clip to its recipe. The asset
+// generator writes each to sounds/<name>.wav.
+var Clips = map[string]func() []byte{
+	"jump": func() []byte {
+		return Tone(WaveTriangle, 300, 600, 0.12)
+	},
+	"shoot": func() []byte {
+		return Concat(Tone(WaveSquare, 1400, 700, 0.05), Tone(WaveNoise, 0, 0, 0.03))
+	},
+	"boost": func() []byte {
+		return Concat(Tone(WaveSine, 600, 900, 0.08), Tone(WaveSine, 900, 1400, 0.12))
+	},
+	"hit": func() []byte {
+		return Concat(Tone(WaveSquare, 900, 300, 0.08), Tone(WaveNoise, 0, 0, 0.1))
+	},
+	"game_over": func() []byte {
+		return Concat(Tone(WaveSquare, 440, 330, 0.2), Tone(WaveSquare, 330, 110, 0.5))
+	},
+	"music": Music,
+}
@@ -0,0 +1,140 @@
+package soundgen
+
+import (
+	"math"
+)
+
+// Waveform selects the oscillator used by Tone
+type Waveform int
+
+const (
+	WaveSine Waveform = iota
+	WaveSquare
+	WaveTriangle
+	WaveNoise
+)
+
+// Tone synthesizes a clip sweeping from freqStart to freqEnd Hz over
+// seconds, with a short attack and exponential decay envelope
+func Tone(wave Waveform, freqStart, freqEnd, seconds float64) []byte {
+	frames := int(seconds * SampleRate)
+	pcm := make([]byte, frames*bytesPerFrame)
+
+	phase := 0.0
+	noise := uint32(0x12345678)
+	for i := 0; i < frames; i++ {
+		t := float64(i) / float64(frames)
+		freq := freqStart + (freqEnd-freqStart)*t
+		phase += freq / SampleRate
+		phase -= math.Floor(phase)
+
+		var v float64
+		switch wave {
+		case WaveSine:
+			v = math.Sin(2 * math.Pi * phase)
+		case WaveSquare:
+			v = 1
+			if phase >= 0.5 {
+				v = -1
+			}
+		case WaveTriangle:
+			v = 4*math.Abs(phase-0.5) - 1
+		case WaveNoise:
+			// xorshift keeps noise deterministic for a given clip
+			noise ^= noise << 13
+			noise ^= noise >> 17
+			noise ^= noise << 5
+			v = float64(noise)/float64(math.MaxUint32)*2 - 1
+		}
+
+		// Envelope: 5ms attack, then exponential decay
+		env := math.Exp(-4 * t)
+		if attack := float64(i) / (0.005 * SampleRate); attack < 1 {
+			env *= attack
+		}
+
+		s := int16(v * env * 0.5 * math.MaxInt16)
+		o := i * bytesPerFrame
+		for ch := 0; ch < 2; ch++ {
+			pcm[o+ch*2] = byte(s)
+			pcm[o+ch*2+1] = byte(uint16(s) >> 8)
+		}
+	}
+	return pcm
+}
+
+// Concat joins clips end to end
+func Concat(clips ...[]byte) []byte {
+	var out []byte
+	for _, c := range clips {
+		out = append(out, c...)
+	}
+	return out
+}
+
+// Silence returns seconds of silent PCM
+func Silence(seconds float64) []byte {
+	return make([]byte, int(seconds*SampleRate)*bytesPerFrame)
+}
+
+// MixInto adds src into dst starting at offset seconds, scaled by gain and
+// clipped to the 16-bit range. Samples past the end of dst wrap around so
+// loops stay seamless.
+func MixInto(dst, src []byte, offset, gain float64) {
+	frames := len(dst) / bytesPerFrame
+	if frames == 0 {
+		return
+	}
+	start := int(offset * SampleRate)
+	for i := 0; i < len(src)/bytesPerFrame; i++ {
+		f := (start + i) % frames
+		for ch := 0; ch < 2; ch++ {
+			si := i*bytesPerFrame + ch*2
+			di := f*bytesPerFrame + ch*2
+			a := int32(int16(uint16(dst[di]) | uint16(dst[di+1])<<8))
+			b := int32(float64(int16(uint16(src[si])|uint16(src[si+1])<<8)) * gain)
+			v := a + b
+			if v > math.MaxInt16 {
+				v = math.MaxInt16
+			} else if v < math.MinInt16 {
+				v = math.MinInt16
+			}
+			dst[di] = byte(v)
+			dst[di+1] = byte(uint16(int16(v)) >> 8)
+		}
+	}
+}
+
+// FilteredNoise synthesizes seconds of one-pole low-passed noise. smoothing
+// in 0.0 - 1.0 darkens the noise (rain ~0.3, wind ~0.98); wobble adds a slow
+// amplitude swell at that many Hz, 0 for none.
+func FilteredNoise(seconds, smoothing, wobble float64, seed uint32) []byte {
+	frames := int(seconds * SampleRate)
+	pcm := make([]byte, frames*bytesPerFrame)
+
+	noise := seed | 1
+	prev := 0.0
+	peak := 1 - smoothing // One-pole filters lose energy; compensate
+	for i := 0; i < frames; i++ {
+		noise ^= noise << 13
+		noise ^= noise >> 17
+		noise ^= noise << 5
+		n := float64(noise)/float64(math.MaxUint32)*2 - 1
+		prev = prev*smoothing + n*(1-smoothing)
+
+		amp := 0.4 / math.Sqrt(peak)
+		if wobble > 0 {
+			// Whole number of cycles per clip keeps the swell loopable
+			cycles := math.Max(1, math.Round(wobble*seconds))
+			amp *= 0.6 + 0.4*math.Sin(2*math.Pi*cycles*float64(i)/float64(frames))
+		}
+
+		s := int16(math.Max(-1, math.Min(1, prev*amp)) * math.MaxInt16)
+		o := i * bytesPerFrame
+		for ch := 0; ch < 2; ch++ {
+			pcm[o+ch*2] = byte(s)
+			pcm[o+ch*2+1] = byte(uint16(s) >> 8)
+		}
+	}
+	return pcm
+}
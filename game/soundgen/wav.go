@@ -0,0 +1,87 @@
+package soundgen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// EncodeWAV writes 16-bit stereo pcm as a 16-bit mono WAV at SampleRate.
+// Every synthesized clip has identical channels, so keeping one loses nothing.
+func EncodeWAV(pcm []byte) []byte {
+	frames := len(pcm) / bytesPerFrame
+	data := make([]byte, frames*2)
+	for i := 0; i < frames; i++ {
+		data[i*2] = pcm[i*bytesPerFrame]
+		data[i*2+1] = pcm[i*bytesPerFrame+1]
+	}
+
+	var b bytes.Buffer
+	le := func(v any) { binary.Write(&b, binary.LittleEndian, v) }
+	b.WriteString("RIFF")
+	le(uint32(36 + len(data)))
+	b.WriteString("WAVEfmt ")
+	le(uint32(16))         // fmt chunk size
+	le(uint16(1))          // PCM
+	le(uint16(1))          // Mono
+	le(uint32(SampleRate)) // Sample rate
+	le(uint32(SampleRate * 2))
+	le(uint16(2))  // Block align
+	le(uint16(16)) // Bits per sample
+	b.WriteString("data")
+	le(uint32(len(data)))
+	b.Write(data)
+	return b.Bytes()
+}
+
+// DecodeWAV reads a 16-bit mono or stereo PCM WAV at SampleRate into
+// 16-bit stereo pcm
+func DecodeWAV(wav []byte) ([]byte, error) {
+	if len(wav) < 12 || string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		return nil, errors.New("not a WAV file")
+	}
+
+	channels := 0
+	for rest := wav[12:]; len(rest) >= 8; {
+		id, size := string(rest[0:4]), int(binary.LittleEndian.Uint32(rest[4:8]))
+		rest = rest[8:]
+		if size > len(rest) {
+			return nil, fmt.Errorf("chunk %q is truncated", id)
+		}
+		chunk := rest[:size]
+		rest = rest[size+size%2:] // Chunks are padded to even sizes
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, errors.New("fmt chunk is too short")
+			}
+			format := binary.LittleEndian.Uint16(chunk[0:2])
+			channels = int(binary.LittleEndian.Uint16(chunk[2:4]))
+			rate := binary.LittleEndian.Uint32(chunk[4:8])
+			bits := binary.LittleEndian.Uint16(chunk[14:16])
+			if format != 1 || bits != 16 || (channels != 1 && channels != 2) {
+				return nil, fmt.Errorf("unsupported format %d, %d bits, %d channels; want 16-bit PCM", format, bits, channels)
+			}
+			if rate != SampleRate {
+				return nil, fmt.Errorf("sample rate %d, want %d", rate, SampleRate)
+			}
+		case "data":
+			if channels == 0 {
+				return nil, errors.New("data chunk before fmt chunk")
+			}
+			if channels == 2 {
+				return chunk[:len(chunk)/bytesPerFrame*bytesPerFrame], nil
+			}
+			frames := len(chunk) / 2
+			pcm := make([]byte, frames*bytesPerFrame)
+			for i := 0; i < frames; i++ {
+				lo, hi := chunk[i*2], chunk[i*2+1]
+				copy(pcm[i*bytesPerFrame:], []byte{lo, hi, lo, hi})
+			}
+			return pcm, nil
+		}
+	}
+	return nil, errors.New("no data chunk")
+}
@@ -0,0 +1,149 @@
+package spritegen
+
+import (
+	"image"
+	"image/color"
+)
+
+// Platform skins, the materials biomes and themes draw platforms in
+const (
+	SkinWooden   = "wooden"
+	SkinIcy      = "icy"
+	SkinMetallic = "metallic"
+	SkinCloud    = "cloud"
+)
+
+// PlatformSkins lists every skin PlatformSkin can draw
+var PlatformSkins = []string{SkinWooden, SkinIcy, SkinMetallic, SkinCloud}
+
+// PlatformSkin draws a platform in the material named by skin. Skins keep
+// their material colors whatever the palette; an unknown skin draws the
+// palette's plain platform.
+func (p Palette) PlatformSkin(skin string) *image.RGBA {
+	switch skin {
+	case SkinWooden:
+		return woodenPlatform()
+	case SkinIcy:
+		return icyPlatform()
+	case SkinMetallic:
+		return metallicPlatform()
+	case SkinCloud:
+		return cloudPlatform()
+	}
+	return p.Platform()
+}
+
+// fillPlatform returns a platform-sized image filled with c
+func fillPlatform(c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, PlatformWidth, PlatformHeight))
+	for y := 0; y < PlatformHeight; y++ {
+		for x := 0; x < PlatformWidth; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// woodenPlatform draws planks with grain, seams and nails
+func woodenPlatform() *image.RGBA {
+	img := fillPlatform(color.RGBA{150, 100, 55, 255})
+	grain := color.RGBA{120, 78, 40, 255}
+	seam := color.RGBA{90, 55, 25, 255}
+
+	// Broken grain lines, offset per plank so they don't line up
+	for x := 0; x < PlatformWidth; x++ {
+		plank := x / 15
+		if (x+plank*3)%7 != 0 {
+			img.Set(x, 3+plank%2, grain)
+		}
+		if (x+plank*5)%9 != 0 {
+			img.Set(x, 7-plank%2, grain)
+		}
+	}
+
+	// Plank seams with a nail near the top and bottom
+	for x := 15; x < PlatformWidth; x += 15 {
+		for y := 0; y < PlatformHeight; y++ {
+			img.Set(x, y, seam)
+		}
+		img.Set(x-2, 2, seam)
+		img.Set(x-2, PlatformHeight-3, seam)
+	}
+	for x := 0; x < PlatformWidth; x++ {
+		img.Set(x, PlatformHeight-1, seam)
+	}
+	return img
+}
+
+// icyPlatform draws a pale slab with a frosted top and glints
+func icyPlatform() *image.RGBA {
+	img := fillPlatform(color.RGBA{185, 225, 245, 255})
+	frost := color.RGBA{245, 252, 255, 255}
+	shade := color.RGBA{140, 195, 230, 255}
+
+	for x := 0; x < PlatformWidth; x++ {
+		img.Set(x, 0, frost)
+		if x%4 != 0 {
+			img.Set(x, 1, frost)
+		}
+		img.Set(x, PlatformHeight-1, shade)
+	}
+
+	// Diagonal glints
+	for x := 6; x < PlatformWidth-4; x += 13 {
+		for i := 0; i < 4; i++ {
+			img.Set(x+i, 6-i, frost)
+		}
+	}
+	return img
+}
+
+// metallicPlatform draws a steel girder with a beveled edge and rivets
+func metallicPlatform() *image.RGBA {
+	img := fillPlatform(color.RGBA{150, 155, 165, 255})
+	light := color.RGBA{200, 205, 215, 255}
+	dark := color.RGBA{95, 100, 110, 255}
+
+	for x := 0; x < PlatformWidth; x++ {
+		img.Set(x, 0, light)
+		img.Set(x, 1, light)
+		img.Set(x, PlatformHeight-1, dark)
+		img.Set(x, PlatformHeight-2, dark)
+	}
+	for x := 4; x < PlatformWidth; x += 10 {
+		img.Set(x, 4, dark)
+		img.Set(x+1, 4, dark)
+		img.Set(x, 5, dark)
+		img.Set(x+1, 5, light)
+	}
+	return img
+}
+
+// cloudPlatform draws a puffy bank of small circles with a flat base
+func cloudPlatform() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, PlatformWidth, PlatformHeight))
+	fill := color.RGBA{250, 250, 255, 240}
+	shade := color.RGBA{215, 220, 235, 240}
+
+	for y := 0; y < PlatformHeight; y++ {
+		for x := 0; x < PlatformWidth; x++ {
+			inside := y >= PlatformHeight/2 && x >= 2 && x < PlatformWidth-2
+			for cx := 6; cx < PlatformWidth; cx += 8 {
+				dx, dy := x-cx, y-5
+				if dx*dx+dy*dy <= 25 {
+					inside = true
+					break
+				}
+			}
+			if !inside {
+				continue
+			}
+			if y >= PlatformHeight-2 {
+				img.Set(x, y, shade)
+			} else {
+				img.Set(x, y, fill)
+			}
+		}
+	}
+	return img
+}
@@ -0,0 +1,399 @@
+// Package spritegen procedurally draws the game's sprites. It backs the
+// asset generator and the in-game placeholders used when an embedded
+// asset can't be loaded.
+package spritegen
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// Sprite dimensions produced by the generators
+const (
+	PlayerSize     = 40
+	PlatformWidth  = 60
+	PlatformHeight = 10
+	BirdWidth      = 40
+	BirdHeight     = 30
+	CloudWidth     = 80
+	CloudHeight    = 40
+	MountainWidth  = 1200
+	MountainHeight = 800
+)
+
+// Palette holds every color the generators draw with
+type Palette struct {
+	PlayerBody, PlayerWing, Eye, Pupil, Beak color.RGBA
+	PlatformBase, PlatformRivet              color.RGBA
+	BirdBody, BirdWing                       color.RGBA
+	CloudFill                                color.RGBA
+	Mountains                                []color.RGBA // Back, middle and front layers
+}
+
+// Classic is the palette of the shipped assets
+var Classic = Palette{
+	PlayerBody:    color.RGBA{50, 100, 220, 255},
+	PlayerWing:    color.RGBA{100, 150, 240, 255},
+	Eye:           color.RGBA{255, 255, 255, 255},
+	Pupil:         color.RGBA{0, 0, 0, 255},
+	Beak:          color.RGBA{255, 200, 0, 255},
+	PlatformBase:  color.RGBA{100, 200, 255, 255},
+	PlatformRivet: color.RGBA{50, 150, 200, 255},
+	BirdBody:      color.RGBA{200, 100, 50, 255},
+	BirdWing:      color.RGBA{200, 150, 50, 255},
+	CloudFill:     color.RGBA{255, 255, 255, 230},
+	Mountains: []color.RGBA{
+		{160, 170, 180, 255}, // Back mountains (lighter gray)
+		{130, 140, 160, 255}, // Middle mountains (medium gray-blue)
+		{100, 110, 140, 255}, // Front mountains (darker blue-gray)
+	},
+}
+
+// Dusk is a warm evening palette
+var Dusk = Palette{
+	PlayerBody:    color.RGBA{120, 60, 160, 255},
+	PlayerWing:    color.RGBA{170, 110, 200, 255},
+	Eye:           color.RGBA{255, 240, 220, 255},
+	Pupil:         color.RGBA{30, 10, 30, 255},
+	Beak:          color.RGBA{255, 150, 60, 255},
+	PlatformBase:  color.RGBA{230, 150, 110, 255},
+	PlatformRivet: color.RGBA{170, 90, 70, 255},
+	BirdBody:      color.RGBA{90, 60, 80, 255},
+	BirdWing:      color.RGBA{130, 90, 110, 255},
+	CloudFill:     color.RGBA{255, 210, 200, 230},
+	Mountains: []color.RGBA{
+		{170, 120, 140, 255},
+		{130, 90, 120, 255},
+		{90, 60, 100, 255},
+	},
+}
+
+// Mono is a high-contrast grayscale palette
+var Mono = Palette{
+	PlayerBody:    color.RGBA{40, 40, 40, 255},
+	PlayerWing:    color.RGBA{90, 90, 90, 255},
+	Eye:           color.RGBA{255, 255, 255, 255},
+	Pupil:         color.RGBA{0, 0, 0, 255},
+	Beak:          color.RGBA{200, 200, 200, 255},
+	PlatformBase:  color.RGBA{220, 220, 220, 255},
+	PlatformRivet: color.RGBA{120, 120, 120, 255},
+	BirdBody:      color.RGBA{70, 70, 70, 255},
+	BirdWing:      color.RGBA{140, 140, 140, 255},
+	CloudFill:     color.RGBA{250, 250, 250, 230},
+	Mountains: []color.RGBA{
+		{170, 170, 170, 255},
+		{130, 130, 130, 255},
+		{90, 90, 90, 255},
+	},
+}
+
+// Palettes maps palette names to palettes for tools
+var Palettes = map[string]Palette{
+	"classic": Classic,
+	"dusk":    Dusk,
+	"mono":    Mono,
+}
+
+// MountainRoughness returns the midpoint displacement roughness for layer i
+func MountainRoughness(i int) float64 {
+	return 0.8 - float64(i)*0.2
+}
+
+// Player draws the flying bird-like player character
+func (p Palette) Player() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, PlayerSize, PlayerSize))
+
+	// Draw bird-like body
+	for y := 10; y < 30; y++ {
+		for x := 10; x < 30; x++ {
+			dx := float64(x - 20)
+			dy := float64(y - 20)
+			if dx*dx+dy*dy < 10*10 {
+				img.Set(x, y, p.PlayerBody)
+			}
+		}
+	}
+
+	// Draw wings - curved ellipses on both sides
+	for _, cx := range []int{8, 32} {
+		for y := 15; y < 25; y++ {
+			for x := cx - 6; x < cx+7; x++ {
+				dx := float64(x - cx)
+				dy := float64(y - 20)
+				if dx*dx/36+dy*dy/25 < 1 {
+					img.Set(x, y, p.PlayerWing)
+				}
+			}
+		}
+	}
+
+	// Draw eyes with pupils
+	for _, ex := range []int{16, 22} {
+		for y := 14; y < 18; y++ {
+			for x := ex; x < ex+3; x++ {
+				img.Set(x, y, p.Eye)
+			}
+		}
+		for y := 15; y < 17; y++ {
+			img.Set(ex+1, y, p.Pupil)
+		}
+	}
+
+	// Draw beak
+	for y := 17; y < 22; y++ {
+		for x := 30; x < 35; x++ {
+			dx := float64(x - 32)
+			dy := float64(y - 19)
+			if dx*dx/25+dy*dy/12 < 1 {
+				img.Set(x, y, p.Beak)
+			}
+		}
+	}
+
+	return img
+}
+
+// Platform draws a platform with darker rivets
+func (p Palette) Platform() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, PlatformWidth, PlatformHeight))
+
+	for y := 0; y < PlatformHeight; y++ {
+		for x := 0; x < PlatformWidth; x++ {
+			img.Set(x, y, p.PlatformBase)
+		}
+	}
+
+	// Add some details
+	for y := 2; y < 8; y++ {
+		for x := 5; x < 55; x += 10 {
+			img.Set(x, y, p.PlatformRivet)
+		}
+	}
+
+	return img
+}
+
+// BirdLeft draws a left-facing bird enemy
+func (p Palette) BirdLeft() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, BirdWidth, BirdHeight))
+
+	// Draw bird body
+	for y := 10; y < 25; y++ {
+		for x := 5; x < 35; x++ {
+			img.Set(x, y, p.BirdBody)
+		}
+	}
+
+	// Draw wings
+	for y := 5; y < 15; y++ {
+		for x := 0; x < 15; x++ {
+			img.Set(x, y, p.BirdWing)
+		}
+		for x := 25; x < 40; x++ {
+			img.Set(x, y, p.BirdWing)
+		}
+	}
+
+	// Draw eye
+	for y := 12; y < 16; y++ {
+		for x := 8; x < 12; x++ {
+			img.Set(x, y, p.Eye)
+		}
+	}
+	for y := 13; y < 15; y++ {
+		for x := 9; x < 11; x++ {
+			img.Set(x, y, p.Pupil)
+		}
+	}
+
+	// Draw beak
+	for y := 17; y < 20; y++ {
+		for x := 0; x < 5; x++ {
+			img.Set(x, y, p.Beak)
+		}
+	}
+
+	return img
+}
+
+// BirdRight draws a right-facing bird enemy
+func (p Palette) BirdRight() *image.RGBA {
+	return FlipHorizontal(p.BirdLeft())
+}
+
+// BirdPerchedLeft draws a left-facing bird sitting on a platform, wings
+// folded and feet on the bottom row
+func (p Palette) BirdPerchedLeft() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, BirdWidth, BirdHeight))
+
+	// Draw bird body, a little rounder than in flight
+	for y := 8; y < 25; y++ {
+		for x := 7; x < 33; x++ {
+			img.Set(x, y, p.BirdBody)
+		}
+	}
+
+	// Draw the folded wing along the body
+	for y := 13; y < 21; y++ {
+		for x := 15; x < 31; x++ {
+			img.Set(x, y, p.BirdWing)
+		}
+	}
+
+	// Draw eye
+	for y := 10; y < 14; y++ {
+		for x := 10; x < 14; x++ {
+			img.Set(x, y, p.Eye)
+		}
+	}
+	for y := 11; y < 13; y++ {
+		for x := 11; x < 13; x++ {
+			img.Set(x, y, p.Pupil)
+		}
+	}
+
+	// Draw beak
+	for y := 15; y < 18; y++ {
+		for x := 2; x < 7; x++ {
+			img.Set(x, y, p.Beak)
+		}
+	}
+
+	// Draw legs
+	for y := 25; y < BirdHeight; y++ {
+		for _, x := range []int{16, 17, 23, 24} {
+			img.Set(x, y, p.Beak)
+		}
+	}
+
+	return img
+}
+
+// BirdPerchedRight draws a right-facing perched bird
+func (p Palette) BirdPerchedRight() *image.RGBA {
+	return FlipHorizontal(p.BirdPerchedLeft())
+}
+
+// FlipHorizontal returns a mirrored copy of img
+func FlipHorizontal(img *image.RGBA) *image.RGBA {
+	b := img.Bounds()
+	out := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			out.Set(x, y, img.At(b.Max.X-1-(x-b.Min.X), y))
+		}
+	}
+	return out
+}
+
+// Cloud draws a cloud from overlapping circles
+func (p Palette) Cloud() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, CloudWidth, CloudHeight))
+
+	centers := []struct{ x, y, r int }{
+		{20, 20, 15},
+		{35, 15, 12},
+		{50, 18, 14},
+		{60, 20, 10},
+	}
+
+	for y := 0; y < CloudHeight; y++ {
+		for x := 0; x < CloudWidth; x++ {
+			// Check if point is inside any of the circles
+			for _, c := range centers {
+				dx := float64(x - c.x)
+				dy := float64(y - c.y)
+				if math.Sqrt(dx*dx+dy*dy) <= float64(c.r) {
+					img.Set(x, y, p.CloudFill)
+					break
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// Mountain draws a mountain layer silhouette using midpoint displacement
+func Mountain(width, height int, baseColor color.RGBA, roughness float64, rng *rand.Rand) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// Generate mountain silhouette using midpoint displacement
+	points := make([]float64, width)
+
+	// Start with a more natural mountain shape
+	points[0] = float64(height) * 0.8
+	points[width-1] = float64(height) * 0.8
+
+	var subdivide func(start, end int, displacement float64)
+	subdivide = func(start, end int, displacement float64) {
+		if end-start < 2 {
+			return
+		}
+
+		mid := (start + end) / 2
+		points[mid] = (points[start] + points[end]) / 2
+		points[mid] += (rng.Float64()*2 - 1) * displacement
+
+		// Ensure the mountain stays within bounds but allow for more height variation
+		if points[mid] < float64(height)*0.3 {
+			points[mid] = float64(height) * 0.3
+		}
+		if points[mid] > float64(height)*0.9 {
+			points[mid] = float64(height) * 0.9
+		}
+
+		subdivide(start, mid, displacement*roughness)
+		subdivide(mid, end, displacement*roughness)
+	}
+
+	subdivide(0, width-1, float64(height)*0.4)
+
+	// Smooth out the points
+	smoothed := make([]float64, width)
+	copy(smoothed, points)
+	for i := 1; i < width-1; i++ {
+		smoothed[i] = (points[i-1] + points[i]*2 + points[i+1]) / 4
+	}
+	points = smoothed
+
+	// Fill the mountain with gradient and proper alpha blending
+	for x := 0; x < width; x++ {
+		mountainHeight := int(points[x])
+		for y := mountainHeight; y < height; y++ {
+			progress := float64(y-mountainHeight) / float64(height-mountainHeight)
+
+			// Add some noise to the color
+			noise := rng.Float64()*0.1 - 0.05
+
+			// Calculate alpha for smooth blending
+			alpha := uint8(255 * (1.0 - math.Pow(progress, 0.5)))
+
+			r := uint8(float64(baseColor.R) * (1.0 - progress*0.3 + noise))
+			g := uint8(float64(baseColor.G) * (1.0 - progress*0.3 + noise))
+			b := uint8(float64(baseColor.B) * (1.0 - progress*0.3 + noise))
+
+			img.SetRGBA(x, y, color.RGBA{r, g, b, alpha})
+		}
+	}
+
+	return img
+}
+
+// Scale enlarges img by an integer factor with nearest-neighbor sampling,
+// keeping pixel art crisp for high-DPI builds and previews
+func Scale(img *image.RGBA, factor int) *image.RGBA {
+	if factor <= 1 {
+		return img
+	}
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx()*factor, b.Dy()*factor))
+	for y := 0; y < b.Dy()*factor; y++ {
+		for x := 0; x < b.Dx()*factor; x++ {
+			out.SetRGBA(x, y, img.RGBAAt(b.Min.X+x/factor, b.Min.Y+y/factor))
+		}
+	}
+	return out
+}
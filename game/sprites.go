@@ -0,0 +1,163 @@
+package game
+
+import (
+	"image"
+	"strings"
+
+	"doodlejump/game/level"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Entities with sprites in the registry
+const (
+	EntityPlayer   = "player"
+	EntityPlatform = "platform"
+	EntityBird     = "bird"
+	EntityCloud    = "cloud"
+)
+
+// VariantPerched is the sprite of a bird sitting on a platform
+const VariantPerched = "perched"
+
+// Facing is the direction a sprite was drawn looking in
+type Facing int
+
+const (
+	FacingAny Facing = iota // Drawn for either direction
+	FacingLeft
+	FacingRight
+)
+
+// facingOf returns the facing for a +1/-1 direction
+func facingOf(direction int) Facing {
+	if direction > 0 {
+		return FacingRight
+	}
+	return FacingLeft
+}
+
+// SpriteKey identifies a sprite: the entity it draws, an optional variant
+// such as a platform skin or an animation state, and the way it faces
+type SpriteKey struct {
+	Entity  string
+	Variant string
+	Facing  Facing
+}
+
+// spriteKeyFor parses a sprite file name such as "bird_left",
+// "platform_icy" or "bird_perched_left" into its key: after the entity
+// comes a variant, a facing, or a variant and then a facing
+func spriteKeyFor(name string) SpriteKey {
+	entity, rest, _ := strings.Cut(name, "_")
+	key := SpriteKey{Entity: entity, Variant: rest}
+	for suffix, facing := range map[string]Facing{"left": FacingLeft, "right": FacingRight} {
+		if rest == suffix {
+			key.Variant, key.Facing = "", facing
+		} else if v, ok := strings.CutSuffix(rest, "_"+suffix); ok {
+			key.Variant, key.Facing = v, facing
+		}
+	}
+	return key
+}
+
+// sprited is an entity drawn with a sprite from the registry
+type sprited interface {
+	spriteKey(g *Game) SpriteKey
+}
+
+// SpriteRegistry holds every sprite the renderer draws. It starts from the
+// embedded assets and the level's theme replaces entries, so new entities
+// and skins only register sprites. A nil registry, as in headless games,
+// has no sprites.
+type SpriteRegistry struct {
+	images map[SpriteKey]*ebiten.Image
+	half   bool // Sprites are kept at half resolution and drawn scaled back up
+}
+
+// loadSprites registers every sprite a theme may replace from the embedded
+// assets. half keeps them at half resolution, to save memory.
+func loadSprites(half bool) *SpriteRegistry {
+	r := &SpriteRegistry{images: map[SpriteKey]*ebiten.Image{}, half: half}
+	for _, name := range level.SpriteNames {
+		r.Register(spriteKeyFor(name), r.upload(loadImage("./assets/"+name+".png")))
+	}
+	return r
+}
+
+// upload turns a decoded sprite into an image for the registry, at the
+// registry's resolution
+func (r *SpriteRegistry) upload(img image.Image) *ebiten.Image {
+	if r.half {
+		img = halfImage(img)
+	}
+	return ebiten.NewImageFromImage(img)
+}
+
+// Register adds img under key, replacing any sprite already there
+func (r *SpriteRegistry) Register(key SpriteKey, img *ebiten.Image) {
+	r.images[key] = img
+}
+
+// Lookup returns the sprite for key, falling back to the entity's plain
+// sprite for an unknown variant and to its any-facing sprite for an
+// unknown facing
+func (r *SpriteRegistry) Lookup(key SpriteKey) *ebiten.Image {
+	if r == nil {
+		return nil
+	}
+	for _, k := range []SpriteKey{
+		key,
+		{Entity: key.Entity, Variant: key.Variant},
+		{Entity: key.Entity, Facing: key.Facing},
+		{Entity: key.Entity},
+	} {
+		if img, ok := r.images[k]; ok {
+			return img
+		}
+	}
+	return nil
+}
+
+// clearVariants drops every variant of entity, leaving its plain sprites
+func (r *SpriteRegistry) clearVariants(entity string) {
+	for k := range r.images {
+		if k.Entity == entity && k.Variant != "" {
+			delete(r.images, k)
+		}
+	}
+}
+
+// sprite returns the registry sprite entity e is drawn with
+func (g *Game) sprite(e sprited) *ebiten.Image {
+	return g.sprites.Lookup(e.spriteKey(g))
+}
+
+// drawSprite draws a sprite from the registry like dst.DrawImage, scaled
+// back up to its nominal size when the registry keeps half resolution
+func (g *Game) drawSprite(dst, img *ebiten.Image, op *ebiten.DrawImageOptions) {
+	if g.sprites.half {
+		scaled := *op
+		scaled.GeoM.Reset()
+		scaled.GeoM.Scale(2, 2)
+		scaled.GeoM.Concat(op.GeoM)
+		op = &scaled
+	}
+	drawImage(dst, img, op)
+}
+
+func (p *Player) spriteKey(g *Game) SpriteKey {
+	return SpriteKey{Entity: EntityPlayer}
+}
+
+func (p *Platform) spriteKey(g *Game) SpriteKey {
+	return SpriteKey{Entity: EntityPlatform, Variant: g.platformSkin(p)}
+}
+
+func (b *Bird) spriteKey(g *Game) SpriteKey {
+	key := SpriteKey{Entity: EntityBird, Facing: facingOf(b.Direction)}
+	if b.Perched > 0 {
+		key.Variant = VariantPerched
+	}
+	return key
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Status icon layout
+const (
+	StatusIconRadius = 5
+	StatusIconGap    = 4   // Space between icons
+	StatusWarnTime   = 2.0 // Icons blink when this many seconds are left
+)
+
+// boostColors are shared by pickups and the status icons
+var boostColors = map[int]color.RGBA{
+	BoostSpeed:      {255, 50, 50, 255},  // Red for speed
+	BoostJump:       {50, 255, 50, 255},  // Green for jump/fly
+	BoostShield:     {50, 50, 255, 255},  // Blue for shield
+	BoostMultiplier: {255, 200, 40, 255}, // Gold for double points
+}
+
+// boostLetters label the boost icons
+var boostLetters = map[int]string{
+	BoostSpeed:      ">",
+	BoostJump:       "J",
+	BoostShield:     "S",
+	BoostMultiplier: "x",
+}
+
+// statusIcon is one active effect shown above the player
+type statusIcon struct {
+	letter string
+	color  color.RGBA
+	timer  *Timer
+}
+
+// statusIcons lists the player's active effects in a stable order
+func (g *Game) statusIcons() []statusIcon {
+	var icons []statusIcon
+	if t := g.timers.Get(TimerBoost); t != nil && g.player.BoostType != BoostNone {
+		icons = append(icons, statusIcon{boostLetters[g.player.BoostType], boostColors[g.player.BoostType], t})
+	}
+	if t := g.timers.Get(TimerFly); t != nil {
+		icons = append(icons, statusIcon{"F", color.RGBA{120, 220, 255, 255}, t})
+	}
+	if t := g.timers.Get(TimerWager); t != nil {
+		icons = append(icons, statusIcon{"$", color.RGBA{255, 200, 40, 255}, t})
+	}
+	return icons
+}
+
+// drawStatusIcons draws a row of effect icons over the player's head, each
+// with its stack count, blinking as it is about to run out
+func (g *Game) drawStatusIcons(screen *ebiten.Image) {
+	icons := g.statusIcons()
+	if len(icons) == 0 {
+		return
+	}
+
+	widths := make([]float64, len(icons))
+	total := 0.0
+	for i, ic := range icons {
+		widths[i] = StatusIconRadius * 2
+		if ic.timer.Stacks > 1 {
+			widths[i] += textWidth(strconv.Itoa(ic.timer.Stacks), TextSmall)
+		}
+		total += widths[i] + StatusIconGap
+	}
+	x := g.player.X - (total-StatusIconGap)/2
+	y := g.screenY(g.player.Y) - PlayerHeight/2 - StatusIconRadius - 4
+
+	for i, ic := range icons {
+		alpha := 1.0
+		if ic.timer.Remaining < StatusWarnTime {
+			if g.save.Settings.ReducedMotion {
+				alpha = 0.5
+			} else if int(ic.timer.Remaining*6)%2 == 0 {
+				alpha = 0.3
+			}
+		}
+		c := ic.color
+		clr := color.RGBA{uint8(float64(c.R) * alpha), uint8(float64(c.G) * alpha), uint8(float64(c.B) * alpha), uint8(float64(c.A) * alpha)}
+		cx := x + StatusIconRadius
+		drawCircle(screen, cx, y, StatusIconRadius, clr)
+		drawTextAlpha(screen, ic.letter, cx-textWidth(ic.letter, TextSmall)/2, y-TextSmall/2, TextSmall, textColor, alpha)
+		if ic.timer.Stacks > 1 {
+			drawTextAlpha(screen, strconv.Itoa(ic.timer.Stacks), cx+StatusIconRadius, y, TextSmall, textColor, alpha)
+		}
+		x += widths[i] + StatusIconGap
+	}
+}
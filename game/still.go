@@ -0,0 +1,73 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// StillScale is the resolution multiplier of stills drawn for store pages
+// and the README
+const StillScale = 4
+
+// LoadRun reads a run state file, such as the run the game suspends when
+// the window closes, for ShowRun
+func LoadRun(path string) (*RunSnapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r RunSnapshot
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("parse run %s: %w", path, err)
+	}
+	if r.Version != RunVersion {
+		return nil, fmt.Errorf("run %s: version %d can't be read by this build", path, r.Version)
+	}
+	return &r, nil
+}
+
+// withRunRules rebuilds the game on the rules r was played by
+func withRunRules(r *RunSnapshot) Option {
+	return func(o *gameOptions) {
+		o.seed, o.seeded = r.Seed, true
+		o.level = r.Level
+		o.hardcore = r.Hardcore
+	}
+}
+
+// ShowRun rebuilds the game at the state of r, frozen in play for Still
+func (g *Game) ShowRun(r *RunSnapshot) {
+	g.restartWith(withRunRules(r))
+	g.restore(r)
+}
+
+// ShowReplay rebuilds the game at tick of r, frozen for Still
+func (g *Game) ShowReplay(r *Replay, tick int) {
+	g.WatchReplay(r)
+	g.seekReplay(tick)
+	g.replayView.paused = true
+}
+
+// Still draws the frame on show, with its HUD or without, at scale times
+// the internal resolution: shapes, text and mountains are drawn at that
+// resolution, sprites scaled up pixel for pixel. Reading pixels needs the
+// game loop: call it from Draw.
+func (g *Game) Still(scale int, hud bool) *image.RGBA {
+	target := ebiten.NewImage(ScreenWidth*scale, ScreenHeight*scale)
+	defer target.Deallocate()
+	drawScaled(float64(scale), func() {
+		if hud {
+			g.drawPlay(target)
+		} else {
+			g.drawWorld(target)
+		}
+	})
+
+	img := image.NewRGBA(target.Bounds())
+	target.ReadPixels(img.Pix)
+	return img
+}
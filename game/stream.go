@@ -0,0 +1,100 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"doodlejump/game/level"
+)
+
+// World streaming parameters
+const (
+	ChunkHeight  = ScreenHeight // World pixels per chunk
+	StreamAhead  = 2            // Chunks kept above the one at the top of the screen
+	StreamBehind = 1            // Chunks kept below the one at the bottom of the screen
+)
+
+// Chunk is a one-screen-tall band of the world. Everything in it is
+// generated from the run seed and the chunk's index alone, so a chunk
+// always holds the same entities however the player got there.
+type Chunk struct {
+	Index     int        // 0 is the first screen, counting up
+	Top       float64    // World Y of the chunk's upper edge
+	Platforms []Platform // PlatformCount platform slots, lowest first
+	Boosts    []Boost    // Boosts resting on the platforms
+	Landmarks []Landmark
+
+	boostOn []int // Boost type resting on each platform, BoostNone for none
+}
+
+// ChunkPopulator adds content to a freshly laid out chunk. It must draw
+// only from rng, which is seeded per chunk.
+type ChunkPopulator func(c *Chunk, rng *rand.Rand)
+
+// chunkPopulators run in order on every chunk after its platforms are laid out
+var chunkPopulators = []ChunkPopulator{populateLandmarks}
+
+// WorldStreamer generates chunks as the camera reaches them and discards
+// those it has left far behind
+type WorldStreamer struct {
+	seed   int64
+	level  *level.Level
+	chunks []*Chunk // Loaded chunks, lowest index first
+}
+
+// newWorldStreamer streams the world of seed laid out with lvl's spawn table
+func newWorldStreamer(seed int64, lvl *level.Level) *WorldStreamer {
+	return &WorldStreamer{seed: seed, level: lvl}
+}
+
+// chunkIndex returns the index of the chunk containing world y
+func chunkIndex(y float64) int {
+	return int(math.Floor(-y/ChunkHeight)) + 1
+}
+
+// chunkSeed mixes the world seed with a chunk index, splitmix64 style, so
+// neighbouring chunks get unrelated streams
+func chunkSeed(seed int64, index int) int64 {
+	z := uint64(seed) + uint64(index)*0x9E3779B97F4A7C15
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	return int64(z ^ z>>31)
+}
+
+// Chunk returns chunk index, generating it when it isn't loaded
+func (s *WorldStreamer) Chunk(index int) *Chunk {
+	for _, c := range s.chunks {
+		if c.Index == index {
+			return c
+		}
+	}
+	return generateChunk(s.seed, index, s.level)
+}
+
+// Update loads the chunks around the screen whose top edge is at world
+// y top, and drops the rest
+func (s *WorldStreamer) Update(top float64) {
+	low := chunkIndex(top+ScreenHeight) - StreamBehind
+	high := chunkIndex(top) + StreamAhead
+
+	kept := s.chunks[:0]
+	for _, c := range s.chunks {
+		if c.Index >= low && c.Index <= high {
+			kept = append(kept, c)
+		}
+	}
+	s.chunks = kept
+
+	next := low
+	if len(s.chunks) > 0 {
+		next = s.chunks[len(s.chunks)-1].Index + 1
+	}
+	for i := next; i <= high; i++ {
+		s.chunks = append(s.chunks, generateChunk(s.seed, i, s.level))
+	}
+}
+
+// Chunks returns the loaded chunks, lowest first
+func (s *WorldStreamer) Chunks() []*Chunk {
+	return s.chunks
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// SummaryTimeout bounds posting the session summary, so a dead webhook
+// can't hold up quitting
+const SummaryTimeout = 5 * time.Second
+
+// SessionSummary says where the summary of a play session goes when the
+// game quits. Empty fields send it nowhere.
+type SessionSummary struct {
+	Path    string // File to write: HTML when it ends in .html or .htm, markdown otherwise
+	Open    bool   // Open the file in the system's viewer once written
+	Webhook string // URL to post the summary to as JSON
+}
+
+// WithSessionSummary writes or sends a summary of the play session when
+// the game quits: runs played, the best score and achievements unlocked
+func WithSessionSummary(s SessionSummary) Option {
+	return func(o *gameOptions) {
+		o.summary = s
+	}
+}
+
+// sessionReport is what a session summary says, as rendered and as posted
+type sessionReport struct {
+	Started      time.Time `json:"started"`
+	Minutes      int       `json:"minutes"` // Play time in runs, rounded down
+	Runs         int       `json:"runs"`
+	Best         int       `json:"best"` // Best score of the session
+	Achievements []string  `json:"achievements"`
+	Text         string    `json:"text"` // The markdown summary, for chat webhooks
+}
+
+// recordRun counts the run g just finished toward the session
+func (s *playSession) recordRun(g *Game) {
+	if !g.countsSession() {
+		return
+	}
+	s.runs++
+	s.best = max(s.best, g.score)
+}
+
+// report sums the session up
+func (s *playSession) report() sessionReport {
+	r := sessionReport{Started: s.started, Minutes: int(s.played / 60), Runs: s.runs, Best: s.best}
+	for _, a := range s.achievements {
+		r.Achievements = append(r.Achievements, a.Name+": "+a.Description)
+	}
+	return r
+}
+
+var summaryMarkdown = template.Must(template.New("summary").Parse(`# GodleJump session, {{.Started.Format "2006-01-02 15:04"}}
+
+- Play time: {{.Minutes}} min
+- Runs: {{.Runs}}
+- Best score: {{.Best}}
+{{if .Achievements}}
+## Achievements unlocked
+{{range .Achievements}}
+- {{.}}{{end}}
+{{end}}`))
+
+var summaryHTML = htmltemplate.Must(htmltemplate.New("summary").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>GodleJump session</title></head>
+<body>
+<h1>GodleJump session, {{.Started.Format "2006-01-02 15:04"}}</h1>
+<ul>
+<li>Play time: {{.Minutes}} min</li>
+<li>Runs: {{.Runs}}</li>
+<li>Best score: {{.Best}}</li>
+</ul>
+{{if .Achievements}}<h2>Achievements unlocked</h2>
+<ul>
+{{range .Achievements}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+// sendSessionSummary writes and posts the session summary wherever the
+// options ask, as the game quits. Failures are logged; quitting goes on.
+func (g *Game) sendSessionSummary() {
+	s := g.opts.summary
+	if s.Path == "" && s.Webhook == "" {
+		return
+	}
+	r := g.session.report()
+	var text bytes.Buffer
+	if err := summaryMarkdown.Execute(&text, r); err != nil {
+		log.Printf("Failed to write the session summary: %v", err)
+		return
+	}
+	r.Text = text.String()
+	if s.Path != "" {
+		if err := writeSummary(s.Path, r); err != nil {
+			log.Printf("Failed to write the session summary: %v", err)
+		} else if s.Open {
+			if err := openFile(s.Path); err != nil {
+				log.Printf("Failed to open the session summary: %v", err)
+			}
+		}
+	}
+	if s.Webhook != "" {
+		if err := postSummary(s.Webhook, r); err != nil {
+			log.Printf("Failed to post the session summary: %v", err)
+		}
+	}
+}
+
+// writeSummary writes r to path, as HTML or markdown by its extension
+func writeSummary(path string, r sessionReport) error {
+	var out bytes.Buffer
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		if err := summaryHTML.Execute(&out, r); err != nil {
+			return err
+		}
+	default:
+		out.WriteString(r.Text)
+	}
+	return os.WriteFile(path, out.Bytes(), 0o644)
+}
+
+// postSummary posts r as JSON to url
+func postSummary(url string, r sessionReport) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: SummaryTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s answered %s", url, resp.Status)
+	}
+	return nil
+}
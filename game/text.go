@@ -0,0 +1,133 @@
+package game
+
+import (
+	"bytes"
+	"embed"
+	"image/color"
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+//go:embed assets/fonts/*.ttf
+var fontAssets embed.FS
+
+// Text sizes. Press Start 2P is drawn on an 8px grid, so stay on multiples of 8.
+const (
+	TextSmall = 8.0
+	TextLarge = 16.0
+
+	textLineHeight = 12 // Vertical spacing for stacked small HUD lines
+)
+
+var (
+	textOutline = color.RGBA{20, 20, 40, 200} // Outline drawn behind every string
+	textColor   = color.RGBA{255, 255, 255, 255}
+)
+
+// hudFont is the embedded pixel font; nil if it failed to load
+var hudFont *text.GoTextFaceSource
+
+// hudFaces caches a face per pixel size
+var hudFaces = map[float64]*text.GoTextFace{}
+
+func init() {
+	data, err := fontAssets.ReadFile("assets/fonts/pressstart2p.ttf")
+	if err == nil {
+		hudFont, err = text.NewGoTextFaceSource(bytes.NewReader(data))
+	}
+	if err != nil {
+		// Same policy as sprites: fall back to the debug font instead of crashing
+		log.Printf("Using debug font: %v", err)
+		hudFont = nil
+	}
+}
+
+// fontFace returns the HUD font face at size
+func fontFace(size float64) *text.GoTextFace {
+	if f, ok := hudFaces[size]; ok {
+		return f
+	}
+	f := &text.GoTextFace{Source: hudFont, Size: size}
+	hudFaces[size] = f
+	return f
+}
+
+// textWidth measures s in pixels at size
+func textWidth(s string, size float64) float64 {
+	if hudFont == nil {
+		return float64(len(s) * 6)
+	}
+	w, _ := text.Measure(s, fontFace(size), 0)
+	return w
+}
+
+// drawText draws s with its top-left corner at x, y, outlined so it stays
+// readable over bright skies
+func drawText(screen *ebiten.Image, s string, x, y, size float64, clr color.Color) {
+	drawTextAlpha(screen, s, x, y, size, clr, 1)
+}
+
+// drawTextAlpha draws outlined text faded by alpha (0.0 - 1.0)
+func drawTextAlpha(screen *ebiten.Image, s string, x, y, size float64, clr color.Color, alpha float64) {
+	if alpha <= 0 {
+		return
+	}
+	scale := drawScale
+	x, y = x*scale, y*scale
+	if hudFont == nil {
+		ebitenutil.DebugPrintAt(screen, s, int(x), int(y))
+		return
+	}
+
+	// The face is picked at the drawn size so scaled frames get sharp glyphs
+	face := fontFace(size * scale)
+
+	// Outline: stamp the string in a dark color around the 8 neighbours
+	outline := scale
+	if size >= TextLarge {
+		outline = 2 * scale
+	}
+	for _, d := range [][2]float64{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}} {
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(x+d[0]*outline, y+d[1]*outline)
+		op.ColorScale.ScaleWithColor(textOutline)
+		op.ColorScale.ScaleAlpha(float32(alpha))
+		text.Draw(screen, s, face, op)
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(clr)
+	op.ColorScale.ScaleAlpha(float32(alpha))
+	text.Draw(screen, s, face, op)
+}
+
+// wrapText breaks s between words into lines no wider than width at size
+func wrapText(s string, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = word
+		case textWidth(line+" "+word, size) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// drawTextCentered draws outlined text horizontally centered on the screen
+func drawTextCentered(screen *ebiten.Image, s string, y, size float64, clr color.Color) {
+	drawText(screen, s, (ScreenWidth-textWidth(s, size))/2, y, size, clr)
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"math"
+
+	"doodlejump/game/config"
+)
+
+// FrameRate is the rate the game's per-tick speeds, accelerations and
+// chances are tuned at. At any other tick rate they are scaled by the
+// game's step, so a run plays the same at 30, 60 or 120 ticks a second.
+const FrameRate = config.DefaultTickRate
+
+// setTickRate runs the simulation at tps ticks per second. Every timer
+// counts down dt a tick, and everything that moves covers step frames'
+// worth of its speed.
+func (g *Game) setTickRate(tps int) {
+	g.tps = tps
+	g.dt = 1 / float64(tps)
+	g.step = FrameRate * g.dt
+}
+
+// ticks is how many ticks frames of FrameRate last at the game's rate,
+// at least one
+func (g *Game) ticks(frames int) int {
+	return max(1, int(math.Round(float64(frames)/g.step)))
+}
+
+// chance turns a chance per frame into the chance per tick
+func (g *Game) chance(perFrame float64) float64 {
+	return perFrame * g.step
+}
+
+// damp turns a factor kept per frame, such as friction, into the factor
+// kept per tick
+func (g *Game) damp(perFrame float64) float64 {
+	if g.step == 1 {
+		return perFrame // Exactly as tuned, with no rounding from Pow
+	}
+	return math.Pow(perFrame, g.step)
+}
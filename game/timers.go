@@ -0,0 +1,180 @@
+package game
+
+import "math"
+
+// TimerKey names a timed effect. Effects owned by the player or the game
+// use the constants below; per-object effects key on the object, such as
+// platformBreak{p}.
+type TimerKey any
+
+// Timed effects of the player and the game
+const (
+	TimerFly        timerName = "fly"        // Flight time left
+	TimerShoot      timerName = "shoot"      // Shooting cooldown
+	TimerBoost      timerName = "boost"      // Remaining life of the active boost
+	TimerInvincible timerName = "invincible" // I-frames
+	TimerWeather    timerName = "weather"    // Time until the weather changes
+	TimerStuck      timerName = "stuck"      // Counts up while stuck to a sticky platform
+	TimerLimit      timerName = "limit"      // Time left of a timed run: kiosk runs and time attack
+)
+
+type timerName string
+
+// platformBreak keys the crumble timer of a disappearing platform
+type platformBreak struct{ p *Platform }
+
+// Timer is one running effect
+type Timer struct {
+	Duration  float64 // Seconds it was started with; +Inf never expires
+	Remaining float64
+	Elapsed   float64
+	Stacks    int // How many times it was started without expiring in between
+
+	// onExpire gets the game rather than capturing it, because NewGame
+	// builds a temporary that restart copies into the live game
+	onExpire func(g *Game)
+}
+
+// Progress is how far through its duration t is, from 0 to 1
+func (t *Timer) Progress() float64 {
+	if t.Duration <= 0 || math.IsInf(t.Duration, 1) {
+		return 0
+	}
+	return t.Elapsed / t.Duration
+}
+
+// Timers runs every timed effect of a game. Entries keep their start order
+// so expiry callbacks, which may roll the game's rng, fire deterministically.
+type Timers struct {
+	keys   []TimerKey
+	timers []*Timer
+}
+
+// index returns the slot of key, or -1
+func (ts *Timers) index(key TimerKey) int {
+	for i, k := range ts.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add starts key for d seconds, calling onExpire (which may be nil) when it
+// runs out. Starting a running timer restarts it and adds a stack.
+func (ts *Timers) Add(key TimerKey, d float64, onExpire func(g *Game)) *Timer {
+	if i := ts.index(key); i >= 0 {
+		t := ts.timers[i]
+		t.Duration, t.Remaining, t.Elapsed = d, d, 0
+		t.Stacks++
+		t.onExpire = onExpire
+		return t
+	}
+	t := &Timer{Duration: d, Remaining: d, Stacks: 1, onExpire: onExpire}
+	ts.keys = append(ts.keys, key)
+	ts.timers = append(ts.timers, t)
+	return t
+}
+
+// Get returns the running timer of key, or nil
+func (ts *Timers) Get(key TimerKey) *Timer {
+	if i := ts.index(key); i >= 0 {
+		return ts.timers[i]
+	}
+	return nil
+}
+
+// Active reports whether key is running
+func (ts *Timers) Active(key TimerKey) bool {
+	return ts.index(key) >= 0
+}
+
+// Remaining returns the seconds left on key, 0 if it isn't running
+func (ts *Timers) Remaining(key TimerKey) float64 {
+	if t := ts.Get(key); t != nil {
+		return t.Remaining
+	}
+	return 0
+}
+
+// Elapsed returns the seconds key has been running, 0 if it isn't
+func (ts *Timers) Elapsed(key TimerKey) float64 {
+	if t := ts.Get(key); t != nil {
+		return t.Elapsed
+	}
+	return 0
+}
+
+// Cancel stops key without calling its expiry callback
+func (ts *Timers) Cancel(key TimerKey) {
+	if i := ts.index(key); i >= 0 {
+		ts.remove(i)
+	}
+}
+
+func (ts *Timers) remove(i int) {
+	ts.keys = append(ts.keys[:i], ts.keys[i+1:]...)
+	ts.timers = append(ts.timers[:i], ts.timers[i+1:]...)
+}
+
+// Tick advances every timer by dt seconds, then fires the callbacks of
+// those that ran out. Callbacks may start new timers, including their own.
+func (ts *Timers) Tick(g *Game, dt float64) {
+	var expired []*Timer
+	for i := 0; i < len(ts.timers); i++ {
+		t := ts.timers[i]
+		t.Remaining -= dt
+		t.Elapsed += dt
+		if t.Remaining <= 0 {
+			expired = append(expired, t)
+			ts.remove(i)
+			i--
+		}
+	}
+	for _, t := range expired {
+		if t.onExpire != nil {
+			t.onExpire(g)
+		}
+	}
+}
+
+// canFly reports whether the player has flight time left
+func (g *Game) canFly() bool {
+	return g.timers.Active(TimerFly)
+}
+
+// startBoost gives the player a boost of type t. The same boost again
+// stacks its time; the jump boost also grants a turn at flying.
+func (g *Game) startBoost(t int) {
+	if g.player.BoostType != t {
+		g.timers.Cancel(TimerBoost) // A different boost starts its own stack
+	}
+	g.player.BoostType = t
+	g.timers.Add(TimerBoost, g.tuning.BoostDuration, expireBoost)
+	if t == BoostJump {
+		g.timers.Add(TimerFly, g.tuning.FlyDuration, nil)
+	}
+}
+
+// expireBoost ends the active boost. A shield running out grants a moment of
+// grace so the bird it was holding off can't kill instantly.
+func expireBoost(g *Game) {
+	if g.player.BoostType == BoostShield {
+		g.announce(stingerWarning, "announce.shield_down")
+		g.events.Publish(Event{Kind: EventShieldExpired})
+		g.timers.Add(TimerInvincible, ShieldGrace, nil)
+	}
+	g.player.BoostType = BoostNone
+}
+
+// changeWeather rolls new weather and schedules the next change. With
+// weather turned off in the settings the roll still happens, so a seed
+// plays the same either way, but the sky stays clear.
+func changeWeather(g *Game) {
+	w := g.rng.Intn(weatherCount)
+	if !g.config.Weather {
+		w = WeatherClear
+	}
+	g.setWeather(w)
+	g.timers.Add(TimerWeather, 15+g.rng.Float64()*20, changeWeather) // 15-35 seconds until next change
+}
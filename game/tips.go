@@ -0,0 +1,124 @@
+package game
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"doodlejump/game/i18n"
+)
+
+// TipSeconds is how long a tip shows before the next one
+const TipSeconds = 6.0
+
+//go:embed assets/tips.json
+var tipsFile []byte
+
+// tip is one entry of the tips file: the i18n key of its text and when it
+// applies. Unset conditions always hold.
+type tip struct {
+	Key      string `json:"key"`
+	Cause    string `json:"cause,omitempty"`     // Only after a run ended by this cause, by name
+	Stuck    bool   `json:"stuck,omitempty"`     // Only after dying stuck to a sticky platform
+	MinGames int    `json:"min_games,omitempty"` // Only once the profile has played this many runs
+	MaxGames int    `json:"max_games,omitempty"` // Only until it has played more than this many
+}
+
+// tips is the embedded tips file; a broken one just shows no tips
+var tips = loadTips()
+
+func loadTips() []tip {
+	var list []tip
+	if err := json.Unmarshal(tipsFile, &list); err != nil {
+		log.Printf("Failed to parse tips: %v", err)
+	}
+	return list
+}
+
+// tipContext is what tips are picked for: the profile's progress and, on
+// the game-over screen, how the run ended
+type tipContext struct {
+	games int
+	dead  bool
+	cause DeathCause
+	stuck bool
+}
+
+// fit is how many of t's conditions c meets, or -1 when one fails
+func (t tip) fit(c tipContext) int {
+	if t.MinGames > 0 && c.games < t.MinGames || t.MaxGames > 0 && c.games > t.MaxGames {
+		return -1
+	}
+	n := 0
+	if t.Cause != "" {
+		if !c.dead || !strings.EqualFold(t.Cause, c.cause.String()) {
+			return -1
+		}
+		n++
+	}
+	if t.Stuck {
+		if !c.stuck {
+			return -1
+		}
+		n++
+	}
+	return n
+}
+
+// pickTips lists, shuffled, the keys of the tips that fit c the closest:
+// a tip about how the run ended beats a general one
+func pickTips(c tipContext) []string {
+	var keys []string
+	best := 0
+	for _, t := range tips {
+		switch n := t.fit(c); {
+		case n > best:
+			keys, best = []string{t.Key}, n
+		case n == best:
+			keys = append(keys, t.Key)
+		}
+	}
+	fxShuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	return keys
+}
+
+// tipRotation cycles through the tips picked for a context
+type tipRotation struct {
+	context tipContext
+	keys    []string
+	at      int     // Index in keys of the tip showing
+	left    float64 // Seconds until the next tip
+	picked  bool
+}
+
+// update shows tips for c, picking them afresh when c changed, and moves
+// on to the next tip every TipSeconds
+func (r *tipRotation) update(c tipContext, dt float64) {
+	if !r.picked || c != r.context {
+		*r = tipRotation{context: c, keys: pickTips(c), left: TipSeconds, picked: true}
+		return
+	}
+	if r.left -= dt; r.left <= 0 && len(r.keys) > 0 {
+		r.at = (r.at + 1) % len(r.keys)
+		r.left = TipSeconds
+	}
+}
+
+// lines is the tip showing, in the active language, one entry per line
+func (r *tipRotation) lines() []string {
+	if len(r.keys) == 0 {
+		return nil
+	}
+	return strings.Split(i18n.T(r.keys[r.at]), "\n")
+}
+
+// titleTips is the context of tips on the title screen
+func (g *Game) titleTips() tipContext {
+	return tipContext{games: g.save.GamesPlayed}
+}
+
+// deathTips is the context of tips on the game-over screen
+func (g *Game) deathTips() tipContext {
+	return tipContext{games: g.save.GamesPlayed, dead: true, cause: g.deathCause, stuck: g.diedStuck}
+}
@@ -0,0 +1,251 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"doodlejump/game/input"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tournament size limits
+const (
+	MinTournamentPlayers = 2
+	MaxTournamentPlayers = 8
+)
+
+// byePlayer fills the empty side of a match in a bracket with an odd number of players
+const byePlayer = -1
+
+// Match is one pairing of a bracket round; the higher score goes through
+// and a tie goes to A, the better-placed player
+type Match struct {
+	A, B           int // Player indices; B is byePlayer for a bye
+	ScoreA, ScoreB int
+	Played         int // Runs played so far: 0, 1 or 2
+}
+
+// done reports whether the match has a winner
+func (m *Match) done() bool {
+	return m.B == byePlayer || m.Played == 2
+}
+
+// winner is the player who goes through, once the match is done
+func (m *Match) winner() int {
+	if m.B == byePlayer || m.ScoreA >= m.ScoreB {
+		return m.A
+	}
+	return m.B
+}
+
+// Tournament is a local single-elimination bracket: every player takes a
+// turn on the same seed and the higher score of each pairing goes through
+type Tournament struct {
+	Seed    int64
+	Players []string
+	Rounds  [][]Match // Rounds[0] is the first round; the last is being played
+}
+
+// WeeklySeed is the seed every tournament of t's ISO week shares, so
+// friends meeting on different days of the week play the same world
+func WeeklySeed(t time.Time) int64 {
+	year, week := t.ISOWeek()
+	return int64(year*100 + week)
+}
+
+// NewTournament seeds a bracket of players in the order given
+func NewTournament(players []string, seed int64) (*Tournament, error) {
+	if len(players) < MinTournamentPlayers || len(players) > MaxTournamentPlayers {
+		return nil, fmt.Errorf("a tournament needs %d to %d players, got %d", MinTournamentPlayers, MaxTournamentPlayers, len(players))
+	}
+	for _, p := range players {
+		if strings.TrimSpace(p) == "" {
+			return nil, errors.New("tournament player names must not be empty")
+		}
+	}
+	t := &Tournament{Seed: seed, Players: players}
+	first := make([]int, len(players))
+	for i := range first {
+		first[i] = i
+	}
+	t.Rounds = [][]Match{pairUp(first)}
+	return t, nil
+}
+
+// ParseTournament builds a tournament from a comma-separated list of names
+func ParseTournament(names string, seed int64) (*Tournament, error) {
+	var players []string
+	for _, n := range strings.Split(names, ",") {
+		players = append(players, strings.TrimSpace(n))
+	}
+	return NewTournament(players, seed)
+}
+
+// pairUp pairs neighbours of a round's entrants; the odd one out gets a bye
+func pairUp(entrants []int) []Match {
+	var round []Match
+	for i := 0; i < len(entrants); i += 2 {
+		m := Match{A: entrants[i], B: byePlayer}
+		if i+1 < len(entrants) {
+			m.B = entrants[i+1]
+		}
+		round = append(round, m)
+	}
+	return round
+}
+
+// round is the round being played
+func (t *Tournament) round() []Match {
+	return t.Rounds[len(t.Rounds)-1]
+}
+
+// Champion returns the winner once the final has been played
+func (t *Tournament) Champion() (int, bool) {
+	r := t.round()
+	if len(r) == 1 && r[0].done() {
+		return r[0].winner(), true
+	}
+	return 0, false
+}
+
+// Next returns the match being played and the player whose turn it is
+func (t *Tournament) Next() (*Match, int, bool) {
+	if _, over := t.Champion(); over {
+		return nil, 0, false
+	}
+	r := t.round()
+	for i := range r {
+		m := &r[i]
+		switch {
+		case m.done():
+		case m.Played == 0:
+			return m, m.A, true
+		default:
+			return m, m.B, true
+		}
+	}
+	return nil, 0, false
+}
+
+// Record books score for the player whose turn it was, and starts the
+// next round once every match of this one is done
+func (t *Tournament) Record(score int) {
+	m, _, ok := t.Next()
+	if !ok {
+		return
+	}
+	if m.Played == 0 {
+		m.ScoreA = score
+	} else {
+		m.ScoreB = score
+	}
+	m.Played++
+
+	var winners []int
+	for _, m := range t.round() {
+		if !m.done() {
+			return
+		}
+		winners = append(winners, m.winner())
+	}
+	if len(winners) > 1 {
+		t.Rounds = append(t.Rounds, pairUp(winners))
+	}
+}
+
+// Reset starts the bracket over with the same players and seed
+func (t *Tournament) Reset() {
+	fresh, _ := NewTournament(t.Players, t.Seed)
+	*t = *fresh
+}
+
+// player names player i, or the bye
+func (t *Tournament) player(i int) string {
+	if i == byePlayer {
+		return "(bye)"
+	}
+	return t.Players[i]
+}
+
+// tournamentLines names the player whose turn it is during a run
+func tournamentLines(g *Game) []hudLine {
+	t := g.opts.tournament
+	if t == nil || g.gameOver {
+		return nil
+	}
+	if _, p, ok := t.Next(); ok {
+		return []hudLine{{"Turn: " + t.Players[p], TextSmall}}
+	}
+	return nil
+}
+
+// sceneStandings shows the bracket between tournament turns
+type sceneStandings struct{}
+
+func (sceneStandings) Update(g *Game) error {
+	if g.audio != nil {
+		g.audio.Update(false)
+	}
+	if !g.controller.JustPressed(input.ActionRestart) || !g.canStartRun() {
+		return nil
+	}
+	if _, over := g.opts.tournament.Champion(); over {
+		g.opts.tournament.Reset()
+		g.feedback(FeedbackUIClick)
+		return nil
+	}
+	g.restart()
+	return nil
+}
+
+func (sceneStandings) Draw(g *Game, screen *ebiten.Image) {
+	g.drawWorld(screen)
+	t := g.opts.tournament
+	drawTextCentered(screen, "Tournament", 40, TextLarge, textColor)
+
+	highlight := color.RGBA{255, 220, 100, 255}
+	current, _, _ := t.Next()
+	y := 80.0
+	for r, round := range t.Rounds {
+		drawText(screen, fmt.Sprintf("Round %d", r+1), 20, y, TextSmall, textColor)
+		y += textLineHeight
+		for i := range round {
+			m := &round[i]
+			clr := textColor
+			if m == current {
+				clr = highlight
+			}
+			drawText(screen, "  "+matchText(t, m), 20, y, TextSmall, clr)
+			y += textLineHeight
+		}
+		y += textLineHeight / 2
+	}
+
+	if champ, over := t.Champion(); over {
+		drawTextCentered(screen, t.Players[champ]+" wins!", ScreenHeight-70, TextLarge, highlight)
+		drawTextCentered(screen, "Press "+g.actionKey(input.ActionRestart)+" for a rematch", ScreenHeight-40, TextSmall, textColor)
+		return
+	}
+	_, p, _ := t.Next()
+	drawTextCentered(screen, "Next up: "+t.Players[p], ScreenHeight-60, TextSmall, highlight)
+	drawTextCentered(screen, "Pass the controls and press SPACE", ScreenHeight-40, TextSmall, textColor)
+}
+
+// matchText is a match's line of the standings, scores filled in as they are played
+func matchText(t *Tournament, m *Match) string {
+	if m.B == byePlayer {
+		return t.player(m.A) + " (bye)"
+	}
+	a, b := "-", "-"
+	if m.Played > 0 {
+		a = fmt.Sprint(m.ScoreA)
+	}
+	if m.Played > 1 {
+		b = fmt.Sprint(m.ScoreB)
+	}
+	return fmt.Sprintf("%s %s : %s %s", t.player(m.A), a, b, t.player(m.B))
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"doodlejump/game/level"
+)
+
+// GameConfig holds the gameplay tuning values, so designers can rebalance
+// the game from a JSON file without recompiling. Its defaults are the
+// built-in constants; custom levels still override physics and spawns.
+type GameConfig struct {
+	Gravity            float64    `json:"gravity"`       // Added to vertical velocity every tick
+	JumpVelocity       float64    `json:"jump_velocity"` // Vertical velocity after a bounce, negative is up
+	BoostSpawnChance   float64    `json:"boost_spawn_chance"`
+	InitialBirds       int        `json:"initial_birds"`
+	MaxBirds           int        `json:"max_birds"`
+	InitialBirdSpeed   [2]float64 `json:"initial_bird_speed"` // Min and max speed at difficulty 0
+	MaxBirdSpeed       [2]float64 `json:"max_bird_speed"`     // Min and max speed once fully ramped
+	ScorePerDifficulty int        `json:"score_per_difficulty"`
+	BulletSpeed        float64    `json:"bullet_speed"`
+	ShootCooldown      float64    `json:"shoot_cooldown"`      // Seconds
+	FlyDuration        float64    `json:"fly_duration"`        // Seconds
+	BoostDuration      float64    `json:"boost_duration"`      // Seconds
+	SpawnInvincibility float64    `json:"spawn_invincibility"` // Seconds
+
+	// Friendly fire rules, both off by default
+	BulletsDislodgeBoosts bool `json:"bullets_dislodge_boosts"` // Shots knock resting boosts off into a fall
+	BulletsHitPlatforms   bool `json:"bullets_hit_platforms"`   // Platforms stop shots
+}
+
+// DefaultGameConfig returns the tuning the game ships with
+func DefaultGameConfig() GameConfig {
+	return GameConfig{
+		Gravity:            Gravity,
+		JumpVelocity:       JumpVelocity,
+		BoostSpawnChance:   BoostSpawnChance,
+		InitialBirds:       InitialBirdCount,
+		MaxBirds:           MaxBirdCount,
+		InitialBirdSpeed:   [2]float64{InitialBirdSpeedMin, InitialBirdSpeedMax},
+		MaxBirdSpeed:       [2]float64{MaxBirdSpeedMin, MaxBirdSpeedMax},
+		ScorePerDifficulty: ScorePerDifficulty,
+		BulletSpeed:        BulletSpeed,
+		ShootCooldown:      ShootCooldown,
+		FlyDuration:        FlyDuration,
+		BoostDuration:      BoostDuration,
+		SpawnInvincibility: SpawnInvincibility,
+	}
+}
+
+// LoadGameConfig reads a tuning file over the defaults; values it leaves
+// out keep their default
+func LoadGameConfig(path string) (GameConfig, error) {
+	c := DefaultGameConfig()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return DefaultGameConfig(), fmt.Errorf("parse tuning %s: %w", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return DefaultGameConfig(), fmt.Errorf("tuning %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// validate rejects values the game can't run with, listing every one
+func (c GameConfig) validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	check(c.Gravity > 0, "gravity must be positive, got %g", c.Gravity)
+	check(c.JumpVelocity < 0, "jump_velocity must be negative (up), got %g", c.JumpVelocity)
+	check(c.BoostSpawnChance >= 0 && c.BoostSpawnChance <= 1, "boost_spawn_chance must be between 0 and 1, got %g", c.BoostSpawnChance)
+	check(c.InitialBirds >= 0 && c.InitialBirds <= c.MaxBirds, "initial_birds must be between 0 and max_birds, got %d", c.InitialBirds)
+	check(c.InitialBirdSpeed[0] > 0 && c.InitialBirdSpeed[0] <= c.InitialBirdSpeed[1], "initial_bird_speed must be [min, max] with 0 < min <= max, got %v", c.InitialBirdSpeed)
+	check(c.MaxBirdSpeed[0] > 0 && c.MaxBirdSpeed[0] <= c.MaxBirdSpeed[1], "max_bird_speed must be [min, max] with 0 < min <= max, got %v", c.MaxBirdSpeed)
+	check(c.ScorePerDifficulty > 0, "score_per_difficulty must be positive, got %d", c.ScorePerDifficulty)
+	check(c.BulletSpeed > 0, "bullet_speed must be positive, got %g", c.BulletSpeed)
+	check(c.ShootCooldown >= 0, "shoot_cooldown must not be negative, got %g", c.ShootCooldown)
+	check(c.FlyDuration > 0, "fly_duration must be positive, got %g", c.FlyDuration)
+	check(c.BoostDuration > 0, "boost_duration must be positive, got %g", c.BoostDuration)
+	check(c.SpawnInvincibility >= 0, "spawn_invincibility must not be negative, got %g", c.SpawnInvincibility)
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// levelRules are the engine limits and defaults levels are checked against
+func (c GameConfig) levelRules() level.Rules {
+	return level.Rules{
+		PlatformSpacing:     PlatformSpacing,
+		MaxBirds:            c.MaxBirds,
+		DefaultGravity:      c.Gravity,
+		DefaultJumpVelocity: c.JumpVelocity,
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Updraft parameters
+const (
+	UpdraftSpawnChance = 0.04 // Chance a recycled platform gets a vent beside it
+	UpdraftWidth       = 36
+	UpdraftHeight      = 180  // Column height above the vent
+	UpdraftLift        = 0.35 // Upward acceleration inside a column, per frame
+	UpdraftDamping     = 0.9  // Fall speed kept per frame inside a column
+	UpdraftRiseSpeed   = 2.0  // Fastest an updraft lifts the player
+	UpdraftParticles   = 14   // Rising particles drawn per column
+)
+
+// Updraft is a column of rising air above a vent that slows falls and
+// gently lifts the player, a point of relief during hard sections
+type Updraft struct {
+	X      float64 // Left edge, world coordinates
+	Bottom float64 // World Y of the vent
+}
+
+// contains reports whether a point is inside the column
+func (u *Updraft) contains(x, y float64) bool {
+	return x >= u.X && x <= u.X+UpdraftWidth && y <= u.Bottom && y >= u.Bottom-UpdraftHeight
+}
+
+// spawnUpdraft maybe places a vent in the gap beside a recycled platform
+func (g *Game) spawnUpdraft(p *Platform) {
+	if g.rng.Float64() >= UpdraftSpawnChance {
+		return
+	}
+	// Put the column on whichever side of the platform has more room
+	x := p.X + PlatformWidth + 10
+	if p.X > ScreenWidth-p.X-PlatformWidth {
+		x = p.X - UpdraftWidth - 10
+	}
+	x = math.Max(0, math.Min(ScreenWidth-UpdraftWidth, x))
+	g.updrafts = append(g.updrafts, Updraft{X: x, Bottom: p.Y + PlatformHeight})
+}
+
+// applyUpdrafts lifts a player inside a column and drops columns that
+// scrolled away; it runs before gravity
+func (g *Game) applyUpdrafts() {
+	for i := 0; i < len(g.updrafts); i++ {
+		u := &g.updrafts[i]
+		if g.screenY(u.Bottom-UpdraftHeight) > ScreenHeight {
+			g.updrafts[i] = g.updrafts[len(g.updrafts)-1]
+			g.updrafts = g.updrafts[:len(g.updrafts)-1]
+			i--
+			continue
+		}
+		if g.stuckToPlatform == nil && g.loadedCannon == nil && u.contains(g.player.X, g.player.Y) {
+			vy := g.player.VelocityY
+			if vy > 0 {
+				vy *= g.damp(UpdraftDamping)
+			}
+			g.player.VelocityY = math.Max(vy-UpdraftLift*g.step, -UpdraftRiseSpeed)
+		}
+	}
+}
+
+// drawUpdrafts draws each column as a faint band with rising particles.
+// Particles are placed from gameTime alone, so drawing needs no state.
+func (g *Game) drawUpdrafts(screen *ebiten.Image) {
+	for _, u := range g.updrafts {
+		bottom := g.screenY(u.Bottom)
+		top := bottom - UpdraftHeight
+		if bottom < 0 || top > ScreenHeight {
+			continue
+		}
+		drawRect(screen, u.X, top, UpdraftWidth, UpdraftHeight, color.RGBA{255, 255, 255, 18})
+
+		// Vent
+		drawRect(screen, u.X+4, bottom-4, UpdraftWidth-8, 4, color.RGBA{90, 80, 70, 255})
+
+		for i := 0; i < UpdraftParticles; i++ {
+			// Each particle rises at its own speed and sways sideways
+			seed := float64(i) * 12.9898
+			speed := 0.25 + math.Mod(seed*0.37, 0.2)
+			t := math.Mod(g.gameTime*speed+float64(i)/UpdraftParticles, 1)
+			x := u.X + UpdraftWidth*(0.2+0.6*math.Mod(seed*0.618, 1)) + 3*math.Sin(g.gameTime*2+seed)
+			y := bottom - t*UpdraftHeight
+			a := uint8(160 * math.Sin(t*math.Pi)) // Fade in at the vent, out at the top
+			drawCircle(screen, x, y, 1.2, color.RGBA{230, 240, 255, a})
+		}
+	}
+}
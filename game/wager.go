@@ -0,0 +1,103 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Gamble platform parameters
+const (
+	WagerDuration  = 10.0 // Seconds a won wager doubles the score
+	WagerWinChance = 0.5  // Chance a gamble pays out rather than calling a wave
+	WagerWaveBirds = 3    // Birds a lost wager sends
+	wagerFlicker   = 5.0  // Gold/black flickers per second
+)
+
+// TimerWager runs while a won wager doubles every point scored
+const TimerWager timerName = "wager"
+
+// Wager outcomes, the Value of an EventWager
+const (
+	WagerWon  = iota // Score doubled for WagerDuration
+	WagerLost        // A wave of birds is on its way
+)
+
+// resolveWager settles the gamble of platform p the first time it is
+// bounced on; a spent gamble platform is an ordinary one
+func (g *Game) resolveWager(p *Platform) {
+	if p.State != PlatformIntact {
+		return
+	}
+	p.State = PlatformSpent
+	if g.rng.Float64() < WagerWinChance {
+		g.timers.Add(TimerWager, WagerDuration, nil)
+		g.feedbackAt(FeedbackBoostPickup, p.X+PlatformWidth/2, p.Y)
+		g.events.Publish(Event{Kind: EventWager, Value: WagerWon, X: p.X, Y: p.Y})
+		return
+	}
+	g.spawnBirdWave()
+	g.feedbackAt(FeedbackShieldHit, p.X+PlatformWidth/2, p.Y)
+	g.events.Publish(Event{Kind: EventWager, Value: WagerLost, X: p.X, Y: p.Y})
+}
+
+// wagerBonus is the extra a won wager adds to points scored in cat
+func (g *Game) wagerBonus(cat ScoreCategory, points int) int {
+	if cat.bonus() || !g.timers.Active(TimerWager) {
+		return 0
+	}
+	return points
+}
+
+// spawnBirdWave sends WagerWaveBirds extra birds in from above. Wave birds
+// leave for good once shot or passed instead of being recycled. A lost
+// wager in zen costs nothing.
+func (g *Game) spawnBirdWave() {
+	if g.mode == ModeZen {
+		return
+	}
+	for j := range WagerWaveBirds {
+		direction := 1
+		if g.rng.Float64() < 0.5 {
+			direction = -1
+		}
+		g.birds = append(g.birds, Bird{
+			X:         g.rng.Float64() * ScreenWidth,
+			Y:         g.worldTop() - BirdHeight*float64(1+j),
+			SpeedX:    g.birdSpeedMax,
+			Direction: direction,
+			Wave:      true,
+		})
+	}
+}
+
+// dropPassedWave removes the wave birds that scrolled off the bottom
+func (g *Game) dropPassedWave() {
+	g.birds = slices.DeleteFunc(g.birds, func(b Bird) bool {
+		return b.Wave && g.screenY(b.Y) > ScreenHeight
+	})
+}
+
+// drawGamblePlatform draws a gamble platform flickering gold and black
+// until it is bounced on, and dull once spent
+func (g *Game) drawGamblePlatform(screen *ebiten.Image, img *ebiten.Image, p *Platform, py float64) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(p.X, py)
+	if g.nightMode {
+		op.ColorM.Scale(0.7, 0.7, 0.9, 1)
+	}
+	switch {
+	case p.State == PlatformSpent:
+		op.ColorM.Scale(0.6, 0.6, 0.6, 1)
+	case g.save.Settings.ReducedMotion || math.Sin(g.gameTime*wagerFlicker*2*math.Pi) > 0:
+		op.ColorM.Scale(1.3, 1.1, 0.2, 1) // Gold
+	default:
+		op.ColorM.Scale(0.25, 0.25, 0.25, 1) // Black
+	}
+	g.drawSprite(screen, img, op)
+	if p.State == PlatformIntact {
+		drawText(screen, "?", p.X+PlatformWidth/2-3, py-12, TextSmall, color.RGBA{255, 220, 100, 255})
+	}
+}
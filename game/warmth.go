@@ -0,0 +1,129 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Hardcore warmth parameters. Rates are fractions of a full meter per second.
+const (
+	WarmthAltitude      = 200.0    // Meters above which the cold bites
+	WarmthDrain         = 1.0 / 40 // Per source of cold: snow, storm, night
+	WarmthSun           = 1.0 / 30 // Clear daytime sky
+	WarmthCampfire      = 1.0 / 4
+	CampfireSpawnChance = 0.06 // Chance a recycled platform above WarmthAltitude has a fire
+	CampfireRadius      = 36   // Distance at which a fire warms the player
+)
+
+// Campfire burns on a platform and warms a player close by
+type Campfire struct {
+	X, Y  float64 // World coordinates of the base of the flames
+	Mount Mount   // Slot it burns in on its platform
+}
+
+// coldSources counts what is chilling the player right now
+func (g *Game) coldSources() float64 {
+	cold := 0.0
+	switch g.weather {
+	case WeatherSnow, WeatherStorm:
+		cold++
+	case WeatherRain:
+		cold += 0.5
+	}
+	if g.timeOfDay() >= NightStart {
+		cold++
+	}
+	return cold
+}
+
+// nearCampfire reports whether the player is warming at a fire
+func (g *Game) nearCampfire() bool {
+	for _, c := range g.campfires {
+		if math.Hypot(g.player.X-c.X, g.player.Y+PlayerHeight/2-c.Y) < CampfireRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// updateWarmth drains and refills the warmth meter in Hardcore; an empty
+// meter ends the run
+func (g *Game) updateWarmth() {
+	if !g.opts.hardcore {
+		return
+	}
+
+	// Fires that scrolled off the bottom are gone
+	for i := 0; i < len(g.campfires); i++ {
+		if g.screenY(g.campfires[i].Y) > ScreenHeight {
+			g.campfires[i] = g.campfires[len(g.campfires)-1]
+			g.campfires = g.campfires[:len(g.campfires)-1]
+			i--
+		}
+	}
+
+	rate := 0.0
+	if alt := g.playerAltitude(); alt > WarmthAltitude {
+		// The cold gets up to twice as harsh a further WarmthAltitude up
+		harsh := 1 + math.Min((alt-WarmthAltitude)/WarmthAltitude, 1)
+		rate -= WarmthDrain * g.coldSources() * harsh
+	}
+	if g.weather == WeatherClear && g.timeOfDay() < NightStart {
+		rate += WarmthSun
+	}
+	if g.nearCampfire() {
+		rate += WarmthCampfire
+	}
+	g.warmth = math.Min(1, g.warmth+rate*g.dt)
+	if g.warmth <= 0 {
+		g.warmth = 0
+		g.endRun(DeathCold)
+	}
+}
+
+// spawnCampfire maybe lights a fire on freshly recycled platform i, in
+// its middle slot unless that is taken
+func (g *Game) spawnCampfire(i int) {
+	p := &g.platforms[i]
+	if !g.opts.hardcore || p.Type != PlatformNormal || g.playerAltitude() < WarmthAltitude ||
+		g.rng.Float64() >= CampfireSpawnChance {
+		return
+	}
+	s, ok := g.freeSlot(i, SlotMiddle)
+	if !ok {
+		return
+	}
+	g.campfires = append(g.campfires, Campfire{X: p.slotX(s), Y: p.Y, Mount: Mount{Platform: i, Slot: s, Mounted: true}})
+}
+
+// drawCampfires draws flickering flames on their platforms; the flicker is
+// only decoration
+func (g *Game) drawCampfires(screen *ebiten.Image) {
+	for _, c := range g.campfires {
+		y := g.screenY(c.Y)
+		drawRect(screen, c.X-6, y-3, 12, 3, color.RGBA{110, 70, 40, 255}) // Logs
+		for i := 0; i < 3; i++ {
+			h := 6 + fxFloat64()*4
+			drawCircle(screen, c.X-3+float64(i)*3, y-3-h/2, h/2, color.RGBA{255, uint8(120 + 40*i), 30, 230})
+		}
+	}
+}
+
+// warmthLines labels the warmth meter in Hardcore
+func warmthLines(g *Game) []hudLine {
+	if !g.opts.hardcore || g.gameOver {
+		return nil
+	}
+	return []hudLine{{"Warmth", TextSmall}}
+}
+
+// warmthBar is the meter after the label, turning icy when nearly empty
+func warmthBar(g *Game, i int) *hudBar {
+	bar := color.RGBA{255, 150, 60, 255}
+	if g.warmth < 0.25 {
+		bar = color.RGBA{120, 180, 255, 255} // Freezing
+	}
+	return &hudBar{fill: g.warmth, color: bar}
+}
@@ -0,0 +1,209 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// weatherCount is the number of weather types, for cycling and rolling
+const weatherCount = WeatherStorm + 1
+
+// birdWeather is how one kind of weather changes bird flight
+type birdWeather struct {
+	Speed    float64 // Multiplier on horizontal speed
+	Sink     float64 // Pixels per frame birds drift down the screen
+	Presence float64 // Fraction of birds that stay out; the rest shelter above the screen
+	Jitter   float64 // Largest random vertical drift per frame
+}
+
+// birdWeathers ties bird behavior to the weather
+var birdWeathers = [weatherCount]birdWeather{
+	WeatherClear: {Speed: 1, Presence: 1},
+	WeatherRain:  {Speed: 0.7, Sink: 0.25, Presence: 1},  // Wet wings: slower and lower
+	WeatherSnow:  {Speed: 0.9, Presence: 1, Jitter: 0.8}, // Gusts knock them about
+	WeatherStorm: {Speed: 0.6, Sink: 0.25, Presence: 0.15},
+}
+
+// Lightning parameters
+const (
+	LightningWidth    = 24  // Width of the column a bolt strikes
+	LightningWarnTime = 1.0 // Seconds the column is marked before the strike
+	LightningFlash    = 0.25
+	LightningMinGap   = 3.0 // Seconds between strikes, at least
+	LightningMaxGap   = 7.0
+)
+
+// Lightning timers
+const (
+	TimerLightning timerName = "lightning" // Time until the next strike is telegraphed
+	TimerStrike    timerName = "strike"    // Telegraph running, bolt at expiry
+	TimerFlash     timerName = "flash"     // Screen flash after a strike
+)
+
+// Cloud cover parameters
+const (
+	CloudCoverFull  = 0.6 // Share of the sky's width under clouds at which rain and snow fall thickest
+	CloudCoverStep  = 4   // Width in pixels of the columns cover is measured in
+	CloudRainOffset = 0.7 // How far down a cloud, as a share of its height, its rain starts
+)
+
+// overhead reports whether cloud c hangs over the view: on screen or
+// above it, where its rain falls into view
+func (g *Game) overhead(c *Cloud) bool {
+	return g.screenY(c.Y)+c.Height*CloudRainOffset < ScreenHeight && c.X+c.Width > 0 && c.X < ScreenWidth
+}
+
+// cloudCover is the share of the screen's width with a cloud overhead
+func (g *Game) cloudCover() float64 {
+	covered, columns := 0, 0
+	for x := 0.0; x < ScreenWidth; x += CloudCoverStep {
+		columns++
+		for i := range g.clouds {
+			if c := &g.clouds[i]; g.overhead(c) && x >= c.X && x < c.X+c.Width {
+				covered++
+				break
+			}
+		}
+	}
+	return float64(covered) / float64(columns)
+}
+
+// weatherDensity scales how often rain and snow spawn by the cloud cover
+// overhead, 1 at CloudCoverFull and more
+func (g *Game) weatherDensity() float64 {
+	return min(1, g.cloudCover()/CloudCoverFull)
+}
+
+// cloudSource picks where under the clouds overhead a particle starts,
+// each cloud by how much of the screen's width it spans; false when the
+// sky is bare
+func (g *Game) cloudSource() (x, y float64, ok bool) {
+	total := 0.0
+	for i := range g.clouds {
+		if c := &g.clouds[i]; g.overhead(c) {
+			total += min(c.X+c.Width, ScreenWidth) - max(c.X, 0)
+		}
+	}
+	if total == 0 {
+		return 0, 0, false
+	}
+	roll := fxFloat64() * total // Picked for a particle, so an effect like it
+	for i := range g.clouds {
+		c := &g.clouds[i]
+		if !g.overhead(c) {
+			continue
+		}
+		left := max(c.X, 0)
+		span := min(c.X+c.Width, ScreenWidth) - left
+		if roll < span {
+			return left + min(roll, span), max(-5, g.screenY(c.Y)+c.Height*CloudRainOffset), true
+		}
+		roll -= span
+	}
+	return 0, 0, false
+}
+
+// rainy reports whether w drops rain
+func rainy(w int) bool {
+	return w == WeatherRain || w == WeatherStorm
+}
+
+// birdsActive is how many birds fly in the current weather
+func (g *Game) birdsActive() int {
+	n := int(float64(len(g.birds))*birdWeathers[g.weather].Presence + 0.5)
+	if n == 0 && len(g.birds) > 0 && birdWeathers[g.weather].Presence > 0 {
+		n = 1
+	}
+	return n
+}
+
+// updateBirdWeather applies the weather's drift to bird b, the i-th bird,
+// and reports whether it is out flying at all
+func (g *Game) updateBirdWeather(i int, b *Bird) bool {
+	if i >= g.birdsActive() {
+		// Sheltering birds wait just above the screen
+		b.Y = min(b.Y, g.worldTop()-BirdHeight*2)
+		return false
+	}
+	w := birdWeathers[g.weather]
+	b.Y += w.Sink * g.step
+	if w.Jitter > 0 {
+		b.Y += (g.rng.Float64()*2 - 1) * w.Jitter * g.step
+	}
+	return true
+}
+
+// updateLightning keeps storms striking: every few seconds a column near
+// the player is marked, then hit
+func (g *Game) updateLightning() {
+	if g.weather != WeatherStorm || g.timers.Active(TimerLightning) || g.timers.Active(TimerStrike) {
+		return
+	}
+	gap := LightningMinGap + g.rng.Float64()*(LightningMaxGap-LightningMinGap)
+	g.timers.Add(TimerLightning, gap, telegraphLightning)
+}
+
+// telegraphLightning marks where the next bolt lands
+func telegraphLightning(g *Game) {
+	if g.weather != WeatherStorm {
+		return
+	}
+	x := g.player.X + (g.rng.Float64()*2-1)*ScreenWidth/3
+	g.boltX = min(max(x, LightningWidth/2), ScreenWidth-LightningWidth/2)
+	g.timers.Add(TimerStrike, LightningWarnTime, strikeLightning)
+}
+
+// strikeLightning hits the marked column. Shields and i-frames protect.
+func strikeLightning(g *Game) {
+	if g.weather != WeatherStorm {
+		return
+	}
+	if g.flashAlpha = g.flashes.Allow(g.gameTime, 1, g.save.Settings); g.flashAlpha > 0 {
+		g.timers.Add(TimerFlash, LightningFlash, nil)
+	}
+	hit := g.player.X > g.boltX-LightningWidth/2 && g.player.X < g.boltX+LightningWidth/2
+	if !hit || g.timers.Active(TimerInvincible) {
+		return
+	}
+	if g.player.BoostType == BoostShield {
+		g.feedback(FeedbackShieldHit)
+		return
+	}
+	g.endRun(DeathLightning)
+}
+
+// drawLightning draws the strike warning, the bolt and its flash
+func (g *Game) drawLightning(screen *ebiten.Image) {
+	if t := g.timers.Get(TimerStrike); t != nil {
+		alpha := uint8(40 + 80*t.Progress())
+		drawRect(screen, g.boltX-LightningWidth/2, 0, LightningWidth, ScreenHeight, color.RGBA{alpha, alpha, 0, alpha})
+	}
+	t := g.timers.Get(TimerFlash)
+	if t == nil {
+		return
+	}
+	fade := 1 - t.Progress()
+	safe := g.save.Settings.PhotosensitiveSafe
+
+	// A jagged bolt down the struck column; the shape is only decoration.
+	// Safe mode skips the flickering bolt and only pulses.
+	if !safe {
+		bolt := color.RGBA{uint8(255 * fade), uint8(255 * fade), uint8(200 * fade), uint8(255 * fade)}
+		x, y := g.boltX, 0.0
+		for y < ScreenHeight {
+			nx := g.boltX + (fxFloat64()*2-1)*LightningWidth/2
+			ny := y + 20 + fxFloat64()*20
+			drawLine(screen, x, y, nx, ny, bolt)
+			x, y = nx, ny
+		}
+	}
+
+	// Safe pulses ease in and out instead of starting at full strength
+	shape := fade
+	if safe {
+		shape = 1 - math.Abs(2*t.Progress()-1)
+	}
+	drawRect(screen, 0, 0, ScreenWidth, ScreenHeight, flashColor(g.flashAlpha*shape, g.save.Settings))
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"doodlejump/game/config"
+)
+
+// WebhookTimeout bounds each post to the webhook
+const WebhookTimeout = 10 * time.Second
+
+// notifyRun queues a webhook notice about the run that just ended when it
+// set a new personal best, beating prevBest, or finished a daily
+// challenge. A first run sets no best to tell about.
+func (g *Game) notifyRun(prevBest int) {
+	if g.config.Webhook == nil {
+		return
+	}
+	var lines []string
+	if g.opts.daily != "" {
+		d := g.save.Daily
+		lines = append(lines, fmt.Sprintf("%s finished the daily challenge %s: %s (best %s, attempt %d)",
+			g.opts.profile, d.Date, g.resultText(g.score), g.resultText(d.Best), d.Attempts))
+	}
+	if prevBest > 0 && g.score > prevBest {
+		lines = append(lines, fmt.Sprintf("%s set a new personal best in %s: %s (was %s)",
+			g.opts.profile, g.mode, g.resultText(g.score), g.resultText(prevBest)))
+	}
+	g.notice = strings.Join(lines, "\n")
+}
+
+// resultText is a score as the mode counts it: points, or height in the
+// modes that score it
+func (g *Game) resultText(score int) string {
+	if g.mode.scoresHeight() {
+		return strconv.Itoa(score) + "m"
+	}
+	return strconv.Itoa(score)
+}
+
+// postNotice sends the queued notice in the background, sharing the frame
+// just drawn with it when the service takes images. Reading the frame needs
+// the game loop, so Draw calls this.
+func (g *Game) postNotice() {
+	if g.notice == "" || g.config.Webhook == nil {
+		return
+	}
+	text, hook := g.notice, *g.config.Webhook
+	g.notice = ""
+	var img image.Image
+	if hook.Format == config.WebhookDiscord {
+		img = g.Still(CaptureScale, true)
+	}
+	go func() {
+		if err := postWebhook(hook, text, img); err != nil {
+			log.Printf("Webhook: %v", err)
+		}
+	}()
+}
+
+// postWebhook posts text to hook in its service's format. Discord gets
+// img attached; Slack's incoming webhooks take text only.
+func postWebhook(hook config.Webhook, text string, img image.Image) error {
+	var body bytes.Buffer
+	contentType := "application/json"
+	switch hook.Format {
+	case config.WebhookSlack:
+		if err := json.NewEncoder(&body).Encode(map[string]string{"text": text}); err != nil {
+			return err
+		}
+	case config.WebhookDiscord:
+		form := multipart.NewWriter(&body)
+		payload, err := json.Marshal(map[string]any{
+			"content": text,
+			"embeds":  []any{map[string]any{"image": map[string]string{"url": "attachment://run.png"}}},
+		})
+		if err != nil {
+			return err
+		}
+		if err := form.WriteField("payload_json", string(payload)); err != nil {
+			return err
+		}
+		file, err := form.CreateFormFile("files[0]", "run.png")
+		if err != nil {
+			return err
+		}
+		if err := png.Encode(file, img); err != nil {
+			return err
+		}
+		if err := form.Close(); err != nil {
+			return err
+		}
+		contentType = form.FormDataContentType()
+	default:
+		return fmt.Errorf("unknown format %q", hook.Format)
+	}
+
+	client := &http.Client{Timeout: WebhookTimeout}
+	resp, err := client.Post(hook.URL, contentType, &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s answered %s", hook.URL, resp.Status)
+	}
+	return nil
+}
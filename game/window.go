@@ -0,0 +1,114 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"doodlejump/game/config"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// windowScale is the window's size multiplier: the render scale when one
+// was asked for, otherwise the size the window last had
+func (g *Game) windowScale() float64 {
+	if w := g.config.Window; !g.opts.scaleSet && w != nil && w.Scale > 0 {
+		return w.Scale
+	}
+	return g.opts.renderScale
+}
+
+// PlaceWindow puts the window back on the monitor and at the position it
+// had when the game last quit. A monitor named or numbered (from 1) by
+// monitor wins over the remembered one, and the window then opens
+// centered on it; "" keeps the remembered one. It fails when there is no
+// such monitor.
+func (g *Game) PlaceWindow(monitor string) error {
+	monitors := ebiten.AppendMonitors(nil)
+	last := g.config.Window
+	if monitor != "" {
+		m, i := findMonitor(monitors, monitor)
+		if m == nil {
+			return fmt.Errorf("no monitor %q, connected are: %s", monitor, monitorList(monitors))
+		}
+		ebiten.SetMonitor(m)
+		if last == nil || last.MonitorIndex != i || last.Monitor != m.Name() {
+			return nil
+		}
+	} else if last != nil {
+		m := lastMonitor(monitors, last)
+		if m == nil {
+			return nil // Unplugged since; the system picks one
+		}
+		ebiten.SetMonitor(m)
+	}
+	if last != nil {
+		ebiten.SetWindowPosition(last.X, last.Y)
+	}
+	return nil
+}
+
+// findMonitor looks monitor up by its number in the list, from 1, or its
+// name, returning it with its number
+func findMonitor(monitors []*ebiten.MonitorType, monitor string) (*ebiten.MonitorType, int) {
+	if n, err := strconv.Atoi(monitor); err == nil {
+		if n < 1 || n > len(monitors) {
+			return nil, 0
+		}
+		return monitors[n-1], n
+	}
+	for i, m := range monitors {
+		if m.Name() == monitor {
+			return m, i + 1
+		}
+	}
+	return nil, 0
+}
+
+// lastMonitor finds the monitor the window was last on: the one in the
+// same place in the list if it still has the name, else the first one
+// with the name
+func lastMonitor(monitors []*ebiten.MonitorType, w *config.Window) *ebiten.MonitorType {
+	if i := w.MonitorIndex - 1; i >= 0 && i < len(monitors) && monitors[i].Name() == w.Monitor {
+		return monitors[i]
+	}
+	m, _ := findMonitor(monitors, w.Monitor)
+	return m
+}
+
+// monitorList names the connected monitors with their numbers
+func monitorList(monitors []*ebiten.MonitorType) string {
+	names := make([]string, len(monitors))
+	for i, m := range monitors {
+		names[i] = strconv.Itoa(i+1) + " " + m.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// saveWindow remembers the window's monitor, position and size in the
+// settings for the next launch. width is the resolution across of what
+// the window shows, which the size is kept relative to.
+func (g *Game) saveWindow(width int) {
+	if g.configPath == "" {
+		return
+	}
+	w := &config.Window{}
+	w.X, w.Y = ebiten.WindowPosition()
+	if ww, _ := ebiten.WindowSize(); ww > 0 {
+		w.Scale = float64(ww) / float64(width)
+	}
+	if m := ebiten.Monitor(); m != nil {
+		w.Monitor = m.Name()
+		for i, o := range ebiten.AppendMonitors(nil) {
+			if o == m {
+				w.MonitorIndex = i + 1
+			}
+		}
+	}
+	g.config.Window = w
+	if err := g.config.Write(g.configPath); err != nil {
+		log.Printf("Failed to write settings: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+// EdgePolicy decides what happens when the player reaches a side of the screen
+type EdgePolicy int
+
+const (
+	EdgeWrap   EdgePolicy = iota // Leave one side, appear on the other
+	EdgeBounce                   // Solid walls that knock the player back
+	EdgeDeath                    // Touching a side ends the run
+)
+
+// edgePolicyNames are the names used by flags and config files
+var edgePolicyNames = map[string]EdgePolicy{
+	"wrap":   EdgeWrap,
+	"bounce": EdgeBounce,
+	"death":  EdgeDeath,
+}
+
+// ParseEdgePolicy looks up an edge policy by name
+func ParseEdgePolicy(name string) (EdgePolicy, error) {
+	if p, ok := edgePolicyNames[name]; ok {
+		return p, nil
+	}
+	return EdgeWrap, fmt.Errorf("unknown edge policy %q (want wrap, bounce or death)", name)
+}
+
+// Wall bounce parameters
+const (
+	WallBounceSpeed = 4.0  // Horizontal knockback when hitting a wall
+	WallFriction    = 0.85 // Knockback kept per frame
+)
+
+// World holds the rules of the playfield that modes and levels can vary
+type World struct {
+	Edges EdgePolicy
+}
+
+// defaultWorld is the classic endless playfield
+func defaultWorld() World {
+	return World{Edges: EdgeWrap}
+}
+
+// screenY converts a world Y coordinate to screen space
+func (g *Game) screenY(worldY float64) float64 {
+	return worldY + g.camera
+}
+
+// worldTop is the world Y coordinate of the top edge of the screen
+func (g *Game) worldTop() float64 {
+	return -g.camera
+}
+
+// SetEdgePolicy changes how the screen sides behave for the current run
+func (g *Game) SetEdgePolicy(p EdgePolicy) {
+	g.world.Edges = p
+}
+
+// applyEdges enforces the world's edge policy after horizontal movement
+func (g *Game) applyEdges() {
+	// Wall knockback decays every tick whatever the policy
+	g.player.X += g.player.VelocityX * g.step
+	g.player.VelocityX *= g.damp(WallFriction)
+	if math.Abs(g.player.VelocityX) < 0.05 {
+		g.player.VelocityX = 0
+	}
+
+	switch g.world.Edges {
+	case EdgeWrap:
+		g.player.X = g.wrapX(g.player.X)
+
+	case EdgeBounce:
+		minX, maxX := float64(PlayerWidth/2), float64(ScreenWidth-PlayerWidth/2)
+		if g.player.X < minX {
+			g.player.X = minX
+			g.player.VelocityX = WallBounceSpeed
+			g.feedback(FeedbackLanding)
+		} else if g.player.X > maxX {
+			g.player.X = maxX
+			g.player.VelocityX = -WallBounceSpeed
+			g.feedback(FeedbackLanding)
+		}
+
+	case EdgeDeath:
+		if g.player.X-PlayerWidth/3 < 0 || g.player.X+PlayerWidth/3 > ScreenWidth {
+			g.endRun(DeathEdge)
+		}
+	}
+}